@@ -1,6 +1,7 @@
 package zena
 
 import (
+	"context"
 	"encoding/hex"
 	"math"
 	"math/big"
@@ -10,9 +11,12 @@ import (
 
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/consensus"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
 	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
 	"github.com/zenanetwork/go-zenanet/rpc"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -28,9 +32,10 @@ var (
 // API is a user facing RPC API to allow controlling the signer and voting
 // mechanisms of the proof-of-authority scheme.
 type API struct {
-	chain         consensus.ChainHeaderReader
-	zena          *Zena
-	rootHashCache *lru.ARCCache
+	chain           consensus.ChainHeaderReader
+	zena            *Zena
+	rootHashCache   *lru.ARCCache
+	checkpointCache *lru.ARCCache
 }
 
 // GetSnapshot retrieves the state snapshot at a given block.
@@ -271,6 +276,289 @@ func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
 	return snap.ValidatorSet.Validators, nil
 }
 
+// ValidatorSetChange describes the validator set taking over at a given block.
+type ValidatorSetChange struct {
+	Number uint64      `json:"number"` // First block produced by the new validator set
+	Hash   common.Hash `json:"hash"`   // Hash of the block announcing the new validator set
+
+	*valset.Diff
+
+	Proposer   common.Address      `json:"proposer"`   // Proposer of the first sprint of the new validator set
+	Validators []*valset.Validator `json:"validators"` // Resulting validators with their proposer priorities
+}
+
+// SpanHistoryEntry describes a span boundary and the validator set taking over.
+type SpanHistoryEntry struct {
+	SpanID     uint64              `json:"spanId"`
+	StartBlock uint64              `json:"startBlock"`
+	EndBlock   uint64              `json:"endBlock"`
+	Change     *ValidatorSetChange `json:"change"`
+}
+
+// GetValidatorSetDiff returns every validator set change which took effect
+// between fromBlock (exclusive) and toBlock (inclusive), with the added,
+// removed and power-changed validators and the resulting proposer priorities.
+func (api *API) GetValidatorSetDiff(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*ValidatorSetChange, error) {
+	from, to, err := api.blockRange(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := api.validatorSetAt(from)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*ValidatorSetChange, 0)
+
+	for number := from + 1; number <= to; number++ {
+		if !api.zena.config.IsSprintStart(number) {
+			continue
+		}
+
+		next, err := api.validatorSetAt(number)
+		if err != nil {
+			return nil, err
+		}
+
+		if change := api.validatorSetChange(number, prev, next, false); change != nil {
+			changes = append(changes, change)
+		}
+
+		prev = next
+	}
+
+	return changes, nil
+}
+
+// GetSpanHistory returns every span boundary between fromBlock (exclusive) and
+// toBlock (inclusive) together with the change of the validator set. It reads
+// the span from the validator contract, so the state of the range is needed.
+func (api *API) GetSpanHistory(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*SpanHistoryEntry, error) {
+	from, to, err := api.blockRange(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	prevSpan, err := api.spanAt(from)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := api.validatorSetAt(from)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*SpanHistoryEntry, 0)
+
+	for number := from + 1; number <= to; number++ {
+		if !api.zena.config.IsSprintStart(number) {
+			continue
+		}
+
+		currentSpan, err := api.spanAt(number)
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := api.validatorSetAt(number)
+		if err != nil {
+			return nil, err
+		}
+
+		if currentSpan.ID != prevSpan.ID {
+			// the same validators may carry on, report the resulting priorities anyway
+			history = append(history, &SpanHistoryEntry{
+				SpanID:     currentSpan.ID,
+				StartBlock: number,
+				EndBlock:   currentSpan.EndBlock,
+				Change:     api.validatorSetChange(number, prev, next, true),
+			})
+		}
+
+		prevSpan, prev = currentSpan, next
+	}
+
+	return history, nil
+}
+
+// blockRange resolves and validates a block range of the validator set APIs.
+func (api *API) blockRange(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) (uint64, uint64, error) {
+	current := api.chain.CurrentHeader().Number.Uint64()
+
+	resolve := func(number rpc.BlockNumber) uint64 {
+		if number < 0 {
+			return current
+		}
+
+		return uint64(number.Int64())
+	}
+
+	from, to := resolve(fromBlock), resolve(toBlock)
+
+	if from > to || to > current {
+		return 0, 0, &valset.InvalidStartEndBlockError{Start: from, End: to, CurrentHeader: current}
+	}
+
+	if to-from+1 > MaxCheckpointLength {
+		return 0, 0, &MaxCheckpointLengthExceededError{from, to}
+	}
+
+	return from, to, nil
+}
+
+// validatorSetAt returns the validator set producing the given block.
+func (api *API) validatorSetAt(number uint64) (*valset.ValidatorSet, error) {
+	if number > 0 {
+		number--
+	}
+
+	header := api.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	snap, err := api.zena.snapshot(api.chain, number, header.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.ValidatorSet, nil
+}
+
+// spanAt returns the span covering the given block. Spans are committed ahead
+// of time, so the span stored in the contract may already be the next one.
+func (api *API) spanAt(number uint64) (*span.Span, error) {
+	parent := number
+	if parent > 0 {
+		parent--
+	}
+
+	header := api.chain.GetHeaderByNumber(parent)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	current, err := api.zena.spanner.GetCurrentSpan(context.Background(), header.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if number >= current.StartBlock || current.ID == 0 {
+		return current, nil
+	}
+
+	// Spans are contiguous, the previous one ends right before the current one.
+	// Its start block isn't known from this state.
+	return &span.Span{
+		ID:       current.ID - 1,
+		EndBlock: current.StartBlock - 1,
+	}, nil
+}
+
+// validatorSetChange describes the move from prev to next at the given block.
+// It returns nil if the validators and their powers are the same, unless force is set.
+func (api *API) validatorSetChange(number uint64, prev, next *valset.ValidatorSet, force bool) *ValidatorSetChange {
+	diff := valset.DiffValidatorSets(prev, next)
+	if diff.IsEmpty() && !force {
+		return nil
+	}
+
+	var hash common.Hash
+	if header := api.chain.GetHeaderByNumber(number - 1); header != nil {
+		hash = header.Hash()
+	}
+
+	next = next.Copy()
+
+	return &ValidatorSetChange{
+		Number:     number,
+		Hash:       hash,
+		Diff:       diff,
+		Proposer:   next.GetProposer().Address,
+		Validators: next.Validators,
+	}
+}
+
+// GetProducerStats returns, for every validator, the blocks produced in turn,
+// the blocks produced as a backup producer and the missed slots between
+// fromBlock and toBlock (both inclusive).
+func (api *API) GetProducerStats(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) (*ProducerStatsReport, error) {
+	from, to, err := api.blockRange(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.zena.producerStats(api.chain, from, to)
+}
+
+// GetProducerStatsBySpan returns the producer statistics of the blocks of the
+// given span produced so far.
+func (api *API) GetProducerStatsBySpan(spanID uint64) (*ProducerStatsReport, error) {
+	if api.zena.IrisClient == nil {
+		return nil, errIrisClientNotSet
+	}
+
+	irisSpan, err := api.zena.IrisClient.Span(context.Background(), spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := api.chain.CurrentHeader().Number.Uint64()
+	if irisSpan.StartBlock > current {
+		return nil, &valset.InvalidStartEndBlockError{Start: irisSpan.StartBlock, End: irisSpan.EndBlock, CurrentHeader: current}
+	}
+
+	return api.GetProducerStats(rpc.BlockNumber(irisSpan.StartBlock), rpc.BlockNumber(min(irisSpan.EndBlock, current)))
+}
+
+// SimulateStateSync executes the commit of the given candidate span, if any,
+// and of the given state-sync event records on top of the state of the given
+// block, as Finalize would do in its child block. No block is produced and the
+// state is discarded, only the gas, logs, revert reasons and state changes of
+// every system call are returned.
+func (api *API) SimulateStateSync(number rpc.BlockNumber, events []*clerk.EventRecordWithTime, irisSpan *span.IrisSpan) (*StateSyncSimulation, error) {
+	var header *types.Header
+	if number < 0 {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	chain, ok := api.chain.(stateProvider)
+	if !ok {
+		return nil, errStateUnavailable
+	}
+
+	statedb, err := chain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.zena.simulateSystemCalls(api.chain, statedb, header, irisSpan, events)
+}
+
+// GetParams returns the zena parameters in effect at a given block, which
+// may be in the future. It defaults to the current block.
+func (api *API) GetParams(number *rpc.BlockNumber) (*params.ZenaParams, error) {
+	if number == nil || *number < 0 {
+		return api.zena.config.ParamsAt(api.chain.CurrentHeader().Number.Uint64()), nil
+	}
+
+	return api.zena.config.ParamsAt(uint64(number.Int64())), nil
+}
+
+// GetParamsSchedule returns the zena parameters in effect from every block at
+// which a parameter changes or a zena fork activates.
+func (api *API) GetParamsSchedule() []*params.ZenaParams {
+	return api.zena.config.ParamsSchedule()
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	if err := api.initializeRootHashCache(); err != nil {
@@ -295,32 +583,15 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 		return "", &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
 	}
 
-	blockHeaders := make([]*types.Header, end-start+1)
-	wg := new(sync.WaitGroup)
-	concurrent := make(chan bool, 20)
-
-	for i := start; i <= end; i++ {
-		wg.Add(1)
-		concurrent <- true
-
-		go func(number uint64) {
-			blockHeaders[number-start] = api.chain.GetHeaderByNumber(number)
-
-			<-concurrent
-			wg.Done()
-		}(i)
+	blockHeaders, err := api.getHeaders(start, end)
+	if err != nil {
+		return "", err
 	}
-	wg.Wait()
-	close(concurrent)
 
 	headers := make([][32]byte, nextPowerOfTwo(length))
 
 	for i := 0; i < len(blockHeaders); i++ {
 		blockHeader := blockHeaders[i]
-		// Handle no header case, which is possible if ancient pruning was done
-		if blockHeader == nil {
-			return "", errUnknownBlock
-		}
 		header := crypto.Keccak256(appendBytes32(
 			blockHeader.Number.Bytes(),
 			new(big.Int).SetUint64(blockHeader.Time).Bytes(),
@@ -345,6 +616,36 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	return root, nil
 }
 
+// getHeaders returns the headers of the blocks from start to end.
+func (api *API) getHeaders(start uint64, end uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, end-start+1)
+	wg := new(sync.WaitGroup)
+	concurrent := make(chan bool, 20)
+
+	for i := start; i <= end; i++ {
+		wg.Add(1)
+		concurrent <- true
+
+		go func(number uint64) {
+			headers[number-start] = api.chain.GetHeaderByNumber(number)
+
+			<-concurrent
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+	close(concurrent)
+
+	for _, header := range headers {
+		// Handle no header case, which is possible if ancient pruning was done
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+	}
+
+	return headers, nil
+}
+
 func (api *API) initializeRootHashCache() error {
 	var err error
 	if api.rootHashCache == nil {
@@ -70,23 +70,11 @@ func (gc *GenesisContractsClient) CommitState(
 	header *types.Header,
 	chCtx statefull.ChainContext,
 ) (uint64, error) {
-	eventRecord := event.BuildEventRecord()
-
-	recordBytes, err := rlp.EncodeToBytes(eventRecord)
+	data, err := PackCommitState(gc.stateReceiverABI, event)
 	if err != nil {
 		return 0, err
 	}
 
-	const method = "commitState"
-
-	t := event.Time.Unix()
-
-	data, err := gc.stateReceiverABI.Pack(method, big.NewInt(0).SetInt64(t), recordBytes)
-	if err != nil {
-		log.Error("Unable to pack tx for commitState", "error", err)
-		return 0, err
-	}
-
 	msg := statefull.GetSystemMessage(common.HexToAddress(gc.StateReceiverContract), data)
 
 	log.Info("→ committing new state", "eventRecord", event.ID)
@@ -103,6 +91,29 @@ func (gc *GenesisContractsClient) CommitState(
 	return gasUsed, nil
 }
 
+// PackCommitState packs the call data of the commitState system call which
+// delivers the given event record to the state receiver contract.
+func PackCommitState(stateReceiverABI abi.ABI, event *clerk.EventRecordWithTime) ([]byte, error) {
+	eventRecord := event.BuildEventRecord()
+
+	recordBytes, err := rlp.EncodeToBytes(eventRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	const method = "commitState"
+
+	t := event.Time.Unix()
+
+	data, err := stateReceiverABI.Pack(method, big.NewInt(0).SetInt64(t), recordBytes)
+	if err != nil {
+		log.Error("Unable to pack tx for commitState", "error", err)
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func (gc *GenesisContractsClient) LastStateId(state *state.StateDB, number uint64, hash common.Hash) (*big.Int, error) {
 	blockNr := rpc.BlockNumber(number)
 
@@ -0,0 +1,193 @@
+package zena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/inclusion"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/types"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+var (
+	// errNotCheckpointed is returned if an inclusion proof is requested for a
+	// block which isn't covered by any Iris checkpoint yet.
+	errNotCheckpointed = errors.New("block not checkpointed yet")
+
+	// errCheckpointMismatch is returned if the root hash of the local blocks
+	// differs from the root hash of the checkpoint covering them.
+	errCheckpointMismatch = errors.New("local blocks don't match the checkpoint root hash")
+
+	// errUnknownTransaction is returned if a receipt inclusion proof is
+	// requested for a transaction which isn't indexed.
+	errUnknownTransaction = errors.New("unknown transaction")
+
+	// errReceiptsUnavailable is returned if the chain can't look up the
+	// transactions and their receipts.
+	errReceiptsUnavailable = errors.New("receipts not available")
+)
+
+// receiptProvider is implemented by the chains which can look up the
+// transactions and their receipts.
+type receiptProvider interface {
+	GetTransactionLookup(hash common.Hash) (*rawdb.LegacyTxLookupEntry, *types.Transaction, error)
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// GetBlockInclusionProof returns the Merkle proof of the inclusion of a block
+// under the root hash of the Iris checkpoint covering it.
+func (api *API) GetBlockInclusionProof(ctx context.Context, number uint64) (*inclusion.BlockProof, error) {
+	header := api.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	checkpointNumber, cp, err := api.coveringCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := cp.StartBlock.Uint64(), cp.EndBlock.Uint64()
+	if end-start+1 > MaxCheckpointLength {
+		return nil, &MaxCheckpointLengthExceededError{start, end}
+	}
+
+	headers, err := api.getHeaders(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make([]common.Hash, len(headers))
+	for i, header := range headers {
+		leaves[i] = inclusion.HeaderLeaf(header)
+	}
+
+	proof := &inclusion.BlockProof{
+		Checkpoint:  uint64(checkpointNumber),
+		StartBlock:  start,
+		EndBlock:    end,
+		RootHash:    cp.RootHash,
+		Number:      number,
+		Timestamp:   header.Time,
+		TxHash:      header.TxHash,
+		ReceiptHash: header.ReceiptHash,
+		Path:        inclusion.Prove(leaves, number-start),
+	}
+
+	// Make sure the proof holds, the local chain may differ from the checkpoint
+	if err := inclusion.VerifyBlock(proof, cp.RootHash); err != nil {
+		return nil, fmt.Errorf("%w: checkpoint %d: %v", errCheckpointMismatch, checkpointNumber, err)
+	}
+
+	return proof, nil
+}
+
+// GetReceiptInclusionProof returns the Merkle proof of the inclusion of the
+// receipt of a transaction in its block, along with the proof of the inclusion
+// of the block under the root hash of the Iris checkpoint covering it.
+func (api *API) GetReceiptInclusionProof(ctx context.Context, hash common.Hash) (*inclusion.ReceiptProof, error) {
+	chain, ok := api.chain.(receiptProvider)
+	if !ok {
+		return nil, errReceiptsUnavailable
+	}
+
+	lookup, _, err := chain.GetTransactionLookup(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if lookup == nil {
+		return nil, errUnknownTransaction
+	}
+
+	receipts := chain.GetReceiptsByHash(lookup.BlockHash)
+	if receipts == nil {
+		return nil, errReceiptsUnavailable
+	}
+
+	nodes, receipt, err := inclusion.ProveReceipt(receipts, lookup.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := api.GetBlockInclusionProof(ctx, lookup.BlockIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inclusion.ReceiptProof{
+		Block:   block,
+		TxHash:  hash,
+		TxIndex: lookup.Index,
+		Receipt: receipt,
+		Nodes:   nodes,
+	}, nil
+}
+
+// coveringCheckpoint searches the Iris checkpoint covering a block, the
+// checkpoints covering consecutive ranges of blocks in their order.
+func (api *API) coveringCheckpoint(ctx context.Context, number uint64) (int64, *checkpoint.Checkpoint, error) {
+	if api.zena.IrisClient == nil {
+		return 0, nil, errIrisClientNotSet
+	}
+
+	count, err := api.zena.IrisClient.FetchCheckpointCount(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for low, high := int64(1), count; low <= high; {
+		mid := low + (high-low)/2
+
+		cp, err := api.fetchCheckpoint(ctx, mid)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch {
+		case number < cp.StartBlock.Uint64():
+			high = mid - 1
+		case number > cp.EndBlock.Uint64():
+			low = mid + 1
+		default:
+			return mid, cp, nil
+		}
+	}
+
+	return 0, nil, errNotCheckpointed
+}
+
+// fetchCheckpoint returns a checkpoint from Iris, the checkpoints never
+// changing once submitted.
+func (api *API) fetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	if err := api.initializeCheckpointCache(); err != nil {
+		return nil, err
+	}
+
+	if cp, known := api.checkpointCache.Get(number); known {
+		return cp.(*checkpoint.Checkpoint), nil
+	}
+
+	cp, err := api.zena.IrisClient.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	api.checkpointCache.Add(number, cp)
+
+	return cp, nil
+}
+
+func (api *API) initializeCheckpointCache() error {
+	var err error
+	if api.checkpointCache == nil {
+		api.checkpointCache, err = lru.NewARC(64)
+	}
+
+	return err
+}
@@ -0,0 +1,212 @@
+// Package inclusion builds and verifies the Merkle proofs of the inclusion of
+// blocks, and of their receipts, under the root hashes of the Iris checkpoints.
+package inclusion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/rlp"
+	"github.com/zenanetwork/go-zenanet/trie"
+	"github.com/zenanetwork/go-zenanet/trie/trienode"
+	"github.com/zenanetwork/go-zenanet/triedb"
+)
+
+var (
+	// ErrInvalidProof is returned if a proof doesn't lead to the root hash.
+	ErrInvalidProof = errors.New("invalid inclusion proof")
+
+	// ErrBlockOutOfRange is returned if the block of a proof isn't covered by
+	// its checkpoint.
+	ErrBlockOutOfRange = errors.New("block out of checkpoint range")
+)
+
+// BlockProof proves the inclusion of a block under the root hash of the Iris
+// checkpoint covering it. The leaf of the block is derived from its number,
+// timestamp, transactions root and receipts root.
+type BlockProof struct {
+	Checkpoint  uint64        `json:"checkpoint"` // Number of the Iris checkpoint covering the block
+	StartBlock  uint64        `json:"startBlock"`
+	EndBlock    uint64        `json:"endBlock"`
+	RootHash    common.Hash   `json:"rootHash"`
+	Number      uint64        `json:"number"`
+	Timestamp   uint64        `json:"timestamp"`
+	TxHash      common.Hash   `json:"transactionsRoot"`
+	ReceiptHash common.Hash   `json:"receiptsRoot"`
+	Path        []common.Hash `json:"path"` // Sibling hashes from the leaf of the block up to the root
+}
+
+// ReceiptProof proves the inclusion of a receipt in the receipt trie of a block,
+// and of the block under the root hash of the checkpoint covering it.
+type ReceiptProof struct {
+	Block   *BlockProof     `json:"block"`
+	TxHash  common.Hash     `json:"transactionHash"`
+	TxIndex uint64          `json:"transactionIndex"`
+	Receipt hexutil.Bytes   `json:"receipt"` // Consensus encoding of the receipt
+	Nodes   []hexutil.Bytes `json:"nodes"`   // Nodes of the receipt trie from its root to the receipt
+}
+
+// Leaf returns the leaf of a block in the Merkle tree of a checkpoint.
+func Leaf(number, timestamp uint64, txHash, receiptHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(
+		common.BigToHash(new(big.Int).SetUint64(number)).Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(timestamp)).Bytes(),
+		txHash.Bytes(),
+		receiptHash.Bytes(),
+	)
+}
+
+// HeaderLeaf returns the leaf of a block header in the Merkle tree of a
+// checkpoint.
+func HeaderLeaf(header *types.Header) common.Hash {
+	return Leaf(header.Number.Uint64(), header.Time, header.TxHash, header.ReceiptHash)
+}
+
+// depth returns the depth of the Merkle tree of a number of leaves, padded with
+// empty leaves up to the next power of two.
+func depth(leaves uint64) int {
+	d := 0
+	for width := uint64(1); width < leaves; width <<= 1 {
+		d++
+	}
+
+	return d
+}
+
+// Prove returns the sibling hashes from the leaf at index up to the root of the
+// Merkle tree of the leaves, padded with empty leaves up to the next power of
+// two as for the checkpoint root hashes.
+func Prove(leaves []common.Hash, index uint64) []common.Hash {
+	level := make([]common.Hash, 1<<depth(uint64(len(leaves))))
+	copy(level, leaves)
+
+	path := make([]common.Hash, 0, depth(uint64(len(leaves))))
+
+	for len(level) > 1 {
+		path = append(path, level[index^1])
+
+		next := make([]common.Hash, len(level)/2)
+		for i := range next {
+			next[i] = crypto.Keccak256Hash(level[2*i].Bytes(), level[2*i+1].Bytes())
+		}
+
+		level, index = next, index/2
+	}
+
+	return path
+}
+
+// RootFromPath returns the root of the Merkle tree reached from the leaf at
+// index with its sibling hashes.
+func RootFromPath(leaf common.Hash, index uint64, path []common.Hash) common.Hash {
+	hash := leaf
+
+	for _, sibling := range path {
+		if index%2 == 0 {
+			hash = crypto.Keccak256Hash(hash.Bytes(), sibling.Bytes())
+		} else {
+			hash = crypto.Keccak256Hash(sibling.Bytes(), hash.Bytes())
+		}
+
+		index /= 2
+	}
+
+	return hash
+}
+
+// VerifyBlock verifies a block proof against the root hash of the checkpoint
+// covering the block, which must be known to be valid.
+func VerifyBlock(proof *BlockProof, rootHash common.Hash) error {
+	if proof.StartBlock > proof.EndBlock || proof.Number < proof.StartBlock || proof.Number > proof.EndBlock {
+		return fmt.Errorf("%w: block %d, checkpoint %d-%d", ErrBlockOutOfRange, proof.Number, proof.StartBlock, proof.EndBlock)
+	}
+
+	if len(proof.Path) != depth(proof.EndBlock-proof.StartBlock+1) {
+		return fmt.Errorf("%w: path of %d hashes for %d blocks", ErrInvalidProof, len(proof.Path), proof.EndBlock-proof.StartBlock+1)
+	}
+
+	leaf := Leaf(proof.Number, proof.Timestamp, proof.TxHash, proof.ReceiptHash)
+	if root := RootFromPath(leaf, proof.Number-proof.StartBlock, proof.Path); root != rootHash {
+		return fmt.Errorf("%w: root %s, want %s", ErrInvalidProof, root, rootHash)
+	}
+
+	return nil
+}
+
+// ProveReceipt returns the nodes of the receipt trie of a block from its root
+// to the receipt at index, along with the consensus encoding of the receipt.
+func ProveReceipt(receipts types.Receipts, index uint64) ([]hexutil.Bytes, hexutil.Bytes, error) {
+	if index >= uint64(receipts.Len()) {
+		return nil, nil, fmt.Errorf("receipt %d out of %d", index, receipts.Len())
+	}
+
+	var (
+		tr  = trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+		buf = new(bytes.Buffer)
+	)
+
+	for i := 0; i < receipts.Len(); i++ {
+		buf.Reset()
+		receipts.EncodeIndex(i, buf)
+
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), common.CopyBytes(buf.Bytes())); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	proof := trienode.NewProofSet()
+	if err := tr.Prove(rlp.AppendUint64(nil, index), proof); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make([]hexutil.Bytes, 0, proof.KeyCount())
+	for _, node := range proof.List() {
+		nodes = append(nodes, node)
+	}
+
+	buf.Reset()
+	receipts.EncodeIndex(int(index), buf)
+
+	return nodes, common.CopyBytes(buf.Bytes()), nil
+}
+
+// VerifyReceipt verifies a receipt proof against the root hash of the
+// checkpoint covering its block, which must be known to be valid, and returns
+// the proven receipt.
+func VerifyReceipt(proof *ReceiptProof, rootHash common.Hash) (*types.Receipt, error) {
+	if proof.Block == nil {
+		return nil, fmt.Errorf("%w: missing block proof", ErrInvalidProof)
+	}
+
+	if err := VerifyBlock(proof.Block, rootHash); err != nil {
+		return nil, err
+	}
+
+	nodes := make(trienode.ProofList, 0, len(proof.Nodes))
+	for _, node := range proof.Nodes {
+		nodes = append(nodes, rlp.RawValue(node))
+	}
+
+	value, err := trie.VerifyProof(proof.Block.ReceiptHash, rlp.AppendUint64(nil, proof.TxIndex), nodes.Set())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	if !bytes.Equal(value, proof.Receipt) {
+		return nil, fmt.Errorf("%w: receipt doesn't match the receipt trie", ErrInvalidProof)
+	}
+
+	receipt := new(types.Receipt)
+	if err := receipt.UnmarshalBinary(value); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
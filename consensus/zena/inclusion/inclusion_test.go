@@ -0,0 +1,140 @@
+package inclusion
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsleonard/go-merkle"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/trie"
+)
+
+// merkleRoot returns the root of the leaves as computed for the checkpoints.
+func merkleRoot(t *testing.T, leaves []common.Hash) common.Hash {
+	t.Helper()
+
+	data := make([][]byte, 1<<depth(uint64(len(leaves))))
+	for i := range data {
+		data[i] = make([]byte, common.HashLength)
+		if i < len(leaves) {
+			copy(data[i], leaves[i].Bytes())
+		}
+	}
+
+	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
+	require.NoError(t, tree.Generate(data, sha3.NewLegacyKeccak256()))
+
+	return common.BytesToHash(tree.Root().Hash)
+}
+
+func testHeaders(start, end uint64) []*types.Header {
+	headers := make([]*types.Header, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		headers = append(headers, &types.Header{
+			Number:      new(big.Int).SetUint64(number),
+			Time:        1_700_000_000 + 2*number,
+			TxHash:      common.BigToHash(new(big.Int).SetUint64(number)),
+			ReceiptHash: common.BigToHash(new(big.Int).SetUint64(number * 7)),
+		})
+	}
+
+	return headers
+}
+
+func TestBlockProof(t *testing.T) {
+	t.Parallel()
+
+	for _, length := range []uint64{1, 2, 5, 8, 13} {
+		start := uint64(100)
+		end := start + length - 1
+		headers := testHeaders(start, end)
+
+		leaves := make([]common.Hash, len(headers))
+		for i, header := range headers {
+			leaves[i] = HeaderLeaf(header)
+		}
+
+		root := merkleRoot(t, leaves)
+
+		for i, header := range headers {
+			proof := &BlockProof{
+				StartBlock:  start,
+				EndBlock:    end,
+				RootHash:    root,
+				Number:      header.Number.Uint64(),
+				Timestamp:   header.Time,
+				TxHash:      header.TxHash,
+				ReceiptHash: header.ReceiptHash,
+				Path:        Prove(leaves, uint64(i)),
+			}
+			require.NoError(t, VerifyBlock(proof, root), "length %d, block %d", length, i)
+
+			// Any change to the block breaks the proof
+			proof.Timestamp++
+			require.ErrorIs(t, VerifyBlock(proof, root), ErrInvalidProof)
+			proof.Timestamp--
+
+			proof.Number = end + 1
+			require.ErrorIs(t, VerifyBlock(proof, root), ErrBlockOutOfRange)
+		}
+	}
+}
+
+func TestReceiptProof(t *testing.T) {
+	t.Parallel()
+
+	receipts := types.Receipts{
+		&types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21_000, Logs: []*types.Log{}},
+		&types.Receipt{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 50_000, Logs: []*types.Log{}},
+		&types.Receipt{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 90_000, Logs: []*types.Log{
+			{Address: common.Address{0x01}, Topics: []common.Hash{{0x02}}, Data: []byte{0x03}},
+		}},
+	}
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+
+	header := &types.Header{
+		Number:      big.NewInt(10),
+		Time:        1_700_000_000,
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.DeriveSha(receipts, trie.NewStackTrie(nil)),
+	}
+	leaves := []common.Hash{HeaderLeaf(header), {}, {0x01}}
+	root := merkleRoot(t, leaves)
+
+	block := &BlockProof{
+		StartBlock:  10,
+		EndBlock:    12,
+		RootHash:    root,
+		Number:      10,
+		Timestamp:   header.Time,
+		TxHash:      header.TxHash,
+		ReceiptHash: header.ReceiptHash,
+		Path:        Prove(leaves, 0),
+	}
+
+	for i := range receipts {
+		nodes, encoded, err := ProveReceipt(receipts, uint64(i))
+		require.NoError(t, err)
+
+		proof := &ReceiptProof{Block: block, TxIndex: uint64(i), Receipt: encoded, Nodes: nodes}
+
+		receipt, err := VerifyReceipt(proof, root)
+		require.NoError(t, err)
+		require.Equal(t, receipts[i].Status, receipt.Status)
+		require.Equal(t, receipts[i].CumulativeGasUsed, receipt.CumulativeGasUsed)
+
+		// The receipt of another transaction doesn't match the proof
+		proof.TxIndex = uint64((i + 1) % len(receipts))
+		_, err = VerifyReceipt(proof, root)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	}
+
+	_, _, err := ProveReceipt(receipts, uint64(len(receipts)))
+	require.Error(t, err)
+}
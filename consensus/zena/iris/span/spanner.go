@@ -288,6 +288,29 @@ func (c *ChainSpanner) GetCurrentValidatorsByHash(ctx context.Context, headerHas
 const method = "commitSpan"
 
 func (c *ChainSpanner) CommitSpan(ctx context.Context, irisSpan IrisSpan, state *state.StateDB, header *types.Header, chainContext core.ChainContext) error {
+	data, err := PackCommitSpan(c.validatorSet, irisSpan)
+	if err != nil {
+		return err
+	}
+
+	log.Info("✅ Committing new span",
+		"id", irisSpan.ID,
+		"startBlock", irisSpan.StartBlock,
+		"endBlock", irisSpan.EndBlock,
+	)
+
+	// get system message
+	msg := statefull.GetSystemMessage(c.validatorContractAddress, data)
+
+	// apply message
+	_, err = statefull.ApplyMessage(ctx, msg, state, header, c.chainConfig, chainContext)
+
+	return err
+}
+
+// PackCommitSpan packs the call data of the commitSpan system call which
+// stores the given span in the validator set contract.
+func PackCommitSpan(validatorSet abi.ABI, irisSpan IrisSpan) ([]byte, error) {
 	// get validators bytes
 	validators := make([]valset.MinimalVal, 0, len(irisSpan.ValidatorSet.Validators))
 	for _, val := range irisSpan.ValidatorSet.Validators {
@@ -296,7 +319,7 @@ func (c *ChainSpanner) CommitSpan(ctx context.Context, irisSpan IrisSpan, state
 
 	validatorBytes, err := rlp.EncodeToBytes(validators)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// get producers bytes
@@ -307,18 +330,16 @@ func (c *ChainSpanner) CommitSpan(ctx context.Context, irisSpan IrisSpan, state
 
 	producerBytes, err := rlp.EncodeToBytes(producers)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Info("✅ Committing new span",
+	log.Debug("Packing commitSpan",
 		"id", irisSpan.ID,
-		"startBlock", irisSpan.StartBlock,
-		"endBlock", irisSpan.EndBlock,
 		"validatorBytes", hex.EncodeToString(validatorBytes),
 		"producerBytes", hex.EncodeToString(producerBytes),
 	)
 
-	data, err := c.validatorSet.Pack(method,
+	data, err := validatorSet.Pack(method,
 		big.NewInt(0).SetUint64(irisSpan.ID),
 		big.NewInt(0).SetUint64(irisSpan.StartBlock),
 		big.NewInt(0).SetUint64(irisSpan.EndBlock),
@@ -328,14 +349,8 @@ func (c *ChainSpanner) CommitSpan(ctx context.Context, irisSpan IrisSpan, state
 	if err != nil {
 		log.Error("Unable to pack tx for commitSpan", "error", err)
 
-		return err
+		return nil, err
 	}
 
-	// get system message
-	msg := statefull.GetSystemMessage(c.validatorContractAddress, data)
-
-	// apply message
-	_, err = statefull.ApplyMessage(ctx, msg, state, header, c.chainConfig, chainContext)
-
-	return err
+	return data, nil
 }
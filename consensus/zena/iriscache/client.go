@@ -0,0 +1,155 @@
+// Package iriscache implements an IIrisClient decorator which persists
+// finalized Iris responses in the local database.
+package iriscache
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zenanetwork/go-zenanet/consensus/zena"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// IrisCacheClient serves spans, numbered checkpoints and state-sync events
+// from the database and only reaches out to the wrapped client on a miss.
+// These responses never change once Iris has returned them, so they can be
+// kept forever. Latest checkpoint, milestone and no-ack queries are always
+// forwarded as they change over time.
+type IrisCacheClient struct {
+	client zena.IIrisClient
+	db     ethdb.KeyValueStore
+}
+
+var _ zena.IIrisClient = (*IrisCacheClient)(nil)
+
+// NewIrisCacheClient wraps the given client with a cache backed by db.
+func NewIrisCacheClient(client zena.IIrisClient, db ethdb.KeyValueStore) *IrisCacheClient {
+	return &IrisCacheClient{
+		client: client,
+		db:     db,
+	}
+}
+
+func (c *IrisCacheClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	if to >= 0 {
+		if blob := rawdb.ReadIrisStateSyncEvents(c.db, fromID, uint64(to)); len(blob) > 0 {
+			var events []*clerk.EventRecordWithTime
+			if err := json.Unmarshal(blob, &events); err == nil {
+				stateSyncHitMeter.Mark(1)
+				return events, nil
+			}
+
+			log.Warn("Dropping corrupted iris state-sync cache entry", "fromID", fromID, "to", to)
+			_ = rawdb.DeleteIrisStateSyncEvents(c.db, fromID, uint64(to))
+		}
+	}
+
+	stateSyncMissMeter.Mark(1)
+
+	events, err := c.client.StateSyncEvents(ctx, fromID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty page may only mean that Iris hasn't caught up with the
+	// root chain yet, so only non-empty responses are kept.
+	if to >= 0 && len(events) > 0 {
+		if blob, err := json.Marshal(events); err == nil {
+			_ = rawdb.WriteIrisStateSyncEvents(c.db, fromID, uint64(to), blob)
+		}
+	}
+
+	return events, nil
+}
+
+func (c *IrisCacheClient) Span(ctx context.Context, spanID uint64) (*span.IrisSpan, error) {
+	if blob := rawdb.ReadIrisSpan(c.db, spanID); len(blob) > 0 {
+		res := new(span.IrisSpan)
+		if err := json.Unmarshal(blob, res); err == nil {
+			spanHitMeter.Mark(1)
+			return res, nil
+		}
+
+		log.Warn("Dropping corrupted iris span cache entry", "spanID", spanID)
+		_ = rawdb.DeleteIrisSpan(c.db, spanID)
+	}
+
+	spanMissMeter.Mark(1)
+
+	res, err := c.client.Span(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if blob, err := json.Marshal(res); err == nil {
+		_ = rawdb.WriteIrisSpan(c.db, spanID, blob)
+	}
+
+	return res, nil
+}
+
+func (c *IrisCacheClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	// -1 stands for the latest checkpoint, which isn't final
+	if number < 0 {
+		return c.client.FetchCheckpoint(ctx, number)
+	}
+
+	if blob := rawdb.ReadIrisCheckpoint(c.db, uint64(number)); len(blob) > 0 {
+		res := new(checkpoint.Checkpoint)
+		if err := json.Unmarshal(blob, res); err == nil {
+			checkpointHitMeter.Mark(1)
+			return res, nil
+		}
+
+		log.Warn("Dropping corrupted iris checkpoint cache entry", "number", number)
+		_ = rawdb.DeleteIrisCheckpoint(c.db, uint64(number))
+	}
+
+	checkpointMissMeter.Mark(1)
+
+	res, err := c.client.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if blob, err := json.Marshal(res); err == nil {
+		_ = rawdb.WriteIrisCheckpoint(c.db, uint64(number), blob)
+	}
+
+	return res, nil
+}
+
+func (c *IrisCacheClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return c.client.FetchCheckpointCount(ctx)
+}
+
+func (c *IrisCacheClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	return c.client.FetchMilestone(ctx)
+}
+
+func (c *IrisCacheClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return c.client.FetchMilestoneCount(ctx)
+}
+
+func (c *IrisCacheClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return c.client.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (c *IrisCacheClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return c.client.FetchLastNoAckMilestone(ctx)
+}
+
+func (c *IrisCacheClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return c.client.FetchMilestoneID(ctx, milestoneID)
+}
+
+// Close closes the wrapped client. The database is owned by the caller.
+func (c *IrisCacheClient) Close() {
+	c.client.Close()
+}
@@ -0,0 +1,142 @@
+package iriscache
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingClient is an IIrisClient counting the requests reaching it.
+type countingClient struct {
+	events      []*clerk.EventRecordWithTime
+	checkpoints int
+	spans       int
+	stateSyncs  int
+}
+
+func (c *countingClient) StateSyncEvents(context.Context, uint64, int64) ([]*clerk.EventRecordWithTime, error) {
+	c.stateSyncs++
+	return c.events, nil
+}
+
+func (c *countingClient) Span(_ context.Context, spanID uint64) (*span.IrisSpan, error) {
+	c.spans++
+
+	return &span.IrisSpan{
+		Span:    span.Span{ID: spanID, StartBlock: spanID * 6400, EndBlock: (spanID+1)*6400 - 1},
+		ChainID: "1",
+	}, nil
+}
+
+func (c *countingClient) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	c.checkpoints++
+
+	return &checkpoint.Checkpoint{
+		StartBlock: big.NewInt(number * 256),
+		EndBlock:   big.NewInt((number+1)*256 - 1),
+		RootHash:   common.BigToHash(big.NewInt(number)),
+	}, nil
+}
+
+func (c *countingClient) FetchCheckpointCount(context.Context) (int64, error) { return 0, nil }
+
+func (c *countingClient) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	return &milestone.Milestone{}, nil
+}
+
+func (c *countingClient) FetchMilestoneCount(context.Context) (int64, error)      { return 0, nil }
+func (c *countingClient) FetchNoAckMilestone(context.Context, string) error       { return nil }
+func (c *countingClient) FetchLastNoAckMilestone(context.Context) (string, error) { return "", nil }
+func (c *countingClient) FetchMilestoneID(context.Context, string) error          { return nil }
+func (c *countingClient) Close()                                                  {}
+
+func TestSpanCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &countingClient{}
+		client  = NewIrisCacheClient(backend, db)
+	)
+
+	first, err := client.Span(context.Background(), 3)
+	require.NoError(t, err)
+
+	second, err := client.Span(context.Background(), 3)
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+	require.Equal(t, 1, backend.spans)
+
+	// A new cache on top of the same database doesn't hit the backend either.
+	_, err = NewIrisCacheClient(backend, db).Span(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, backend.spans)
+}
+
+func TestCheckpointCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend = &countingClient{}
+		client  = NewIrisCacheClient(backend, rawdb.NewMemoryDatabase())
+	)
+
+	for i := 0; i < 2; i++ {
+		res, err := client.FetchCheckpoint(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, int64(5*256), res.StartBlock.Int64())
+	}
+
+	require.Equal(t, 1, backend.checkpoints)
+
+	// The latest checkpoint is never cached.
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchCheckpoint(context.Background(), -1)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 3, backend.checkpoints)
+}
+
+func TestStateSyncCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend = &countingClient{}
+		client  = NewIrisCacheClient(backend, rawdb.NewMemoryDatabase())
+		to      = time.Now().Unix()
+	)
+
+	// Empty responses aren't cached.
+	for i := 0; i < 2; i++ {
+		events, err := client.StateSyncEvents(context.Background(), 1, to)
+		require.NoError(t, err)
+		require.Empty(t, events)
+	}
+
+	require.Equal(t, 2, backend.stateSyncs)
+
+	backend.events = []*clerk.EventRecordWithTime{
+		{EventRecord: clerk.EventRecord{ID: 1, Data: []byte{0x01}}, Time: time.Unix(to-10, 0).UTC()},
+		{EventRecord: clerk.EventRecord{ID: 2, Data: []byte{0x02}}, Time: time.Unix(to-5, 0).UTC()},
+	}
+
+	for i := 0; i < 2; i++ {
+		events, err := client.StateSyncEvents(context.Background(), 1, to)
+		require.NoError(t, err)
+		require.Equal(t, backend.events, events)
+	}
+
+	require.Equal(t, 3, backend.stateSyncs)
+}
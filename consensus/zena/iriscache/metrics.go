@@ -0,0 +1,14 @@
+package iriscache
+
+import (
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+var (
+	spanHitMeter        = metrics.NewRegisteredMeter("client/cache/span/hit", nil)
+	spanMissMeter       = metrics.NewRegisteredMeter("client/cache/span/miss", nil)
+	checkpointHitMeter  = metrics.NewRegisteredMeter("client/cache/checkpoint/hit", nil)
+	checkpointMissMeter = metrics.NewRegisteredMeter("client/cache/checkpoint/miss", nil)
+	stateSyncHitMeter   = metrics.NewRegisteredMeter("client/cache/statesync/hit", nil)
+	stateSyncMissMeter  = metrics.NewRegisteredMeter("client/cache/statesync/miss", nil)
+)
@@ -0,0 +1,458 @@
+// Package irispool implements an IIrisClient which spreads requests over
+// several Iris endpoints, failing over between them and optionally requiring
+// an N-of-M agreement for finality related responses.
+package irispool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+var (
+	// ErrNoMembers is returned when the pool is created without any member.
+	ErrNoMembers = errors.New("iris pool has no members")
+
+	// ErrNoHealthyMember is returned when every member of the pool failed to
+	// answer a request.
+	ErrNoHealthyMember = errors.New("no healthy iris member available")
+
+	// ErrNoQuorum is returned when the members of the pool didn't agree on
+	// a response in quorum mode.
+	ErrNoQuorum = errors.New("iris members did not reach quorum")
+)
+
+const (
+	// DefaultAttemptTimeout bounds a single request against one member. The
+	// HTTP client retries internally until its context expires, so this is
+	// what allows the pool to move on to the next member.
+	DefaultAttemptTimeout = 15 * time.Second
+
+	// DefaultHealthCheckInterval is the interval between two health probes
+	// of every member.
+	DefaultHealthCheckInterval = 10 * time.Second
+
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Member is a named Iris client participating in the pool.
+type Member struct {
+	Name   string
+	Client zena.IIrisClient
+}
+
+// Config holds the settings of the pool.
+type Config struct {
+	// Quorum is the number of members which must return an identical span,
+	// checkpoint or milestone. Zero or one disables the agreement mode.
+	Quorum int
+
+	// AttemptTimeout bounds a single request against one member.
+	AttemptTimeout time.Duration
+
+	// HealthCheckInterval is the interval between two health probes.
+	HealthCheckInterval time.Duration
+}
+
+type member struct {
+	Member
+
+	healthy atomic.Bool
+}
+
+// IrisPoolClient is an IIrisClient backed by several Iris endpoints.
+type IrisPoolClient struct {
+	members []*member
+	config  Config
+
+	// primary is the index of the member which is tried first
+	primary atomic.Int32
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+var _ zena.IIrisClient = (*IrisPoolClient)(nil)
+
+// NewIrisPoolClient creates a pool out of the given members and starts the
+// background health checks.
+func NewIrisPoolClient(members []Member, config Config) (*IrisPoolClient, error) {
+	if len(members) == 0 {
+		return nil, ErrNoMembers
+	}
+
+	if config.Quorum > len(members) {
+		return nil, fmt.Errorf("iris pool quorum %d exceeds member count %d", config.Quorum, len(members))
+	}
+
+	if config.AttemptTimeout == 0 {
+		config.AttemptTimeout = DefaultAttemptTimeout
+	}
+
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+
+	c := &IrisPoolClient{
+		members: make([]*member, 0, len(members)),
+		config:  config,
+		closeCh: make(chan struct{}),
+	}
+
+	for _, m := range members {
+		pm := &member{Member: m}
+		pm.healthy.Store(true)
+		c.members = append(c.members, pm)
+	}
+
+	membersGauge.Update(int64(len(c.members)))
+	healthyMembersGauge.Update(int64(len(c.members)))
+
+	c.wg.Add(1)
+
+	go c.healthLoop()
+
+	return c, nil
+}
+
+// Members returns the names of the pool members together with their health.
+func (c *IrisPoolClient) Members() map[string]bool {
+	res := make(map[string]bool, len(c.members))
+
+	for _, m := range c.members {
+		res[m.Name] = m.healthy.Load()
+	}
+
+	return res
+}
+
+func (c *IrisPoolClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return failover(ctx, c, stateSyncRequest, func(ctx context.Context, client zena.IIrisClient) ([]*clerk.EventRecordWithTime, error) {
+		return client.StateSyncEvents(ctx, fromID, to)
+	})
+}
+
+func (c *IrisPoolClient) Span(ctx context.Context, spanID uint64) (*span.IrisSpan, error) {
+	return agree(ctx, c, spanRequest, func(ctx context.Context, client zena.IIrisClient) (*span.IrisSpan, error) {
+		return client.Span(ctx, spanID)
+	})
+}
+
+func (c *IrisPoolClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return agree(ctx, c, checkpointRequest, func(ctx context.Context, client zena.IIrisClient) (*checkpoint.Checkpoint, error) {
+		return client.FetchCheckpoint(ctx, number)
+	})
+}
+
+func (c *IrisPoolClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return failover(ctx, c, checkpointCountRequest, func(ctx context.Context, client zena.IIrisClient) (int64, error) {
+		return client.FetchCheckpointCount(ctx)
+	})
+}
+
+func (c *IrisPoolClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	return agree(ctx, c, milestoneRequest, func(ctx context.Context, client zena.IIrisClient) (*milestone.Milestone, error) {
+		return client.FetchMilestone(ctx)
+	})
+}
+
+func (c *IrisPoolClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return failover(ctx, c, milestoneCountRequest, func(ctx context.Context, client zena.IIrisClient) (int64, error) {
+		return client.FetchMilestoneCount(ctx)
+	})
+}
+
+func (c *IrisPoolClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	_, err := failover(ctx, c, milestoneNoAckRequest, func(ctx context.Context, client zena.IIrisClient) (struct{}, error) {
+		return struct{}{}, client.FetchNoAckMilestone(ctx, milestoneID)
+	})
+
+	return err
+}
+
+func (c *IrisPoolClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return failover(ctx, c, milestoneLastNoAckRequest, func(ctx context.Context, client zena.IIrisClient) (string, error) {
+		return client.FetchLastNoAckMilestone(ctx)
+	})
+}
+
+func (c *IrisPoolClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	_, err := failover(ctx, c, milestoneIDRequest, func(ctx context.Context, client zena.IIrisClient) (struct{}, error) {
+		return struct{}{}, client.FetchMilestoneID(ctx, milestoneID)
+	})
+
+	return err
+}
+
+// Close stops the health checks and closes every member.
+func (c *IrisPoolClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+		c.wg.Wait()
+
+		for _, m := range c.members {
+			m.Client.Close()
+		}
+	})
+}
+
+// ordered returns the members in the order they should be tried: healthy
+// members starting from the primary, followed by the unhealthy ones as a
+// last resort.
+func (c *IrisPoolClient) ordered() []*member {
+	var (
+		n         = len(c.members)
+		start     = int(c.primary.Load())
+		healthy   = make([]*member, 0, n)
+		unhealthy = make([]*member, 0, n)
+	)
+
+	for i := 0; i < n; i++ {
+		m := c.members[(start+i)%n]
+		if m.healthy.Load() {
+			healthy = append(healthy, m)
+		} else {
+			unhealthy = append(unhealthy, m)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (c *IrisPoolClient) markHealthy(m *member) {
+	if !m.healthy.Swap(true) {
+		log.Info("Iris member is healthy again", "member", m.Name)
+		c.updateHealthyGauge()
+	}
+}
+
+func (c *IrisPoolClient) markUnhealthy(m *member, err error) {
+	if m.healthy.Swap(false) {
+		log.Warn("Iris member marked unhealthy", "member", m.Name, "err", err)
+		c.updateHealthyGauge()
+	}
+}
+
+func (c *IrisPoolClient) updateHealthyGauge() {
+	var healthy int64
+
+	for _, m := range c.members {
+		if m.healthy.Load() {
+			healthy++
+		}
+	}
+
+	healthyMembersGauge.Update(healthy)
+}
+
+// promote makes the given member the first one to be tried.
+func (c *IrisPoolClient) promote(m *member) {
+	for i, candidate := range c.members {
+		if candidate == m {
+			if c.primary.Swap(int32(i)) != int32(i) {
+				log.Info("Switched primary iris member", "member", m.Name)
+			}
+
+			return
+		}
+	}
+}
+
+// isAnswer reports whether the error is a valid answer from Iris rather
+// than a failure of the member itself. An unavailable member says nothing
+// about the others, so the request fails over.
+func isAnswer(err error) bool {
+	return err == nil ||
+		errors.Is(err, iris.ErrNotInRejectedList) ||
+		errors.Is(err, iris.ErrNotInMilestoneList)
+}
+
+// isUnavailable reports whether the member is up but can't serve the request
+// for now, which doesn't make it unhealthy.
+func isUnavailable(err error) bool {
+	return errors.Is(err, iris.ErrServiceUnavailable)
+}
+
+// failover sends the request to the members one by one, until one of them
+// answers. If every member is unavailable, their error is returned as is.
+func failover[T any](ctx context.Context, c *IrisPoolClient, reqType requestType, fn func(context.Context, zena.IIrisClient) (T, error)) (T, error) {
+	var (
+		zero        T
+		lastErr     error = ErrNoHealthyMember
+		unavailable       = true
+	)
+
+	for i, m := range c.ordered() {
+		select {
+		case <-c.closeCh:
+			return zero, iris.ErrShutdownDetected
+		default:
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, c.config.AttemptTimeout)
+		res, err := fn(attemptCtx, m.Client)
+		cancel()
+
+		if isAnswer(err) {
+			if i > 0 {
+				failoverMeter(reqType).Mark(1)
+				c.promote(m)
+			}
+
+			c.markHealthy(m)
+
+			return res, err
+		}
+
+		// The caller gave up, there is no point in trying other members.
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+
+		log.Debug("Iris member failed, trying next", "member", m.Name, "request", reqType, "err", err)
+
+		if !isUnavailable(err) {
+			c.markUnhealthy(m, err)
+
+			unavailable = false
+		}
+
+		lastErr = err
+	}
+
+	if unavailable && lastErr != ErrNoHealthyMember {
+		return zero, lastErr
+	}
+
+	return zero, fmt.Errorf("%w: %w", ErrNoHealthyMember, lastErr)
+}
+
+type agreeResult[T any] struct {
+	member *member
+	res    T
+	err    error
+}
+
+// agree behaves like failover when the quorum is disabled. Otherwise it
+// queries every member concurrently and returns the response at least
+// quorum members agree upon.
+func agree[T any](ctx context.Context, c *IrisPoolClient, reqType requestType, fn func(context.Context, zena.IIrisClient) (*T, error)) (*T, error) {
+	if c.config.Quorum <= 1 {
+		return failover(ctx, c, reqType, fn)
+	}
+
+	resCh := make(chan agreeResult[*T], len(c.members))
+
+	for _, m := range c.members {
+		go func(m *member) {
+			attemptCtx, cancel := context.WithTimeout(ctx, c.config.AttemptTimeout)
+			defer cancel()
+
+			res, err := fn(attemptCtx, m.Client)
+			resCh <- agreeResult[*T]{member: m, res: res, err: err}
+		}(m)
+	}
+
+	var (
+		votes   = make(map[common.Hash]int)
+		answers = make(map[common.Hash]*T)
+		voters  = make(map[common.Hash][]string)
+		lastErr error
+	)
+
+	for range c.members {
+		r := <-resCh
+
+		if r.err != nil || r.res == nil {
+			if ctx.Err() == nil && !isAnswer(r.err) && !isUnavailable(r.err) {
+				c.markUnhealthy(r.member, r.err)
+			}
+
+			lastErr = r.err
+
+			continue
+		}
+
+		c.markHealthy(r.member)
+
+		blob, err := json.Marshal(r.res)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		key := crypto.Keccak256Hash(blob)
+		votes[key]++
+		answers[key] = r.res
+		voters[key] = append(voters[key], r.member.Name)
+	}
+
+	if len(votes) > 1 {
+		disagreementMeter(reqType).Mark(1)
+
+		logCtx := make([]interface{}, 0, 2*len(voters)+2)
+		logCtx = append(logCtx, "request", reqType)
+
+		for key, names := range voters {
+			logCtx = append(logCtx, key.TerminalString(), names)
+		}
+
+		log.Warn("Iris members disagree", logCtx...)
+	}
+
+	for key, count := range votes {
+		if count >= c.config.Quorum {
+			return answers[key], nil
+		}
+	}
+
+	noQuorumMeter(reqType).Mark(1)
+
+	if len(votes) == 0 && lastErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoQuorum, lastErr)
+	}
+
+	return nil, fmt.Errorf("%w: %d distinct responses, need %d matching", ErrNoQuorum, len(votes), c.config.Quorum)
+}
+
+// healthLoop periodically probes every member and updates its health.
+func (c *IrisPoolClient) healthLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			for _, m := range c.members {
+				ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+				_, err := m.Client.FetchCheckpointCount(ctx)
+				cancel()
+
+				if err != nil && !isUnavailable(err) {
+					c.markUnhealthy(m, err)
+				} else if err == nil {
+					c.markHealthy(m)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package irispool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errFake = errors.New("fake member down")
+
+// fakeClient is a minimal IIrisClient returning canned responses.
+type fakeClient struct {
+	err       error
+	milestone *milestone.Milestone
+	count     int64
+	calls     int
+}
+
+func (f *fakeClient) StateSyncEvents(context.Context, uint64, int64) ([]*clerk.EventRecordWithTime, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *fakeClient) Span(context.Context, uint64) (*span.IrisSpan, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *fakeClient) FetchCheckpoint(context.Context, int64) (*checkpoint.Checkpoint, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *fakeClient) FetchCheckpointCount(context.Context) (int64, error) {
+	f.calls++
+	return f.count, f.err
+}
+
+func (f *fakeClient) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	f.calls++
+	return f.milestone, f.err
+}
+
+func (f *fakeClient) FetchMilestoneCount(context.Context) (int64, error) {
+	f.calls++
+	return f.count, f.err
+}
+
+func (f *fakeClient) FetchNoAckMilestone(context.Context, string) error {
+	f.calls++
+
+	if f.err != nil {
+		return f.err
+	}
+
+	return iris.ErrNotInRejectedList
+}
+
+func (f *fakeClient) FetchLastNoAckMilestone(context.Context) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *fakeClient) FetchMilestoneID(context.Context, string) error {
+	f.calls++
+	return f.err
+}
+
+func (f *fakeClient) Close() {}
+
+func newMilestone(hash common.Hash) *milestone.Milestone {
+	return &milestone.Milestone{
+		StartBlock: big.NewInt(1),
+		EndBlock:   big.NewInt(16),
+		Hash:       hash,
+	}
+}
+
+func TestFailover(t *testing.T) {
+	t.Parallel()
+
+	var (
+		down = &fakeClient{err: errFake}
+		up   = &fakeClient{count: 42}
+	)
+
+	pool, err := NewIrisPoolClient([]Member{{Name: "down", Client: down}, {Name: "up", Client: up}}, Config{})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	count, err := pool.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(42), count)
+	require.Equal(t, map[string]bool{"down": false, "up": true}, pool.Members())
+
+	// The healthy member is now the primary one and the failed one isn't tried first.
+	_, err = pool.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, down.calls)
+	require.Equal(t, 2, up.calls)
+}
+
+func TestFailoverKeepsIrisAnswers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = &fakeClient{}
+		second = &fakeClient{}
+	)
+
+	pool, err := NewIrisPoolClient([]Member{{Name: "first", Client: first}, {Name: "second", Client: second}}, Config{})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	// A milestone missing from the rejected list is an answer, not a failure.
+	err = pool.FetchNoAckMilestone(context.Background(), "id")
+	require.ErrorIs(t, err, iris.ErrNotInRejectedList)
+	require.Equal(t, 0, second.calls)
+	require.Equal(t, map[string]bool{"first": true, "second": true}, pool.Members())
+}
+
+func TestFailoverOnServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		unavailable = &fakeClient{err: fmt.Errorf("%w: response code 503", iris.ErrServiceUnavailable)}
+		up          = &fakeClient{count: 42}
+	)
+
+	pool, err := NewIrisPoolClient([]Member{{Name: "unavailable", Client: unavailable}, {Name: "up", Client: up}}, Config{})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	// An unavailable member isn't an answer, the next one is asked. It's
+	// still healthy though.
+	count, err := pool.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(42), count)
+	require.Equal(t, map[string]bool{"unavailable": true, "up": true}, pool.Members())
+
+	// Callers see the service is unavailable once every member is.
+	up.err = unavailable.err
+
+	_, err = pool.FetchCheckpointCount(context.Background())
+	require.ErrorIs(t, err, iris.ErrServiceUnavailable)
+	require.NotErrorIs(t, err, ErrNoHealthyMember)
+	require.Equal(t, map[string]bool{"unavailable": true, "up": true}, pool.Members())
+}
+
+func TestAllMembersDown(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewIrisPoolClient([]Member{{Name: "a", Client: &fakeClient{err: errFake}}, {Name: "b", Client: &fakeClient{err: errFake}}}, Config{})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	_, err = pool.FetchMilestoneCount(context.Background())
+	require.ErrorIs(t, err, ErrNoHealthyMember)
+}
+
+func TestQuorum(t *testing.T) {
+	t.Parallel()
+
+	var (
+		good = newMilestone(common.HexToHash("0x01"))
+		bad  = newMilestone(common.HexToHash("0x02"))
+	)
+
+	pool, err := NewIrisPoolClient([]Member{
+		{Name: "a", Client: &fakeClient{milestone: good}},
+		{Name: "b", Client: &fakeClient{milestone: bad}},
+		{Name: "c", Client: &fakeClient{milestone: good}},
+	}, Config{Quorum: 2})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	res, err := pool.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, good.Hash, res.Hash)
+
+	pool, err = NewIrisPoolClient([]Member{
+		{Name: "a", Client: &fakeClient{milestone: good}},
+		{Name: "b", Client: &fakeClient{milestone: bad}},
+		{Name: "c", Client: &fakeClient{err: errFake}},
+	}, Config{Quorum: 2})
+	require.NoError(t, err)
+
+	defer pool.Close()
+
+	_, err = pool.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, ErrNoQuorum)
+}
+
+func TestInvalidQuorum(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewIrisPoolClient(nil, Config{})
+	require.ErrorIs(t, err, ErrNoMembers)
+
+	_, err = NewIrisPoolClient([]Member{{Name: "a", Client: &fakeClient{}}}, Config{Quorum: 2})
+	require.Error(t, err)
+}
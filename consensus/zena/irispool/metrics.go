@@ -0,0 +1,43 @@
+package irispool
+
+import (
+	"fmt"
+
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+type requestType string
+
+const (
+	stateSyncRequest          requestType = "statesync"
+	spanRequest               requestType = "span"
+	checkpointRequest         requestType = "checkpoint"
+	checkpointCountRequest    requestType = "checkpointcount"
+	milestoneRequest          requestType = "milestone"
+	milestoneCountRequest     requestType = "milestonecount"
+	milestoneNoAckRequest     requestType = "milestonenoack"
+	milestoneLastNoAckRequest requestType = "milestonelastnoack"
+	milestoneIDRequest        requestType = "milestoneid"
+)
+
+var (
+	membersGauge        = metrics.NewRegisteredGauge("client/pool/members", nil)
+	healthyMembersGauge = metrics.NewRegisteredGauge("client/pool/members/healthy", nil)
+)
+
+// failoverMeter counts the requests which were answered by a member other
+// than the primary one.
+func failoverMeter(reqType requestType) metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("client/pool/%s/failover", reqType), nil)
+}
+
+// disagreementMeter counts the requests for which the members returned
+// different responses.
+func disagreementMeter(reqType requestType) metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("client/pool/%s/disagreement", reqType), nil)
+}
+
+// noQuorumMeter counts the requests for which no response reached quorum.
+func noQuorumMeter(reqType requestType) metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("client/pool/%s/noquorum", reqType), nil)
+}
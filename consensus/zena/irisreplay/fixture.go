@@ -0,0 +1,271 @@
+// Package irisreplay implements IIrisClients which record the responses of
+// Iris to a fixture file and serve them back deterministically, so tests and
+// debugging sessions can run against real-world captures without a network.
+package irisreplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// fixtureVersion is bumped whenever the fixture layout changes.
+const fixtureVersion = 1
+
+var (
+	// ErrNotRecorded is returned in replay mode for requests missing from the
+	// fixture.
+	ErrNotRecorded = errors.New("iris response not recorded")
+
+	errNoHeader       = errors.New("missing iris fixture header")
+	errUnknownVersion = errors.New("unknown iris fixture version")
+)
+
+// knownErrors are the errors callers check with errors.Is, they are restored
+// when a recorded failure is replayed.
+var knownErrors = []error{
+	iris.ErrNotInRejectedList,
+	iris.ErrNotInMilestoneList,
+	iris.ErrServiceUnavailable,
+	iris.ErrNoResponse,
+}
+
+// Response is a single recorded answer of Iris.
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+func newResponse(result any, err error) (*Response, error) {
+	resp := &Response{}
+
+	if err != nil {
+		resp.Error = err.Error()
+	} else if result != nil {
+		blob, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Result = blob
+	}
+
+	return resp, nil
+}
+
+// Fixture holds every recorded response, keyed by request. Requests which
+// were answered differently over time, like the latest milestone, keep all
+// of their responses in order.
+type Fixture struct {
+	Version int
+	Calls   map[string][]*Response
+}
+
+func newFixture() *Fixture {
+	return &Fixture{
+		Version: fixtureVersion,
+		Calls:   make(map[string][]*Response),
+	}
+}
+
+// fixtureHeader is the first JSON value of a fixture file. It is followed by
+// one fixtureEntry per recorded response, in the order they were recorded, so
+// responses can be appended to the file as they come.
+type fixtureHeader struct {
+	Version int `json:"version"`
+}
+
+type fixtureEntry struct {
+	Key string `json:"key"`
+	Response
+}
+
+// ReadFixture loads a fixture from the given file. A recording interrupted by
+// a crash ends with a partial response, which is left out.
+func ReadFixture(path string) (*Fixture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fixture, _, err := decodeFixture(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode iris fixture %s: %w", path, err)
+	}
+
+	return fixture, nil
+}
+
+// decodeFixture decodes a fixture up to its last complete response, and
+// returns the offset of the end of that response.
+func decodeFixture(r io.Reader) (*Fixture, int64, error) {
+	dec := json.NewDecoder(r)
+
+	var header fixtureHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errNoHeader, err)
+	}
+
+	if header.Version != fixtureVersion {
+		return nil, 0, fmt.Errorf("%w: %d", errUnknownVersion, header.Version)
+	}
+
+	fixture := newFixture()
+
+	for {
+		offset := dec.InputOffset()
+
+		var entry fixtureEntry
+		if err := dec.Decode(&entry); errors.Is(err, io.EOF) {
+			return fixture, offset, nil
+		} else if errors.Is(err, io.ErrUnexpectedEOF) {
+			log.Warn("Ignoring partial iris fixture response", "offset", offset)
+			return fixture, offset, nil
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		fixture.Calls[entry.Key] = append(fixture.Calls[entry.Key], &entry.Response)
+	}
+}
+
+// WriteFixture stores the fixture in the given file.
+func WriteFixture(path string, fixture *Fixture) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if err := encodeFixture(f, fixture); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func encodeFixture(w io.Writer, fixture *Fixture) error {
+	var (
+		buf = bufio.NewWriter(w)
+		enc = json.NewEncoder(buf)
+	)
+
+	if err := enc.Encode(fixtureHeader{Version: fixtureVersion}); err != nil {
+		return err
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(fixture.Calls)) {
+		for _, resp := range fixture.Calls[key] {
+			if err := enc.Encode(fixtureEntry{Key: key, Response: *resp}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return buf.Flush()
+}
+
+// appendFixture opens the fixture at path to append responses to it. The
+// responses already recorded are kept, and a partial one left by a crash is
+// dropped. If the file holds no fixture of the current version, it's started
+// over.
+func appendFixture(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	_, offset, err := decodeFixture(bufio.NewReader(f))
+
+	switch {
+	case err == nil:
+		// Resume right after the last complete response
+		if err := f.Truncate(offset); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		return f, nil
+
+	case errors.Is(err, errNoHeader), errors.Is(err, errUnknownVersion):
+		if err := f.Truncate(0); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		if err := json.NewEncoder(f).Encode(fixtureHeader{Version: fixtureVersion}); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		return f, nil
+
+	default:
+		f.Close()
+		return nil, err
+	}
+}
+
+// decodeError turns a recorded error back into an error, restoring the
+// sentinel errors callers match against.
+func decodeError(msg string) error {
+	for _, known := range knownErrors {
+		if strings.Contains(msg, known.Error()) {
+			return fmt.Errorf("%w: %s", known, msg)
+		}
+	}
+
+	return errors.New(msg)
+}
+
+func stateSyncKey(fromID uint64, to int64) string {
+	return fmt.Sprintf("state-sync/%d/%d", fromID, to)
+}
+
+func spanKey(spanID uint64) string {
+	return fmt.Sprintf("span/%d", spanID)
+}
+
+func checkpointKey(number int64) string {
+	return fmt.Sprintf("checkpoint/%d", number)
+}
+
+func noAckMilestoneKey(milestoneID string) string {
+	return "milestone-no-ack/" + milestoneID
+}
+
+func milestoneIDKey(milestoneID string) string {
+	return "milestone-id/" + milestoneID
+}
+
+const (
+	checkpointCountKey    = "checkpoint-count"
+	milestoneKey          = "milestone"
+	milestoneCountKey     = "milestone-count"
+	lastNoAckMilestoneKey = "milestone-last-no-ack"
+)
@@ -0,0 +1,153 @@
+package irisreplay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/zenanetwork/go-zenanet/consensus/zena"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// RecordingClient forwards every request to the wrapped client and appends
+// the responses to a fixture file as they come, so memory use doesn't grow
+// with the recording. Responses are buffered until Flush or Close.
+type RecordingClient struct {
+	client zena.IIrisClient
+	path   string
+
+	lock sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	enc  *json.Encoder
+}
+
+var _ zena.IIrisClient = (*RecordingClient)(nil)
+
+// NewRecordingClient wraps the given client and records its responses into
+// the fixture at path. Responses already present in the file are kept.
+func NewRecordingClient(client zena.IIrisClient, path string) (*RecordingClient, error) {
+	file, err := appendFixture(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open iris fixture %s: %w", path, err)
+	}
+
+	buf := bufio.NewWriter(file)
+
+	return &RecordingClient{
+		client: client,
+		path:   path,
+		file:   file,
+		buf:    buf,
+		enc:    json.NewEncoder(buf),
+	}, nil
+}
+
+// Flush writes the buffered responses to disk.
+func (r *RecordingClient) Flush() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.buf.Flush()
+}
+
+func (r *RecordingClient) record(ctx context.Context, key string, result any, err error) {
+	// Interrupted requests say nothing about Iris, don't keep them
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	resp, err := newResponse(result, err)
+	if err == nil {
+		r.lock.Lock()
+		err = r.enc.Encode(fixtureEntry{Key: key, Response: *resp})
+		r.lock.Unlock()
+	}
+
+	if err != nil {
+		log.Warn("Failed to record iris response", "request", key, "err", err)
+	}
+}
+
+func (r *RecordingClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	res, err := r.client.StateSyncEvents(ctx, fromID, to)
+	r.record(ctx, stateSyncKey(fromID, to), res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) Span(ctx context.Context, spanID uint64) (*span.IrisSpan, error) {
+	res, err := r.client.Span(ctx, spanID)
+	r.record(ctx, spanKey(spanID), res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	res, err := r.client.FetchCheckpoint(ctx, number)
+	r.record(ctx, checkpointKey(number), res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	res, err := r.client.FetchCheckpointCount(ctx)
+	r.record(ctx, checkpointCountKey, res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	res, err := r.client.FetchMilestone(ctx)
+	r.record(ctx, milestoneKey, res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	res, err := r.client.FetchMilestoneCount(ctx)
+	r.record(ctx, milestoneCountKey, res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	err := r.client.FetchNoAckMilestone(ctx, milestoneID)
+	r.record(ctx, noAckMilestoneKey(milestoneID), nil, err)
+
+	return err
+}
+
+func (r *RecordingClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	res, err := r.client.FetchLastNoAckMilestone(ctx)
+	r.record(ctx, lastNoAckMilestoneKey, res, err)
+
+	return res, err
+}
+
+func (r *RecordingClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	err := r.client.FetchMilestoneID(ctx, milestoneID)
+	r.record(ctx, milestoneIDKey(milestoneID), nil, err)
+
+	return err
+}
+
+// Close writes the buffered responses to disk and closes the wrapped client.
+func (r *RecordingClient) Close() {
+	if err := r.Flush(); err != nil {
+		log.Error("Failed to write iris fixture", "path", r.path, "err", err)
+	}
+
+	if err := r.file.Close(); err != nil {
+		log.Error("Failed to close iris fixture", "path", r.path, "err", err)
+	}
+
+	r.client.Close()
+}
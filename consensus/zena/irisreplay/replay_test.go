@@ -0,0 +1,264 @@
+package irisreplay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceClient answers the latest milestone with a new one on every call.
+type sequenceClient struct {
+	milestones int64
+}
+
+func (s *sequenceClient) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return []*clerk.EventRecordWithTime{
+		{EventRecord: clerk.EventRecord{ID: fromID, Contract: common.HexToAddress("0x1001"), Data: []byte{0xca, 0xfe}}, Time: time.Unix(to-1, 0).UTC()},
+	}, nil
+}
+
+func (s *sequenceClient) Span(_ context.Context, spanID uint64) (*span.IrisSpan, error) {
+	validator := valset.NewValidator(common.HexToAddress("0x01"), 10)
+
+	return &span.IrisSpan{
+		Span:              span.Span{ID: spanID, StartBlock: 256, EndBlock: 6655},
+		ValidatorSet:      valset.ValidatorSet{Validators: []*valset.Validator{validator}, Proposer: validator},
+		SelectedProducers: []valset.Validator{*validator},
+		ChainID:           "1",
+	}, nil
+}
+
+func (s *sequenceClient) FetchCheckpoint(context.Context, int64) (*checkpoint.Checkpoint, error) {
+	return nil, fmt.Errorf("%w: response code 503", iris.ErrServiceUnavailable)
+}
+
+func (s *sequenceClient) FetchCheckpointCount(context.Context) (int64, error) { return 7, nil }
+
+func (s *sequenceClient) FetchMilestone(context.Context) (*milestone.Milestone, error) {
+	s.milestones++
+
+	return &milestone.Milestone{
+		StartBlock: big.NewInt(s.milestones * 16),
+		EndBlock:   big.NewInt(s.milestones*16 + 15),
+		Hash:       common.BigToHash(big.NewInt(s.milestones)),
+	}, nil
+}
+
+func (s *sequenceClient) FetchMilestoneCount(context.Context) (int64, error) {
+	return s.milestones, nil
+}
+
+func (s *sequenceClient) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	return fmt.Errorf("%w: milestoneID %q", iris.ErrNotInRejectedList, milestoneID)
+}
+
+func (s *sequenceClient) FetchLastNoAckMilestone(context.Context) (string, error) { return "id", nil }
+func (s *sequenceClient) FetchMilestoneID(context.Context, string) error          { return nil }
+func (s *sequenceClient) Close()                                                  {}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "iris.json")
+	)
+
+	recorder, err := NewRecordingClient(&sequenceClient{}, path)
+	require.NoError(t, err)
+
+	wantSpan, err := recorder.Span(ctx, 1)
+	require.NoError(t, err)
+
+	wantEvents, err := recorder.StateSyncEvents(ctx, 5, 1700000000)
+	require.NoError(t, err)
+
+	var wantMilestones []*milestone.Milestone
+
+	for i := 0; i < 2; i++ {
+		m, err := recorder.FetchMilestone(ctx)
+		require.NoError(t, err)
+
+		wantMilestones = append(wantMilestones, m)
+	}
+
+	_, err = recorder.FetchCheckpoint(ctx, -1)
+	require.ErrorIs(t, err, iris.ErrServiceUnavailable)
+
+	err = recorder.FetchNoAckMilestone(ctx, "abc")
+	require.ErrorIs(t, err, iris.ErrNotInRejectedList)
+
+	count, err := recorder.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+
+	recorder.Close()
+
+	replayer, err := NewReplayClientFromFile(path)
+	require.NoError(t, err)
+
+	gotSpan, err := replayer.Span(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, wantSpan.Span, gotSpan.Span)
+	require.Equal(t, wantSpan.SelectedProducers, gotSpan.SelectedProducers)
+	require.Equal(t, wantSpan.ValidatorSet.Validators, gotSpan.ValidatorSet.Validators)
+
+	gotEvents, err := replayer.StateSyncEvents(ctx, 5, 1700000000)
+	require.NoError(t, err)
+	require.Equal(t, wantEvents, gotEvents)
+
+	// Milestones are served in the recorded order, the last one is repeated.
+	for _, want := range append(wantMilestones, wantMilestones[1]) {
+		got, err := replayer.FetchMilestone(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want.Hash, got.Hash)
+	}
+
+	_, err = replayer.FetchCheckpoint(ctx, -1)
+	require.ErrorIs(t, err, iris.ErrServiceUnavailable)
+
+	err = replayer.FetchNoAckMilestone(ctx, "abc")
+	require.ErrorIs(t, err, iris.ErrNotInRejectedList)
+
+	gotCount, err := replayer.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, count, gotCount)
+
+	_, err = replayer.Span(ctx, 2)
+	require.ErrorIs(t, err, ErrNotRecorded)
+
+	replayer.Reset()
+
+	got, err := replayer.FetchMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, wantMilestones[0].Hash, got.Hash)
+}
+
+func TestRecordAppends(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "iris.json")
+	)
+
+	recorder, err := NewRecordingClient(&sequenceClient{}, path)
+	require.NoError(t, err)
+
+	_, err = recorder.Span(ctx, 1)
+	require.NoError(t, err)
+
+	_, err = recorder.FetchMilestone(ctx)
+	require.NoError(t, err)
+
+	// Flushed responses are on disk while recording goes on
+	require.NoError(t, recorder.Flush())
+
+	fixture, err := ReadFixture(path)
+	require.NoError(t, err)
+	require.Len(t, fixture.Calls, 2)
+	require.Len(t, fixture.Calls[spanKey(1)], 1)
+	require.Len(t, fixture.Calls[milestoneKey], 1)
+
+	recorder.Close()
+
+	// Recording again keeps the earlier responses
+	recorder, err = NewRecordingClient(&sequenceClient{milestones: 1}, path)
+	require.NoError(t, err)
+
+	_, err = recorder.Span(ctx, 2)
+	require.NoError(t, err)
+
+	_, err = recorder.FetchMilestone(ctx)
+	require.NoError(t, err)
+
+	recorder.Close()
+
+	replayer, err := NewReplayClientFromFile(path)
+	require.NoError(t, err)
+
+	for _, id := range []uint64{1, 2} {
+		got, err := replayer.Span(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, id, got.Span.ID)
+	}
+
+	for _, want := range []int64{1, 2} {
+		got, err := replayer.FetchMilestone(ctx)
+		require.NoError(t, err)
+		require.Equal(t, common.BigToHash(big.NewInt(want)), got.Hash)
+	}
+
+	// Files holding no fixture are started over
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+
+	recorder, err = NewRecordingClient(&sequenceClient{}, path)
+	require.NoError(t, err)
+	recorder.Close()
+
+	fixture, err = ReadFixture(path)
+	require.NoError(t, err)
+	require.Empty(t, fixture.Calls)
+}
+
+func TestReplayTruncated(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		path = filepath.Join(t.TempDir(), "iris.json")
+	)
+
+	recorder, err := NewRecordingClient(&sequenceClient{}, path)
+	require.NoError(t, err)
+
+	for _, id := range []uint64{1, 2} {
+		_, err = recorder.Span(ctx, id)
+		require.NoError(t, err)
+	}
+
+	recorder.Close()
+
+	// A crash in the middle of the last response leaves it partially written
+	blob, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, blob[:len(blob)-10], 0o600))
+
+	replayer, err := NewReplayClientFromFile(path)
+	require.NoError(t, err)
+
+	got, err := replayer.Span(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), got.Span.ID)
+
+	_, err = replayer.Span(ctx, 2)
+	require.ErrorIs(t, err, ErrNotRecorded)
+
+	// Recording again drops the partial response
+	recorder, err = NewRecordingClient(&sequenceClient{}, path)
+	require.NoError(t, err)
+
+	_, err = recorder.Span(ctx, 3)
+	require.NoError(t, err)
+
+	recorder.Close()
+
+	fixture, err := ReadFixture(path)
+	require.NoError(t, err)
+	require.Len(t, fixture.Calls, 2)
+	require.Len(t, fixture.Calls[spanKey(1)], 1)
+	require.Len(t, fixture.Calls[spanKey(3)], 1)
+}
@@ -0,0 +1,133 @@
+package irisreplay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/zenanetwork/go-zenanet/consensus/zena"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/checkpoint"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/milestone"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+)
+
+// ReplayClient serves the responses of a fixture. Requests recorded several
+// times are answered in the recorded order, the last response being repeated
+// once all of them were served.
+type ReplayClient struct {
+	fixture *Fixture
+
+	lock    sync.Mutex
+	cursors map[string]int
+}
+
+var _ zena.IIrisClient = (*ReplayClient)(nil)
+
+// NewReplayClient creates a client serving the given fixture.
+func NewReplayClient(fixture *Fixture) *ReplayClient {
+	return &ReplayClient{
+		fixture: fixture,
+		cursors: make(map[string]int),
+	}
+}
+
+// NewReplayClientFromFile creates a client serving the fixture stored at path.
+func NewReplayClientFromFile(path string) (*ReplayClient, error) {
+	fixture, err := ReadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayClient(fixture), nil
+}
+
+// Reset rewinds every request to its first recorded response.
+func (r *ReplayClient) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cursors = make(map[string]int)
+}
+
+// next returns the next recorded response of the given request.
+func (r *ReplayClient) next(key string) (*Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	responses := r.fixture.Calls[key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+	}
+
+	i := r.cursors[key]
+	if i < len(responses)-1 {
+		r.cursors[key] = i + 1
+	}
+
+	return responses[i], nil
+}
+
+// replay decodes the next recorded response of the given request into T.
+func replay[T any](r *ReplayClient, key string) (T, error) {
+	var res T
+
+	resp, err := r.next(key)
+	if err != nil {
+		return res, err
+	}
+
+	if resp.Error != "" {
+		return res, decodeError(resp.Error)
+	}
+
+	if len(resp.Result) > 0 {
+		if err := json.Unmarshal(resp.Result, &res); err != nil {
+			return res, fmt.Errorf("failed to decode recorded %s: %w", key, err)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *ReplayClient) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return replay[[]*clerk.EventRecordWithTime](r, stateSyncKey(fromID, to))
+}
+
+func (r *ReplayClient) Span(_ context.Context, spanID uint64) (*span.IrisSpan, error) {
+	return replay[*span.IrisSpan](r, spanKey(spanID))
+}
+
+func (r *ReplayClient) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return replay[*checkpoint.Checkpoint](r, checkpointKey(number))
+}
+
+func (r *ReplayClient) FetchCheckpointCount(_ context.Context) (int64, error) {
+	return replay[int64](r, checkpointCountKey)
+}
+
+func (r *ReplayClient) FetchMilestone(_ context.Context) (*milestone.Milestone, error) {
+	return replay[*milestone.Milestone](r, milestoneKey)
+}
+
+func (r *ReplayClient) FetchMilestoneCount(_ context.Context) (int64, error) {
+	return replay[int64](r, milestoneCountKey)
+}
+
+func (r *ReplayClient) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	_, err := replay[struct{}](r, noAckMilestoneKey(milestoneID))
+	return err
+}
+
+func (r *ReplayClient) FetchLastNoAckMilestone(_ context.Context) (string, error) {
+	return replay[string](r, lastNoAckMilestoneKey)
+}
+
+func (r *ReplayClient) FetchMilestoneID(_ context.Context, milestoneID string) error {
+	_, err := replay[struct{}](r, milestoneIDKey(milestoneID))
+	return err
+}
+
+// Close is a no-op, the fixture is kept in memory.
+func (r *ReplayClient) Close() {}
@@ -0,0 +1,274 @@
+package zena
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+const (
+	// producerRecordRetention is the number of recent blocks whose producer
+	// records are kept. The statistics of older blocks are rebuilt on demand.
+	producerRecordRetention = 1 << 20
+
+	// producerRecordLookback bounds the number of blocks recorded at once on a
+	// new head, e.g. after a sync.
+	producerRecordLookback = 1024
+)
+
+// producerRecord is the outcome of a single block slot: who produced the
+// block, how far from the in-turn proposer it was and who missed their turn.
+type producerRecord struct {
+	Signer     common.Address   `json:"signer"`
+	Succession int              `json:"succession"`
+	Missed     []common.Address `json:"missed"`
+}
+
+func readProducerRecord(db ethdb.KeyValueReader, number uint64, hash common.Hash) *producerRecord {
+	blob := rawdb.ReadZenaProducerRecord(db, hash, number)
+	if len(blob) == 0 {
+		return nil
+	}
+
+	record := new(producerRecord)
+	if err := json.Unmarshal(blob, record); err != nil {
+		log.Warn("Invalid producer record", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+
+	return record
+}
+
+func writeProducerRecord(db ethdb.KeyValueWriter, number uint64, hash common.Hash, record *producerRecord) error {
+	blob, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	rawdb.WriteZenaProducerRecord(db, hash, number, blob)
+
+	return nil
+}
+
+// newProducerRecord builds the record of a block signed by signer on top of
+// the given snapshot. Every validator due before the signer missed its slot.
+func newProducerRecord(snap *Snapshot, signer common.Address) (*producerRecord, error) {
+	succession, err := snap.GetSignerSuccessionNumber(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		validators       = snap.ValidatorSet.Validators
+		proposerIndex, _ = snap.ValidatorSet.GetByAddress(snap.ValidatorSet.GetProposer().Address)
+		missed           = make([]common.Address, 0, succession)
+	)
+
+	for i := 0; i < succession; i++ {
+		missed = append(missed, validators[(proposerIndex+i)%len(validators)].Address)
+	}
+
+	return &producerRecord{
+		Signer:     signer,
+		Succession: succession,
+		Missed:     missed,
+	}, nil
+}
+
+// ProducerChain is a chain whose canonical blocks have their producers recorded.
+type ProducerChain interface {
+	consensus.ChainHeaderReader
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// StartProducerRecorder records the producers of the canonical blocks of the
+// chain as they are inserted, until the engine is closed.
+func (c *Zena) StartProducerRecorder(chain ProducerChain) {
+	if c.db == nil {
+		return
+	}
+
+	heads := make(chan core.ChainHeadEvent, 16)
+	c.producerSub = chain.SubscribeChainHeadEvent(heads)
+
+	c.producerWg.Add(1)
+
+	go func() {
+		defer c.producerWg.Done()
+
+		for {
+			select {
+			case ev := <-heads:
+				c.recordProducers(chain, ev.Block.Header())
+			case <-c.producerSub.Err():
+				return
+			}
+		}
+	}()
+}
+
+// buildProducerRecord builds the producer record of a header from the
+// snapshot of its parent.
+func (c *Zena) buildProducerRecord(chain consensus.ChainHeaderReader, header *types.Header) (*producerRecord, error) {
+	number := header.Number.Uint64()
+
+	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ecrecover(header, c.signatures, c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProducerRecord(snap, signer)
+}
+
+// recordProducers persists the producer records of a new canonical head and of
+// the canonical blocks before it which aren't recorded yet, and updates the
+// producer metrics once per block.
+func (c *Zena) recordProducers(chain consensus.ChainHeaderReader, head *types.Header) {
+	header := head
+
+	for i := 0; header != nil && i < producerRecordLookback; i++ {
+		number, hash := header.Number.Uint64(), header.Hash()
+		if number == 0 || rawdb.HasZenaProducerRecord(c.db, hash, number) {
+			break
+		}
+
+		record, err := c.buildProducerRecord(chain, header)
+		if err != nil {
+			log.Debug("Failed to build producer record", "number", number, "hash", hash, "err", err)
+			break
+		}
+
+		if err := writeProducerRecord(c.db, number, hash, record); err != nil {
+			log.Warn("Failed to store producer record", "number", number, "hash", hash, "err", err)
+			break
+		}
+
+		recordProducerMetrics(record)
+
+		header = chain.GetHeader(header.ParentHash, number-1)
+	}
+
+	if number := head.Number.Uint64(); number >= producerRecordRetention {
+		rawdb.DeleteZenaProducerRecordsBefore(c.db, number-producerRecordRetention+1)
+	}
+}
+
+// recordProducerMetrics updates the producer metrics with a new record.
+func recordProducerMetrics(record *producerRecord) {
+	if !metrics.Enabled {
+		return
+	}
+
+	if record.Succession == 0 {
+		metrics.GetOrRegisterCounter(fmt.Sprintf("zena/producer/%s/inturn", record.Signer.Hex()), nil).Inc(1)
+	} else {
+		metrics.GetOrRegisterCounter(fmt.Sprintf("zena/producer/%s/backup", record.Signer.Hex()), nil).Inc(1)
+		backupBlocksHistogram.Update(int64(record.Succession))
+	}
+
+	for _, validator := range record.Missed {
+		metrics.GetOrRegisterCounter(fmt.Sprintf("zena/producer/%s/missed", validator.Hex()), nil).Inc(1)
+	}
+}
+
+var backupBlocksHistogram = metrics.NewRegisteredHistogram("zena/producer/backup/succession", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+// ProducerStats holds the block production statistics of a validator.
+type ProducerStats struct {
+	Address            common.Address `json:"address"`
+	InTurn             uint64         `json:"inTurn"`             // Blocks produced as the in-turn proposer
+	Backup             uint64         `json:"backup"`             // Blocks produced as a backup producer
+	BackupBySuccession map[int]uint64 `json:"backupBySuccession"` // Backup blocks per succession number
+	Missed             uint64         `json:"missed"`             // Slots where the validator was due but a backup produced the block
+}
+
+// ProducerStatsReport aggregates the producer statistics over a block range.
+type ProducerStatsReport struct {
+	From      uint64           `json:"from"`
+	To        uint64           `json:"to"`
+	Blocks    uint64           `json:"blocks"`
+	Producers []*ProducerStats `json:"producers"`
+}
+
+// producerStats aggregates the producer records of the canonical blocks in
+// [from, to]. Records missing from the database, e.g. for blocks imported
+// before the records were introduced, are rebuilt from the snapshots.
+func (c *Zena) producerStats(chain consensus.ChainHeaderReader, from, to uint64) (*ProducerStatsReport, error) {
+	var (
+		stats  = make(map[common.Address]*ProducerStats)
+		report = &ProducerStatsReport{From: from, To: to, Producers: []*ProducerStats{}}
+	)
+
+	get := func(address common.Address) *ProducerStats {
+		s, ok := stats[address]
+		if !ok {
+			s = &ProducerStats{Address: address, BackupBySuccession: make(map[int]uint64)}
+			stats[address] = s
+		}
+
+		return s
+	}
+
+	if from == 0 {
+		// the genesis block has no producer
+		from = 1
+	}
+
+	for number := from; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+
+		record := readProducerRecord(c.db, number, header.Hash())
+		if record == nil {
+			var err error
+			if record, err = c.buildProducerRecord(chain, header); err != nil {
+				return nil, err
+			}
+
+			if err := writeProducerRecord(c.db, number, header.Hash(), record); err != nil {
+				log.Warn("Failed to store producer record", "number", number, "err", err)
+			}
+		}
+
+		report.Blocks++
+
+		producer := get(record.Signer)
+		if record.Succession == 0 {
+			producer.InTurn++
+		} else {
+			producer.Backup++
+			producer.BackupBySuccession[record.Succession]++
+		}
+
+		for _, validator := range record.Missed {
+			get(validator).Missed++
+		}
+	}
+
+	for _, s := range stats {
+		report.Producers = append(report.Producers, s)
+	}
+
+	sort.Slice(report.Producers, func(i, j int) bool {
+		return report.Producers[i].Address.Cmp(report.Producers[j].Address) < 0
+	})
+
+	return report, nil
+}
@@ -0,0 +1,78 @@
+package zena
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+)
+
+func TestNewProducerRecord(t *testing.T) {
+	t.Parallel()
+
+	validators := buildRandomValidatorSet(numVals)
+	sort.Sort(valset.ValidatorsByAddress(validators))
+
+	proposerIndex := 97
+	validators[proposerIndex].VotingPower = 200
+
+	snap := &Snapshot{
+		ValidatorSet: valset.NewValidatorSet(validators),
+	}
+
+	// in-turn proposer
+	record, err := newProducerRecord(snap, snap.ValidatorSet.Validators[proposerIndex].Address)
+	require.NoError(t, err)
+	require.Equal(t, 0, record.Succession)
+	require.Empty(t, record.Missed)
+
+	// backup producer wrapping around the validator list, every validator
+	// between the proposer and the signer missed its slot
+	signer := snap.ValidatorSet.Validators[1].Address
+
+	record, err = newProducerRecord(snap, signer)
+	require.NoError(t, err)
+	require.Equal(t, 4, record.Succession)
+	require.Equal(t, []common.Address{
+		snap.ValidatorSet.Validators[97].Address,
+		snap.ValidatorSet.Validators[98].Address,
+		snap.ValidatorSet.Validators[99].Address,
+		snap.ValidatorSet.Validators[0].Address,
+	}, record.Missed)
+
+	// unknown signer
+	_, err = newProducerRecord(snap, common.HexToAddress("0xdead"))
+	require.Error(t, err)
+}
+
+func TestProducerRecordStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		hash   = common.HexToHash("0x01")
+		record = &producerRecord{
+			Signer:     common.HexToAddress("0x02"),
+			Succession: 1,
+			Missed:     []common.Address{common.HexToAddress("0x03")},
+		}
+	)
+
+	require.Nil(t, readProducerRecord(db, 10, hash))
+	require.NoError(t, writeProducerRecord(db, 10, hash, record))
+	require.Equal(t, record, readProducerRecord(db, 10, hash))
+
+	// records of other blocks at the same height are kept apart
+	require.Nil(t, readProducerRecord(db, 10, common.HexToHash("0x04")))
+
+	// records are only pruned below the given block
+	rawdb.DeleteZenaProducerRecordsBefore(db, 10)
+	require.Equal(t, record, readProducerRecord(db, 10, hash))
+
+	rawdb.DeleteZenaProducerRecordsBefore(db, 11)
+	require.Nil(t, readProducerRecord(db, 10, hash))
+}
@@ -0,0 +1,302 @@
+package zena
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/zenanetwork/go-zenanet/accounts/abi"
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/consensus"
+	"github.com/zenanetwork/go-zenanet/consensus/misc/eip1559"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/contract"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/statefull"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+)
+
+// Change is the value of a piece of state before and after a system call.
+type Change[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// AccountDiff lists the changes a system call made to an account.
+type AccountDiff struct {
+	Balance *Change[*hexutil.Big]                `json:"balance,omitempty"`
+	Nonce   *Change[hexutil.Uint64]              `json:"nonce,omitempty"`
+	Code    *Change[common.Hash]                 `json:"codeHash,omitempty"`
+	Storage map[common.Hash]*Change[common.Hash] `json:"storage,omitempty"`
+}
+
+// SystemCallResult is the outcome of a simulated system call.
+type SystemCallResult struct {
+	GasUsed      hexutil.Uint64                  `json:"gasUsed"`
+	Failed       bool                            `json:"failed"`
+	Error        string                          `json:"error,omitempty"`
+	RevertReason string                          `json:"revertReason,omitempty"`
+	ReturnData   hexutil.Bytes                   `json:"returnData,omitempty"`
+	Logs         []*types.Log                    `json:"logs"`
+	StateDiff    map[common.Address]*AccountDiff `json:"stateDiff"`
+}
+
+// StateSyncEventResult is the outcome of the commitState call of a single
+// event record. Delivered reports whether the state receiver managed to hand
+// the record over to its receiver contract.
+type StateSyncEventResult struct {
+	ID        uint64         `json:"id"`
+	Contract  common.Address `json:"contract"`
+	Delivered bool           `json:"delivered"`
+	*SystemCallResult
+}
+
+// StateSyncSimulation is the outcome of the system calls simulated on top of
+// a block, in the order Finalize would run them.
+type StateSyncSimulation struct {
+	ParentNumber hexutil.Uint64          `json:"parentNumber"`
+	ParentHash   common.Hash             `json:"parentHash"`
+	Span         *SystemCallResult       `json:"span,omitempty"`
+	Events       []*StateSyncEventResult `json:"events"`
+	TotalGasUsed hexutil.Uint64          `json:"totalGasUsed"`
+}
+
+// stateProvider is implemented by the chains able to open the state of a block.
+type stateProvider interface {
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// errStateUnavailable is returned when the simulated chain can't open states.
+var errStateUnavailable = errors.New("state not available")
+
+// simulateSystemCalls commits the given span, if any, followed by the given
+// event records on top of statedb, as if they were part of the child block
+// of parent. The state is modified in place and must be discarded afterwards.
+func (c *Zena) simulateSystemCalls(
+	chain consensus.ChainHeaderReader,
+	statedb *state.StateDB,
+	parent *types.Header,
+	irisSpan *span.IrisSpan,
+	events []*clerk.EventRecordWithTime,
+) (*StateSyncSimulation, error) {
+	header := c.simulatedHeader(parent)
+
+	var (
+		cx     = statefull.ChainContext{Chain: chain, Zena: c}
+		result = &StateSyncSimulation{
+			ParentNumber: hexutil.Uint64(parent.Number.Uint64()),
+			ParentHash:   parent.Hash(),
+			Events:       make([]*StateSyncEventResult, 0, len(events)),
+		}
+		txIndex int
+	)
+
+	if irisSpan != nil {
+		data, err := span.PackCommitSpan(contract.ValidatorSet(), *irisSpan)
+		if err != nil {
+			return nil, err
+		}
+
+		to := common.HexToAddress(c.config.ValidatorContract)
+		result.Span = c.simulateSystemCall(statedb, header, cx, txIndex, statefull.GetSystemMessage(to, data))
+		result.TotalGasUsed += result.Span.GasUsed
+		txIndex++
+	}
+
+	for _, event := range events {
+		data, err := contract.PackCommitState(contract.StateReceiver(), event)
+		if err != nil {
+			return nil, err
+		}
+
+		to := common.HexToAddress(c.config.StateReceiverContract)
+		call := c.simulateSystemCall(statedb, header, cx, txIndex, statefull.GetSystemMessage(to, data))
+
+		result.Events = append(result.Events, &StateSyncEventResult{
+			ID:               event.ID,
+			Contract:         event.Contract,
+			Delivered:        !call.Failed && new(big.Int).SetBytes(call.ReturnData).Sign() != 0,
+			SystemCallResult: call,
+		})
+		result.TotalGasUsed += call.GasUsed
+		txIndex++
+	}
+
+	return result, nil
+}
+
+// simulatedHeader returns the header of a child block of parent, which is
+// used as the execution context of the simulated system calls.
+func (c *Zena) simulatedHeader(parent *types.Header) *types.Header {
+	number := new(big.Int).Add(parent.Number, common.Big1)
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     number,
+		Time:       parent.Time + c.config.CalculatePeriod(number.Uint64()),
+		GasLimit:   parent.GasLimit,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		Coinbase:   parent.Coinbase,
+	}
+
+	if c.chainConfig.IsLondon(number) {
+		header.BaseFee = eip1559.CalcBaseFee(c.chainConfig, parent)
+	}
+
+	return header
+}
+
+// simulateSystemCall applies a system message to statedb like the tracers
+// replay state syncs, so the state is finalised on the same failures as in
+// Finalize, and reports its gas, logs, revert reason and the state it changed.
+func (c *Zena) simulateSystemCall(
+	statedb *state.StateDB,
+	header *types.Header,
+	cx statefull.ChainContext,
+	txIndex int,
+	msg statefull.Callmsg,
+) *SystemCallResult {
+	// Logs are grouped by a pseudo transaction hash, unique to the call
+	txHash := crypto.Keccak256Hash(header.Number.Bytes(), big.NewInt(int64(txIndex)).Bytes(), msg.Data())
+	statedb.SetTxContext(txHash, txIndex)
+
+	tracker := newDiffTracker(statedb)
+	defer statedb.SetLogger(nil)
+
+	blockContext := core.NewEVMBlockContext(header, cx, &header.Coinbase)
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, statedb, c.chainConfig, vm.Config{})
+
+	// ApplyZenaMessage never fails, the outcome of the call is in the result
+	execResult, _ := statefull.ApplyZenaMessage(vmenv, msg)
+
+	result := &SystemCallResult{
+		GasUsed:    hexutil.Uint64(execResult.UsedGas),
+		ReturnData: execResult.ReturnData,
+		Logs:       statedb.GetLogs(txHash, header.Number.Uint64(), common.Hash{}),
+		StateDiff:  tracker.diff(),
+	}
+
+	if err := execResult.Err; err != nil {
+		result.Failed = true
+		result.Error = err.Error()
+
+		if errors.Is(err, vm.ErrExecutionReverted) {
+			if reason, unpackErr := abi.UnpackRevert(execResult.ReturnData); unpackErr == nil {
+				result.RevertReason = reason
+			}
+		}
+	}
+
+	if result.Logs == nil {
+		result.Logs = []*types.Log{}
+	}
+
+	return result
+}
+
+// diffTracker records the original value of every piece of state touched by
+// a call, so that the changes which survived the call can be reported.
+type diffTracker struct {
+	statedb *state.StateDB
+
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+	codes    map[common.Address]common.Hash
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+// newDiffTracker starts tracking the changes made to statedb.
+func newDiffTracker(statedb *state.StateDB) *diffTracker {
+	t := &diffTracker{
+		statedb:  statedb,
+		balances: make(map[common.Address]*big.Int),
+		nonces:   make(map[common.Address]uint64),
+		codes:    make(map[common.Address]common.Hash),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+
+	statedb.SetLogger(&tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, prev, _ *big.Int, _ tracing.BalanceChangeReason) {
+			if _, ok := t.balances[addr]; !ok {
+				t.balances[addr] = new(big.Int).Set(prev)
+			}
+		},
+		OnNonceChange: func(addr common.Address, prev, _ uint64) {
+			if _, ok := t.nonces[addr]; !ok {
+				t.nonces[addr] = prev
+			}
+		},
+		OnCodeChange: func(addr common.Address, prevCodeHash common.Hash, _ []byte, _ common.Hash, _ []byte) {
+			if _, ok := t.codes[addr]; !ok {
+				t.codes[addr] = prevCodeHash
+			}
+		},
+		OnStorageChange: func(addr common.Address, slot common.Hash, prev, _ common.Hash) {
+			slots, ok := t.storage[addr]
+			if !ok {
+				slots = make(map[common.Hash]common.Hash)
+				t.storage[addr] = slots
+			}
+
+			if _, ok := slots[slot]; !ok {
+				slots[slot] = prev
+			}
+		},
+	})
+
+	return t
+}
+
+// diff compares the original values against the current state. Changes
+// undone by a revert compare equal and are left out.
+func (t *diffTracker) diff() map[common.Address]*AccountDiff {
+	diffs := make(map[common.Address]*AccountDiff)
+
+	get := func(addr common.Address) *AccountDiff {
+		d, ok := diffs[addr]
+		if !ok {
+			d = new(AccountDiff)
+			diffs[addr] = d
+		}
+
+		return d
+	}
+
+	for addr, prev := range t.balances {
+		if cur := t.statedb.GetBalance(addr).ToBig(); cur.Cmp(prev) != 0 {
+			get(addr).Balance = &Change[*hexutil.Big]{From: (*hexutil.Big)(prev), To: (*hexutil.Big)(cur)}
+		}
+	}
+
+	for addr, prev := range t.nonces {
+		if cur := t.statedb.GetNonce(addr); cur != prev {
+			get(addr).Nonce = &Change[hexutil.Uint64]{From: hexutil.Uint64(prev), To: hexutil.Uint64(cur)}
+		}
+	}
+
+	for addr, prev := range t.codes {
+		if cur := t.statedb.GetCodeHash(addr); cur != prev {
+			get(addr).Code = &Change[common.Hash]{From: prev, To: cur}
+		}
+	}
+
+	for addr, slots := range t.storage {
+		for slot, prev := range slots {
+			if cur := t.statedb.GetState(addr, slot); cur != prev {
+				d := get(addr)
+				if d.Storage == nil {
+					d.Storage = make(map[common.Hash]*Change[common.Hash])
+				}
+
+				d.Storage[slot] = &Change[common.Hash]{From: prev, To: cur}
+			}
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,184 @@
+package zena
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/clerk"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/contract"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/statefull"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/params"
+)
+
+var (
+	// The codes load a slot first, which warms the contract like the
+	// genesis contracts do, since system calls have no access list.
+
+	// stores 1 in slot 0, emits an empty log and returns true
+	simulateReceiverCode = hexutil.MustDecode("0x60005450" + "600160005560006000a0600160005260206000f3")
+
+	// stores 1 in slot 5 and reverts with Error("boom")
+	simulateRevertCode = hexutil.MustDecode("0x60005450" + "6001600555" + "6064601560003960646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"626f6f6d00000000000000000000000000000000000000000000000000000000")
+
+	// increments slot 0 and returns true, or reverts if the calldata is longer
+	// than 200 bytes
+	simulateCounterCode = hexutil.MustDecode("0x600054600101600055" + "60c83611601a57" + "600160005260206000f3" + "5b60006000fd")
+)
+
+func TestSimulateSystemCalls(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validatorContract     = common.HexToAddress("0x1000")
+		stateReceiverContract = common.HexToAddress("0x1001")
+	)
+
+	c := &Zena{
+		chainConfig: params.AllEthashProtocolChanges,
+		config: &params.ZenaConfig{
+			Period:                map[string]uint64{"0": 2},
+			ValidatorContract:     validatorContract.Hex(),
+			StateReceiverContract: stateReceiverContract.Hex(),
+		},
+	}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	statedb.SetCode(stateReceiverContract, simulateReceiverCode)
+	statedb.SetCode(validatorContract, simulateRevertCode)
+
+	parent := &types.Header{
+		Number:     big.NewInt(15),
+		Time:       1700000000,
+		GasLimit:   30_000_000,
+		Difficulty: big.NewInt(1),
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+	}
+
+	validator := valset.NewValidator(common.HexToAddress("0x01"), 10)
+	irisSpan := &span.IrisSpan{
+		Span:              span.Span{ID: 1, StartBlock: 16, EndBlock: 6415},
+		ValidatorSet:      valset.ValidatorSet{Validators: []*valset.Validator{validator}, Proposer: validator},
+		SelectedProducers: []valset.Validator{*validator},
+	}
+
+	events := []*clerk.EventRecordWithTime{
+		{EventRecord: clerk.EventRecord{ID: 1, Contract: common.HexToAddress("0x2001"), Data: []byte{0x01}}, Time: time.Unix(1699999000, 0)},
+		{EventRecord: clerk.EventRecord{ID: 2, Contract: common.HexToAddress("0x2001"), Data: []byte{0x02}}, Time: time.Unix(1699999500, 0)},
+	}
+
+	result, err := c.simulateSystemCalls(nil, statedb, parent, irisSpan, events)
+	require.NoError(t, err)
+
+	require.Equal(t, hexutil.Uint64(15), result.ParentNumber)
+	require.Equal(t, parent.Hash(), result.ParentHash)
+
+	// The span commit reverts, its storage write is rolled back
+	require.NotNil(t, result.Span)
+	require.True(t, result.Span.Failed)
+	require.Equal(t, "boom", result.Span.RevertReason)
+	require.Empty(t, result.Span.StateDiff)
+	require.Empty(t, result.Span.Logs)
+
+	require.Len(t, result.Events, 2)
+
+	first := result.Events[0]
+	require.Equal(t, uint64(1), first.ID)
+	require.True(t, first.Delivered)
+	require.False(t, first.Failed)
+	require.Len(t, first.Logs, 1)
+	require.Equal(t, stateReceiverContract, first.Logs[0].Address)
+	require.Equal(t, uint64(16), first.Logs[0].BlockNumber)
+	require.Equal(t, &Change[common.Hash]{From: common.Hash{}, To: common.BigToHash(common.Big1)}, first.StateDiff[stateReceiverContract].Storage[common.Hash{}])
+
+	// The slot already holds 1, the second event leaves the state untouched
+	second := result.Events[1]
+	require.True(t, second.Delivered)
+	require.Len(t, second.Logs, 1)
+	require.Empty(t, second.StateDiff)
+
+	require.Equal(t, result.Span.GasUsed+first.GasUsed+second.GasUsed, result.TotalGasUsed)
+}
+
+func TestSimulateSystemCallsMatchFinalize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validatorContract     = common.HexToAddress("0x1000")
+		stateReceiverContract = common.HexToAddress("0x1001")
+	)
+
+	config := *params.AllEthashProtocolChanges
+	config.Zena = &params.ZenaConfig{
+		Period:                map[string]uint64{"0": 2},
+		ValidatorContract:     validatorContract.Hex(),
+		StateReceiverContract: stateReceiverContract.Hex(),
+	}
+
+	c := &Zena{chainConfig: &config, config: config.Zena}
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+
+	statedb.SetCode(stateReceiverContract, simulateCounterCode)
+	statedb.Finalise(true)
+
+	parent := &types.Header{
+		Number:     big.NewInt(15),
+		Time:       1700000000,
+		GasLimit:   30_000_000,
+		Difficulty: big.NewInt(1),
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+	}
+
+	// The second event fails, so the state is finalised before the third one
+	// increments the slot again
+	receiver := common.HexToAddress("0x2001")
+	events := []*clerk.EventRecordWithTime{
+		{EventRecord: clerk.EventRecord{ID: 1, Contract: receiver, Data: []byte{0x01}}, Time: time.Unix(1699999000, 0)},
+		{EventRecord: clerk.EventRecord{ID: 2, Contract: receiver, Data: make([]byte, 128)}, Time: time.Unix(1699999200, 0)},
+		{EventRecord: clerk.EventRecord{ID: 3, Contract: receiver, Data: []byte{0x03}}, Time: time.Unix(1699999500, 0)},
+	}
+
+	expected := statedb.Copy()
+
+	result, err := c.simulateSystemCalls(nil, statedb, parent, nil, events)
+	require.NoError(t, err)
+	require.Len(t, result.Events, 3)
+
+	require.True(t, result.Events[0].Delivered)
+	require.True(t, result.Events[1].Failed)
+	require.True(t, result.Events[2].Delivered)
+	require.Equal(t, &Change[common.Hash]{From: common.BigToHash(common.Big1), To: common.BigToHash(common.Big2)}, result.Events[2].StateDiff[stateReceiverContract].Storage[common.Hash{}])
+
+	// Commit the same events the way Finalize does
+	header := c.simulatedHeader(parent)
+	cx := statefull.ChainContext{Zena: c}
+
+	for i, event := range events {
+		data, err := contract.PackCommitState(contract.StateReceiver(), event)
+		require.NoError(t, err)
+
+		gasUsed, err := statefull.ApplyMessage(context.Background(), statefull.GetSystemMessage(stateReceiverContract, data), expected, header, &config, cx)
+		require.NoError(t, err)
+		require.Equal(t, hexutil.Uint64(gasUsed), result.Events[i].GasUsed, "event %d", event.ID)
+	}
+
+	require.Equal(t, expected.IntermediateRoot(true), statedb.IntermediateRoot(true))
+}
@@ -0,0 +1,178 @@
+package zena
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+// spanPrefetchTimeout bounds a background span fetch from Iris.
+const spanPrefetchTimeout = 30 * time.Second
+
+var (
+	spanPrefetchHitMeter   = metrics.NewRegisteredMeter("zena/span/prefetch/hit", nil)
+	spanPrefetchMissMeter  = metrics.NewRegisteredMeter("zena/span/prefetch/miss", nil)
+	spanPrefetchErrorMeter = metrics.NewRegisteredMeter("zena/span/prefetch/error", nil)
+	spanPrefetchTimer      = metrics.NewRegisteredTimer("zena/span/prefetch/duration", nil)
+)
+
+// spanPrefetcher fetches and validates the span following the current one in
+// the background, so the span commit at the start of the last sprint of a span
+// doesn't wait on Iris. Prefetches are triggered at every sprint start, a
+// failed one is retried at the next sprint.
+type spanPrefetcher struct {
+	fetch   func(ctx context.Context, id uint64) (*span.IrisSpan, error)
+	chainID string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lock     sync.Mutex
+	spans    map[uint64]*span.IrisSpan // Prefetched spans by id
+	inflight map[uint64]struct{}       // Ids of the spans being fetched
+}
+
+func newSpanPrefetcher(chainID string, fetch func(ctx context.Context, id uint64) (*span.IrisSpan, error)) *spanPrefetcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &spanPrefetcher{
+		fetch:    fetch,
+		chainID:  chainID,
+		ctx:      ctx,
+		cancel:   cancel,
+		spans:    make(map[uint64]*span.IrisSpan),
+		inflight: make(map[uint64]struct{}),
+	}
+}
+
+// prefetch fetches the span following current in the background, unless it
+// is already fetched or being fetched. Spans up to current are dropped.
+func (p *spanPrefetcher) prefetch(current *span.Span) {
+	if p == nil || current == nil {
+		return
+	}
+
+	id := current.ID + 1
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for cached := range p.spans {
+		if cached <= current.ID {
+			delete(p.spans, cached)
+		}
+	}
+
+	if _, ok := p.spans[id]; ok {
+		return
+	}
+
+	if _, ok := p.inflight[id]; ok {
+		return
+	}
+
+	select {
+	case <-p.ctx.Done():
+		return
+	default:
+	}
+
+	p.inflight[id] = struct{}{}
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		start := time.Now()
+
+		ctx, cancel := context.WithTimeout(p.ctx, spanPrefetchTimeout)
+		defer cancel()
+
+		irisSpan, err := p.fetch(ctx, id)
+		if err == nil {
+			err = p.validate(irisSpan, current)
+		}
+
+		p.lock.Lock()
+		delete(p.inflight, id)
+
+		if err == nil {
+			p.spans[id] = irisSpan
+		}
+		p.lock.Unlock()
+
+		if err != nil {
+			spanPrefetchErrorMeter.Mark(1)
+			log.Debug("Failed to prefetch span", "id", id, "err", err)
+
+			return
+		}
+
+		spanPrefetchTimer.UpdateSince(start)
+		log.Debug("Prefetched span", "id", id, "startBlock", irisSpan.StartBlock, "endBlock", irisSpan.EndBlock, "elapsed", time.Since(start))
+	}()
+}
+
+// validate checks that a fetched span can follow the current one.
+func (p *spanPrefetcher) validate(irisSpan *span.IrisSpan, current *span.Span) error {
+	if irisSpan == nil {
+		return fmt.Errorf("span %d not found", current.ID+1)
+	}
+
+	if irisSpan.ID != current.ID+1 {
+		return fmt.Errorf("span %d received instead of span %d", irisSpan.ID, current.ID+1)
+	}
+
+	if irisSpan.ChainID != p.chainID {
+		return fmt.Errorf("chain id of span %d, %s, doesn't match the zena chain id, %s", irisSpan.ID, irisSpan.ChainID, p.chainID)
+	}
+
+	if current.EndBlock != 0 && irisSpan.StartBlock != current.EndBlock+1 {
+		return fmt.Errorf("span %d starts at block %d, expected %d", irisSpan.ID, irisSpan.StartBlock, current.EndBlock+1)
+	}
+
+	if len(irisSpan.ValidatorSet.Validators) == 0 || len(irisSpan.SelectedProducers) == 0 {
+		return fmt.Errorf("span %d has no validators or producers", irisSpan.ID)
+	}
+
+	return nil
+}
+
+// get returns the prefetched span with the given id, if any.
+func (p *spanPrefetcher) get(id uint64) (*span.IrisSpan, bool) {
+	if p == nil {
+		return nil, false
+	}
+
+	p.lock.Lock()
+	irisSpan, ok := p.spans[id]
+	p.lock.Unlock()
+
+	if ok {
+		spanPrefetchHitMeter.Mark(1)
+	} else {
+		spanPrefetchMissMeter.Mark(1)
+	}
+
+	return irisSpan, ok
+}
+
+// close aborts the fetches in flight and waits for them to return.
+func (p *spanPrefetcher) close() {
+	if p == nil {
+		return
+	}
+
+	// Cancel under the lock so no fetch is started once waiting
+	p.lock.Lock()
+	p.cancel()
+	p.lock.Unlock()
+
+	p.wg.Wait()
+}
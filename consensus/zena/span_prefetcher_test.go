@@ -0,0 +1,113 @@
+package zena
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
+)
+
+func TestSpanPrefetcher(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls     atomic.Int32
+		available atomic.Bool
+		validator = valset.NewValidator(common.HexToAddress("0x01"), 10)
+	)
+
+	p := newSpanPrefetcher("80002", func(ctx context.Context, id uint64) (*span.IrisSpan, error) {
+		calls.Add(1)
+
+		if !available.Load() {
+			return nil, errors.New("span not proposed yet")
+		}
+
+		return &span.IrisSpan{
+			Span:              span.Span{ID: id, StartBlock: 6400*(id-1) + 256, EndBlock: 6400*id + 255},
+			ValidatorSet:      valset.ValidatorSet{Validators: []*valset.Validator{validator}},
+			SelectedProducers: []valset.Validator{*validator},
+			ChainID:           "80002",
+		}, nil
+	})
+	defer p.close()
+
+	current := &span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}
+
+	// A failed prefetch isn't cached, it's retried at the next sprint
+	p.prefetch(current)
+	require.Eventually(t, func() bool {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		return calls.Load() == 1 && len(p.inflight) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok := p.get(2)
+	require.False(t, ok)
+
+	available.Store(true)
+	p.prefetch(current)
+	require.Eventually(t, func() bool {
+		_, ok := p.get(2)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	// The span is fetched once
+	p.prefetch(current)
+	require.Equal(t, int32(2), calls.Load())
+
+	irisSpan, ok := p.get(2)
+	require.True(t, ok)
+	require.Equal(t, uint64(6656), irisSpan.StartBlock)
+
+	// Spans which aren't ahead anymore are dropped
+	p.prefetch(&span.Span{ID: 2, StartBlock: 6656, EndBlock: 13055})
+
+	_, ok = p.get(2)
+	require.False(t, ok)
+}
+
+func TestSpanPrefetcherValidate(t *testing.T) {
+	t.Parallel()
+
+	p := newSpanPrefetcher("80002", nil)
+	defer p.close()
+
+	validator := valset.NewValidator(common.HexToAddress("0x01"), 10)
+	current := &span.Span{ID: 1, StartBlock: 256, EndBlock: 6655}
+
+	next := func() *span.IrisSpan {
+		return &span.IrisSpan{
+			Span:              span.Span{ID: 2, StartBlock: 6656, EndBlock: 13055},
+			ValidatorSet:      valset.ValidatorSet{Validators: []*valset.Validator{validator}},
+			SelectedProducers: []valset.Validator{*validator},
+			ChainID:           "80002",
+		}
+	}
+
+	require.NoError(t, p.validate(next(), current))
+
+	irisSpan := next()
+	irisSpan.ChainID = "137"
+	require.ErrorContains(t, p.validate(irisSpan, current), "doesn't match")
+
+	irisSpan = next()
+	irisSpan.ID = 3
+	require.ErrorContains(t, p.validate(irisSpan, current), "instead of span 2")
+
+	irisSpan = next()
+	irisSpan.StartBlock = 6700
+	require.ErrorContains(t, p.validate(irisSpan, current), "expected 6656")
+
+	irisSpan = next()
+	irisSpan.SelectedProducers = nil
+	require.ErrorContains(t, p.validate(irisSpan, current), "no validators or producers")
+}
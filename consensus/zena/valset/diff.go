@@ -0,0 +1,85 @@
+package valset
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// PowerChange describes a validator present in two validator sets with a
+// different voting power.
+type PowerChange struct {
+	Address  common.Address `json:"signer"`
+	OldPower int64          `json:"oldPower"`
+	NewPower int64          `json:"newPower"`
+}
+
+// Diff holds the changes needed to go from one validator set to another.
+type Diff struct {
+	Added        []*Validator   `json:"added"`
+	Removed      []*Validator   `json:"removed"`
+	PowerChanged []*PowerChange `json:"powerChanged"`
+}
+
+// IsEmpty reports whether both validator sets have the same validators with
+// the same voting powers. Proposer priorities are not compared.
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.PowerChanged) == 0
+}
+
+// DiffValidatorSets returns the validators added, removed and the ones whose
+// voting power changed between prev and next. Every list is sorted by address.
+func DiffValidatorSets(prev, next *ValidatorSet) *Diff {
+	diff := &Diff{
+		Added:        []*Validator{},
+		Removed:      []*Validator{},
+		PowerChanged: []*PowerChange{},
+	}
+
+	prevVals := make(map[common.Address]*Validator)
+
+	if prev != nil {
+		for _, val := range prev.Validators {
+			prevVals[val.Address] = val
+		}
+	}
+
+	nextVals := make(map[common.Address]*Validator)
+
+	if next != nil {
+		for _, val := range next.Validators {
+			nextVals[val.Address] = val
+		}
+	}
+
+	for address, val := range nextVals {
+		old, ok := prevVals[address]
+		if !ok {
+			diff.Added = append(diff.Added, val.Copy())
+			continue
+		}
+
+		if old.VotingPower != val.VotingPower {
+			diff.PowerChanged = append(diff.PowerChanged, &PowerChange{
+				Address:  address,
+				OldPower: old.VotingPower,
+				NewPower: val.VotingPower,
+			})
+		}
+	}
+
+	for address, val := range prevVals {
+		if _, ok := nextVals[address]; !ok {
+			diff.Removed = append(diff.Removed, val.Copy())
+		}
+	}
+
+	sort.Sort(ValidatorsByAddress(diff.Added))
+	sort.Sort(ValidatorsByAddress(diff.Removed))
+	sort.Slice(diff.PowerChanged, func(i, j int) bool {
+		return bytes.Compare(diff.PowerChanged[i].Address.Bytes(), diff.PowerChanged[j].Address.Bytes()) < 0
+	})
+
+	return diff
+}
@@ -0,0 +1,40 @@
+package valset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValidatorSets(t *testing.T) {
+	t.Parallel()
+
+	vals := GetValidators()
+
+	prev := NewValidatorSet([]*Validator{vals[0].Copy(), vals[1].Copy(), vals[2].Copy()})
+
+	changed := vals[1].Copy()
+	changed.VotingPower = 250
+
+	next := NewValidatorSet([]*Validator{vals[0].Copy(), changed, vals[3].Copy()})
+
+	diff := DiffValidatorSets(prev, next)
+	require.False(t, diff.IsEmpty())
+
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, vals[3].Address, diff.Added[0].Address)
+
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, vals[2].Address, diff.Removed[0].Address)
+
+	require.Equal(t, []*PowerChange{{Address: vals[1].Address, OldPower: 200, NewPower: 250}}, diff.PowerChanged)
+
+	// Proposer priorities are not part of the diff
+	next.IncrementProposerPriority(3)
+	require.True(t, DiffValidatorSets(next, next.Copy()).IsEmpty())
+
+	// Every validator of a new set is added
+	diff = DiffValidatorSets(nil, prev)
+	require.Len(t, diff.Added, 3)
+	require.Empty(t, diff.Removed)
+}
@@ -0,0 +1,203 @@
+package valset
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// ErrEmptySchedule is returned when a schedule is projected for an empty
+// validator set or for no sprint at all.
+var ErrEmptySchedule = errors.New("empty producer schedule")
+
+// SprintSchedule is the producer schedule of a single sprint: the in-turn
+// proposer followed by the backup producers in succession order.
+type SprintSchedule struct {
+	Sprint   uint64           `json:"sprint"`
+	Proposer common.Address   `json:"proposer"`
+	Backups  []common.Address `json:"backups"`
+
+	// Powers holds the voting power of every validator during the sprint, it
+	// is used as the stake weight of the fairness report.
+	Powers map[common.Address]int64 `json:"-"`
+}
+
+// ScheduleConfig configures the projection of a producer schedule.
+type ScheduleConfig struct {
+	// Sprints is the number of sprints to project.
+	Sprints uint64
+
+	// Backups caps the number of backup producers listed per sprint,
+	// 0 lists every validator.
+	Backups int
+
+	// Changes are validator change sets applied right before the sprint they
+	// are keyed by, as new spans do. A validator with a voting power of 0 is
+	// removed from the set.
+	Changes map[uint64][]*Validator
+}
+
+// ProjectSchedule returns the producer schedule of the upcoming sprints,
+// starting with the current proposer of vals. The validator set evolves as in
+// the snapshots: the change set of a sprint is applied first, then the proposer
+// priorities are incremented once. vals is not modified.
+func ProjectSchedule(vals *ValidatorSet, config ScheduleConfig) ([]*SprintSchedule, error) {
+	if vals.IsNilOrEmpty() || config.Sprints == 0 {
+		return nil, ErrEmptySchedule
+	}
+
+	set := vals.Copy()
+	sort.Sort(ValidatorsByAddress(set.Validators))
+	set.UpdateValidatorMap()
+
+	if err := set.UpdateTotalVotingPower(); err != nil {
+		return nil, err
+	}
+
+	schedule := make([]*SprintSchedule, 0, config.Sprints)
+
+	for sprint := uint64(0); sprint < config.Sprints; sprint++ {
+		if sprint > 0 {
+			if changes, ok := config.Changes[sprint]; ok {
+				if err := set.UpdateWithChangeSet(validatorListCopy(changes)); err != nil {
+					return nil, err
+				}
+			}
+
+			set.IncrementProposerPriority(1)
+		}
+
+		schedule = append(schedule, sprintSchedule(set, sprint, config.Backups))
+	}
+
+	return schedule, nil
+}
+
+// sprintSchedule lists the producers of the current sprint of set.
+func sprintSchedule(set *ValidatorSet, sprint uint64, backups int) *SprintSchedule {
+	var (
+		proposer         = set.GetProposer()
+		proposerIndex, _ = set.GetByAddress(proposer.Address)
+		size             = len(set.Validators)
+	)
+
+	if backups <= 0 || backups > size-1 {
+		backups = size - 1
+	}
+
+	s := &SprintSchedule{
+		Sprint:   sprint,
+		Proposer: proposer.Address,
+		Backups:  make([]common.Address, 0, backups),
+		Powers:   make(map[common.Address]int64, size),
+	}
+
+	for i := 1; i <= backups; i++ {
+		s.Backups = append(s.Backups, set.Validators[(proposerIndex+i)%size].Address)
+	}
+
+	for _, val := range set.Validators {
+		s.Powers[val.Address] = val.VotingPower
+	}
+
+	return s
+}
+
+// RealizedSchedule returns the schedule realized by the chain, from a projected
+// schedule and the signers of its sprints: every sprint is proposed by its
+// signer, with the stake weight of the projection. The realized schedule ends
+// with the signers, the sprints not produced yet are left out.
+func RealizedSchedule(projected []*SprintSchedule, signers []common.Address) []*SprintSchedule {
+	realized := make([]*SprintSchedule, 0, min(len(projected), len(signers)))
+
+	for i, signer := range signers {
+		if i >= len(projected) {
+			break
+		}
+
+		realized = append(realized, &SprintSchedule{
+			Sprint:   projected[i].Sprint,
+			Proposer: signer,
+			Backups:  projected[i].Backups,
+			Powers:   projected[i].Powers,
+		})
+	}
+
+	return realized
+}
+
+// ProposerFairness compares the share of sprints proposed by a validator
+// with its share of the voting power.
+type ProposerFairness struct {
+	Address       common.Address `json:"address"`
+	Expected      float64        `json:"expected"`      // Sprints the stake weight entitles the validator to
+	Proposed      uint64         `json:"proposed"`      // Sprints actually proposed
+	ExpectedShare float64        `json:"expectedShare"` // Expected over the number of sprints
+	ProposedShare float64        `json:"proposedShare"` // Proposed over the number of sprints
+	Deviation     float64        `json:"deviation"`     // Proposed minus expected, in sprints
+}
+
+// FairnessReport compares a producer schedule with the stake weight of the
+// validators.
+type FairnessReport struct {
+	Sprints      uint64              `json:"sprints"`
+	MaxDeviation float64             `json:"maxDeviation"` // Largest absolute deviation, in sprints
+	Validators   []*ProposerFairness `json:"validators"`
+}
+
+// Fairness builds the fairness report of a projected or realized schedule.
+// The expected number of sprints of a validator is the sum of its share of
+// the voting power over every sprint, so validator set changes are accounted
+// for. Sprints without powers only count towards the proposed sprints.
+func Fairness(schedule []*SprintSchedule) *FairnessReport {
+	var (
+		stats  = make(map[common.Address]*ProposerFairness)
+		report = &FairnessReport{Sprints: uint64(len(schedule)), Validators: []*ProposerFairness{}}
+	)
+
+	get := func(address common.Address) *ProposerFairness {
+		s, ok := stats[address]
+		if !ok {
+			s = &ProposerFairness{Address: address}
+			stats[address] = s
+		}
+
+		return s
+	}
+
+	for _, sprint := range schedule {
+		get(sprint.Proposer).Proposed++
+
+		var total int64
+		for _, power := range sprint.Powers {
+			total += power
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		for address, power := range sprint.Powers {
+			get(address).Expected += float64(power) / float64(total)
+		}
+	}
+
+	for _, s := range stats {
+		if report.Sprints > 0 {
+			s.ExpectedShare = s.Expected / float64(report.Sprints)
+			s.ProposedShare = float64(s.Proposed) / float64(report.Sprints)
+		}
+
+		s.Deviation = float64(s.Proposed) - s.Expected
+		report.MaxDeviation = math.Max(report.MaxDeviation, math.Abs(s.Deviation))
+		report.Validators = append(report.Validators, s)
+	}
+
+	sort.Slice(report.Validators, func(i, j int) bool {
+		return report.Validators[i].Address.Cmp(report.Validators[j].Address) < 0
+	})
+
+	return report
+}
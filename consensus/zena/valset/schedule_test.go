@@ -0,0 +1,129 @@
+package valset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+func TestProjectSchedule(t *testing.T) {
+	t.Parallel()
+
+	vals := GetValidators()
+	set := NewValidatorSet([]*Validator{vals[0].Copy(), vals[1].Copy(), vals[2].Copy(), vals[3].Copy()})
+
+	_, err := ProjectSchedule(set, ScheduleConfig{})
+	require.ErrorIs(t, err, ErrEmptySchedule)
+
+	// A full round of the weighted round robin gives every validator as many
+	// sprints as its share of the total power
+	schedule, err := ProjectSchedule(set, ScheduleConfig{Sprints: 10, Backups: 2})
+	require.NoError(t, err)
+	require.Len(t, schedule, 10)
+
+	// The schedule starts with the current proposer, the set itself is left untouched
+	require.Equal(t, set.GetProposer().Address, schedule[0].Proposer)
+
+	proposed := make(map[common.Address]int)
+
+	for i, sprint := range schedule {
+		require.Equal(t, uint64(i), sprint.Sprint)
+		require.Len(t, sprint.Backups, 2)
+		require.NotContains(t, sprint.Backups, sprint.Proposer)
+
+		proposed[sprint.Proposer]++
+	}
+
+	for _, val := range vals {
+		require.Equal(t, int(val.VotingPower/100), proposed[val.Address])
+	}
+
+	report := Fairness(schedule)
+	require.Equal(t, uint64(10), report.Sprints)
+	require.Len(t, report.Validators, 4)
+	require.InDelta(t, 0, report.MaxDeviation, 1e-9)
+
+	for _, fairness := range report.Validators {
+		require.InDelta(t, fairness.ExpectedShare, fairness.ProposedShare, 1e-9)
+	}
+}
+
+func TestProjectScheduleWithChanges(t *testing.T) {
+	t.Parallel()
+
+	vals := GetValidators()
+	set := NewValidatorSet([]*Validator{vals[0].Copy(), vals[1].Copy(), vals[2].Copy(), vals[3].Copy()})
+
+	removed := vals[3].Copy()
+	removed.VotingPower = 0
+
+	schedule, err := ProjectSchedule(set, ScheduleConfig{
+		Sprints: 20,
+		Changes: map[uint64][]*Validator{5: {removed}},
+	})
+	require.NoError(t, err)
+
+	for _, sprint := range schedule[5:] {
+		require.NotEqual(t, vals[3].Address, sprint.Proposer)
+		require.NotContains(t, sprint.Backups, vals[3].Address)
+		require.Len(t, sprint.Backups, 2)
+		require.NotContains(t, sprint.Powers, vals[3].Address)
+	}
+
+	// The removed validator is only expected to propose while it was part of the set
+	report := Fairness(schedule)
+
+	for _, fairness := range report.Validators {
+		if fairness.Address == vals[3].Address {
+			require.InDelta(t, 5*0.4, fairness.Expected, 1e-9)
+		}
+	}
+}
+
+func TestRealizedSchedule(t *testing.T) {
+	t.Parallel()
+
+	vals := GetValidators()
+	set := NewValidatorSet([]*Validator{vals[0].Copy(), vals[1].Copy(), vals[2].Copy(), vals[3].Copy()})
+
+	schedule, err := ProjectSchedule(set, ScheduleConfig{Sprints: 10, Backups: 1})
+	require.NoError(t, err)
+
+	// Only 8 sprints were produced, the first backup signed the third one
+	signers := make([]common.Address, 8)
+	for i := range signers {
+		signers[i] = schedule[i].Proposer
+	}
+
+	signers[2] = schedule[2].Backups[0]
+
+	realized := RealizedSchedule(schedule, signers)
+	require.Len(t, realized, 8)
+	require.Equal(t, schedule[2].Backups[0], realized[2].Proposer)
+	require.Equal(t, schedule[3].Proposer, realized[3].Proposer)
+
+	// The fairness report of the realized schedule accounts for the takeover
+	projected := make(map[common.Address]float64)
+	for _, fairness := range Fairness(schedule[:8]).Validators {
+		projected[fairness.Address] = fairness.Deviation
+	}
+
+	report := Fairness(realized)
+	require.Equal(t, uint64(8), report.Sprints)
+
+	for _, fairness := range report.Validators {
+		switch fairness.Address {
+		case schedule[2].Proposer:
+			require.InDelta(t, projected[fairness.Address]-1, fairness.Deviation, 1e-9)
+		case schedule[2].Backups[0]:
+			require.InDelta(t, projected[fairness.Address]+1, fairness.Deviation, 1e-9)
+		default:
+			require.InDelta(t, projected[fairness.Address], fairness.Deviation, 1e-9)
+		}
+	}
+
+	// Signers beyond the projection are ignored
+	require.Len(t, RealizedSchedule(schedule[:2], signers), 2)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/crypto"
 	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/event"
 	"github.com/zenanetwork/go-zenanet/log"
 	"github.com/zenanetwork/go-zenanet/params"
 	"github.com/zenanetwork/go-zenanet/rlp"
@@ -104,6 +105,10 @@ var (
 
 	errUncleDetected     = errors.New("uncles not allowed")
 	errUnknownValidators = errors.New("unknown validators")
+
+	// errIrisClientNotSet is returned when Iris is needed but the node runs
+	// without it.
+	errIrisClientNotSet = errors.New("iris client not set")
 )
 
 // SignerFn is a signer callback function to request a header to be signed by a
@@ -224,6 +229,11 @@ type Zena struct {
 	GenesisContractsClient GenesisContract
 	IrisClient             IIrisClient
 
+	spanPrefetcher *spanPrefetcher // Fetches the next span from Iris ahead of its commit
+
+	producerSub event.Subscription // Subscription to the chain heads whose producers are recorded
+	producerWg  sync.WaitGroup
+
 	// The fields below are for testing only
 	fakeDiff      bool // Skip difficulty verifications
 	devFakeAuthor bool
@@ -270,6 +280,14 @@ func New(
 		devFakeAuthor:          devFakeAuthor,
 	}
 
+	c.spanPrefetcher = newSpanPrefetcher(chainConfig.ChainID.String(), func(ctx context.Context, id uint64) (*span.IrisSpan, error) {
+		if c.IrisClient == nil {
+			return nil, errIrisClientNotSet
+		}
+
+		return c.IrisClient.Span(ctx, id)
+	})
+
 	c.authorizedSigner.Store(&signer{
 		common.Address{},
 		func(_ accounts.Account, _ string, i []byte) ([]byte, error) {
@@ -1083,9 +1101,17 @@ func (c *Zena) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 	}}
 }
 
-// Close implements consensus.Engine. It's a noop for zena as there are no background threads.
+// Close implements consensus.Engine. It stops the span prefetcher and the
+// producer recorder, and closes the Iris client.
 func (c *Zena) Close() error {
 	c.closeOnce.Do(func() {
+		c.spanPrefetcher.close()
+
+		if c.producerSub != nil {
+			c.producerSub.Unsubscribe()
+			c.producerWg.Wait()
+		}
+
 		if c.IrisClient != nil {
 			c.IrisClient.Close()
 		}
@@ -1111,6 +1137,11 @@ func (c *Zena) checkAndCommitSpan(
 		return c.FetchAndCommitSpan(ctx, span.ID+1, state, header, chain)
 	}
 
+	// Fetch the next span in the background so its commit doesn't wait on Iris
+	if c.IrisClient != nil {
+		c.spanPrefetcher.prefetch(span)
+	}
+
 	return nil
 }
 
@@ -1150,6 +1181,8 @@ func (c *Zena) FetchAndCommitSpan(
 		}
 
 		irisSpan = *s
+	} else if prefetched, ok := c.spanPrefetcher.get(newSpanID); ok {
+		irisSpan = *prefetched
 	} else {
 		response, err := c.IrisClient.Span(ctx, newSpanID)
 		if err != nil {
@@ -39,6 +39,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/common/mclock"
 	"github.com/zenanetwork/go-zenanet/common/prque"
 	"github.com/zenanetwork/go-zenanet/consensus"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state"
 	"github.com/zenanetwork/go-zenanet/core/state/snapshot"
@@ -282,6 +283,10 @@ type BlockChain struct {
 	parallelProcessor            Processor // Parallel block transaction processor interface
 	parallelSpeculativeProcesses int       // Number of parallel speculative processes
 	enforceParallelProcessor     bool
+	parallelExecutionReports     bool                  // Whether to persist a report of the parallel execution of every block
+	parallelReportsRetention     uint64                // Number of recent blocks whose parallel execution reports are kept, 0 for all
+	parallelTxDependencyMode     TxDependencyMode      // How the transaction dependencies declared in block headers are used
+	parallelConcurrency          *blockstm.Concurrency // Sizes the speculative workers of every block, nil for a fixed number
 	forker                       *ForkChoice
 	vmConfig                     vm.Config
 	logger                       *tracing.Hooks
@@ -575,6 +580,77 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 	return bc, nil
 }
 
+// SetParallelExecutionReports sets whether the parallel state processor
+// persists a report of the execution of every block, and the number of recent
+// blocks whose reports are kept (0 keeps them all).
+func (bc *BlockChain) SetParallelExecutionReports(enabled bool, retention uint64) {
+	bc.parallelExecutionReports = enabled
+	bc.parallelReportsRetention = retention
+}
+
+// writeParallelExecutionReport persists the report of the parallel execution
+// of a validated block, and prunes the reports out of the retention window.
+// Reports are optional, so failing to write them never fails the import.
+func (bc *BlockChain) writeParallelExecutionReport(block *types.Block) {
+	processor, ok := bc.parallelProcessor.(*ParallelStateProcessor)
+	if !ok || !bc.parallelExecutionReports {
+		return
+	}
+
+	report := processor.takeReport(block.Hash())
+	if report == nil {
+		return
+	}
+
+	number := block.NumberU64()
+
+	data, err := rlp.EncodeToBytes(report)
+	if err != nil {
+		log.Error("Failed to RLP encode parallel execution report", "number", number, "hash", block.Hash(), "err", err)
+		return
+	}
+
+	rawdb.WriteParallelExecutionReport(bc.db, block.Hash(), number, data)
+
+	if retention := bc.parallelReportsRetention; retention > 0 && number >= retention {
+		rawdb.DeleteParallelExecutionReportsBefore(bc.db, number-retention+1)
+	}
+}
+
+// GetParallelExecutionReport retrieves the report of the parallel execution of
+// a block, or nil if none was stored.
+func (bc *BlockChain) GetParallelExecutionReport(hash common.Hash, number uint64) *blockstm.ExecutionReport {
+	data := rawdb.ReadParallelExecutionReport(bc.db, hash, number)
+	if len(data) == 0 {
+		return nil
+	}
+
+	report := new(blockstm.ExecutionReport)
+	if err := rlp.DecodeBytes(data, report); err != nil {
+		log.Error("Invalid parallel execution report RLP", "hash", hash, "err", err)
+		return nil
+	}
+
+	return report
+}
+
+// SetParallelTxDependencyMode sets how the parallel state processor uses the
+// transaction dependencies declared in block headers.
+func (bc *BlockChain) SetParallelTxDependencyMode(mode TxDependencyMode) {
+	bc.parallelTxDependencyMode = mode
+}
+
+// SetParallelAdaptiveProcesses sets whether the parallel state processor sizes
+// its speculative workers block by block, up to the number of speculative
+// processes, instead of always using all of them.
+func (bc *BlockChain) SetParallelAdaptiveProcesses(enabled bool) {
+	if enabled {
+		bc.parallelConcurrency = blockstm.NewConcurrency(bc.parallelSpeculativeProcesses)
+	} else {
+		bc.parallelConcurrency = nil
+	}
+}
+
 func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (_ types.Receipts, _ []*types.Log, _ uint64, _ *state.StateDB, vtime time.Duration, blockEndErr error) {
 	// Process the block using processor and parallelProcessor at the same time, take the one which finishes first, cancel the other, and return the result
 	ctx, cancel := context.WithCancel(context.Background())
@@ -634,6 +710,9 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (_
 				err = bc.validator.ValidateState(block, parallelStatedb, receipts, usedGas, false)
 				vtime = time.Since(vstart)
 			}
+			if err == nil {
+				bc.writeParallelExecutionReport(block)
+			}
 			resultChan <- Result{receipts, logs, usedGas, err, parallelStatedb, blockExecutionParallelCounter, true}
 		}()
 	}
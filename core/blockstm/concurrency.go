@@ -0,0 +1,122 @@
+package blockstm
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+var (
+	concurrencyWorkersGauge     = metrics.NewRegisteredGauge("blockstm/concurrency/workers", nil)                                            // Speculative workers of the last execution
+	concurrencyWorkersHistogram = metrics.NewRegisteredHistogram("blockstm/concurrency/chosen", nil, metrics.NewExpDecaySample(1028, 0.015)) // Speculative workers of every execution
+	concurrencyAbortRateGauge   = metrics.NewRegisteredGauge("blockstm/concurrency/abortrate", nil)                                          // Moving average of the aborted incarnations, in percent
+	concurrencyAbortsHistogram  = metrics.NewRegisteredHistogram("blockstm/concurrency/aborts", nil, metrics.NewExpDecaySample(1028, 0.015)) // Aborted incarnations of every execution, in percent
+	concurrencyTxTimer          = metrics.NewRegisteredTimer("blockstm/concurrency/txtime", nil)                                             // Execution time per transaction
+)
+
+// abortRateWeight is the weight of the last execution in the moving average of
+// the abort rate.
+const abortRateWeight = 0.2
+
+// Concurrency sizes the pool of speculative workers of every parallel
+// execution, between one and a maximum, after the shape of the block and the
+// conflicts met by the recent executions.
+type Concurrency struct {
+	max int
+
+	lock      sync.Mutex
+	abortRate float64 // Moving average of the share of aborted incarnations
+}
+
+// NewConcurrency creates a Concurrency using at most max speculative workers,
+// GOMAXPROCS if max isn't positive.
+func NewConcurrency(max int) *Concurrency {
+	if max <= 0 {
+		max = runtime.GOMAXPROCS(0)
+	}
+
+	return &Concurrency{max: max}
+}
+
+// Workers returns the number of speculative workers to execute tasks with,
+// never more than GOMAXPROCS nor the number of tasks. When the tasks declare
+// their dependencies, the pool is sized after the average width of the
+// dependency graph: the number of tasks over the length of its longest chain.
+// Otherwise it shrinks as the recent executions abort.
+func (c *Concurrency) Workers(tasks []ExecTask, metadata bool) int {
+	workers := min(c.max, runtime.GOMAXPROCS(0), len(tasks))
+
+	if metadata {
+		workers = min(workers, dependencyWidth(tasks))
+	} else {
+		c.lock.Lock()
+		abortRate := c.abortRate
+		c.lock.Unlock()
+
+		workers = int(math.Ceil(float64(workers) * (1 - abortRate)))
+	}
+
+	workers = max(workers, 1)
+
+	concurrencyWorkersGauge.Update(int64(workers))
+	concurrencyWorkersHistogram.Update(int64(workers))
+
+	return workers
+}
+
+// Update records the outcome of the execution of numTasks tasks, which took
+// elapsed, in the abort rate.
+func (c *Concurrency) Update(result ParallelExecutionResult, numTasks int, elapsed time.Duration) {
+	if result.Executions == 0 || numTasks == 0 {
+		return
+	}
+
+	abortRate := float64(result.Aborts+result.ValidationFailures) / float64(result.Executions)
+
+	c.lock.Lock()
+	c.abortRate = (1-abortRateWeight)*c.abortRate + abortRateWeight*abortRate
+	average := c.abortRate
+	c.lock.Unlock()
+
+	concurrencyAbortRateGauge.Update(int64(average * 100))
+	concurrencyAbortsHistogram.Update(int64(abortRate * 100))
+	concurrencyTxTimer.Update(elapsed / time.Duration(numTasks))
+}
+
+// AbortRate returns the moving average of the share of aborted incarnations.
+func (c *Concurrency) AbortRate() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.abortRate
+}
+
+// dependencyWidth returns the number of tasks over the length of the longest
+// chain of declared dependencies, rounded up.
+func dependencyWidth(tasks []ExecTask) int {
+	var (
+		depths  = make([]int, len(tasks))
+		longest = 0
+	)
+
+	for i, t := range tasks {
+		depths[i] = 1
+
+		for _, dep := range t.Dependencies() {
+			if dep >= 0 && dep < i && depths[dep]+1 > depths[i] {
+				depths[i] = depths[dep] + 1
+			}
+		}
+
+		longest = max(longest, depths[i])
+	}
+
+	if longest == 0 {
+		return 0
+	}
+
+	return (len(tasks) + longest - 1) / longest
+}
@@ -0,0 +1,105 @@
+package blockstm
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+func TestConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tasks := func(deps ...[]int) []ExecTask {
+		out := make([]ExecTask, len(deps))
+		for i, d := range deps {
+			task := NewTestExecTask(i, nil, common.BigToAddress(common.Big1), i)
+			task.dependencies = d
+
+			out[i] = task
+		}
+
+		return out
+	}
+
+	// 4 independent tasks, a chain of 4 tasks and 2 chains of 2 tasks
+	independent := tasks(nil, nil, nil, nil)
+	chain := tasks(nil, []int{0}, []int{1}, []int{2})
+	pairs := tasks(nil, nil, []int{0}, []int{1})
+
+	require.Equal(t, 4, dependencyWidth(independent))
+	require.Equal(t, 1, dependencyWidth(chain))
+	require.Equal(t, 2, dependencyWidth(pairs))
+
+	c := NewConcurrency(64)
+	procs := min(runtime.GOMAXPROCS(0), 4)
+
+	require.Equal(t, procs, c.Workers(independent, true))
+	require.Equal(t, 1, c.Workers(chain, true))
+	require.Equal(t, min(procs, 2), c.Workers(pairs, true))
+
+	// Without declared dependencies, the workers shrink with the aborts
+	require.Equal(t, procs, c.Workers(chain, false))
+
+	for i := 0; i < 50; i++ {
+		c.Update(ParallelExecutionResult{Executions: 10, Aborts: 9, ValidationFailures: 1}, 4, time.Millisecond)
+	}
+
+	require.InDelta(t, 1, c.AbortRate(), 0.001)
+	require.Equal(t, 1, c.Workers(independent, false))
+
+	for i := 0; i < 50; i++ {
+		c.Update(ParallelExecutionResult{Executions: 4}, 4, time.Millisecond)
+	}
+
+	require.InDelta(t, 0, c.AbortRate(), 0.001)
+	require.Equal(t, procs, c.Workers(independent, false))
+
+	// The workers never exceed the maximum
+	require.Equal(t, 1, NewConcurrency(1).Workers(independent, false))
+	require.Equal(t, 1, c.Workers(nil, false))
+}
+
+func TestConcurrencyFallbacks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		c     = NewConcurrency(64)
+		procs = min(runtime.GOMAXPROCS(0), 4)
+		tasks = conflictingTasks(4, false)
+	)
+
+	require.Equal(t, procs, c.Workers(tasks, false))
+
+	// Trusted executions with incomplete dependencies discard all their
+	// executions, and the Block-STM executions falling back from them abort
+	// too, which shrinks the workers
+	var (
+		incomplete = ParallelExecutionResult{Executions: 4, Aborts: 4}
+		fallback   = ParallelExecutionResult{Executions: 6, Aborts: 1, ValidationFailures: 1}
+	)
+
+	for i := 0; i < 20; i++ {
+		c.Update(incomplete, 4, time.Millisecond)
+		c.Update(fallback, 4, time.Millisecond)
+	}
+
+	require.Greater(t, c.AbortRate(), 0.4)
+
+	if procs > 1 {
+		require.Less(t, c.Workers(tasks, false), procs)
+	}
+
+	// Then recover once the declared dependencies are complete
+	for i := 0; i < 50; i++ {
+		result, err := ExecuteParallelTrusted(conflictingTasks(4, true), false, numProcs, nil)
+		require.NoError(t, err)
+		c.Update(result, 4, time.Millisecond)
+	}
+
+	require.InDelta(t, 0, c.AbortRate(), 0.001)
+	require.Equal(t, procs, c.Workers(tasks, false))
+}
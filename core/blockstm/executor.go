@@ -71,6 +71,16 @@ func (e ParallelExecFailedError) Error() string {
 	return e.Msg
 }
 
+// IncompleteDependenciesError is returned when the execution trusting the
+// declared dependencies of the transactions finds an undeclared one.
+type IncompleteDependenciesError struct {
+	Tx int
+}
+
+func (e IncompleteDependenciesError) Error() string {
+	return fmt.Sprintf("declared dependencies of tx %d are incomplete", e.Tx)
+}
+
 type IntHeap []int
 
 func (h IntHeap) Len() int           { return len(h) }
@@ -164,6 +174,11 @@ type ParallelExecutionResult struct {
 	Stats   *map[int]ExecutionStat
 	Deps    *DAG
 	AllDeps map[int]map[int]bool
+
+	// Executions is the number of incarnations executed, Aborts and
+	// ValidationFailures the number of them which were aborted during their
+	// execution or failed their validation
+	Executions, Aborts, ValidationFailures int
 }
 
 const numGoProcs = 1
@@ -567,7 +582,15 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 			deps = BuildDAG(*pe.lastTxIO)
 		}
 
-		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps}, err
+		return ParallelExecutionResult{
+			TxIO:               pe.lastTxIO,
+			Stats:              &pe.stats,
+			Deps:               &deps,
+			AllDeps:            allDeps,
+			Executions:         pe.cntExec,
+			Aborts:             pe.cntAbort,
+			ValidationFailures: pe.cntValidationFail,
+		}, err
 	}
 
 	// Send the next immediate pending transaction to be executed
@@ -603,7 +626,7 @@ type PropertyCheck func(*ParallelExecutor) error
 
 func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyCheck, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
 	if len(tasks) == 0 {
-		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil}, nil
+		return ParallelExecutionResult{TxIO: MakeTxnInputOutput(len(tasks))}, nil
 	}
 
 	pe := NewParallelExecutor(tasks, profile, metadata, numProcs)
@@ -643,3 +666,199 @@ func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyChec
 func ExecuteParallel(tasks []ExecTask, profile bool, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
 	return executeParallelWithCheck(tasks, profile, nil, metadata, numProcs, interruptCtx)
 }
+
+// ExecuteParallelTrusted executes the tasks by their declared dependencies,
+// without speculation: a task is only dispatched once the tasks it depends on
+// have committed their writes, and is executed once. Tasks are settled in
+// order, after checking they didn't read a value written by a task they don't
+// declare. If they did, or if a task is aborted, the dependencies are
+// incomplete and an IncompleteDependenciesError is returned, the tasks settled
+// so far must then be discarded: the result counts all the executions done as
+// aborted.
+//
+//nolint:gocognit
+func ExecuteParallelTrusted(tasks []ExecTask, profile bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
+	if len(tasks) == 0 {
+		return ParallelExecutionResult{TxIO: MakeTxnInputOutput(len(tasks))}, nil
+	}
+
+	var (
+		numTasks = len(tasks)
+		mvh      = MakeMVHashMap()
+		txIO     = MakeTxnInputOutput(numTasks)
+
+		// The tasks depending on each task, and the number of dependencies each
+		// task still waits for
+		dependents = make([][]int, numTasks)
+		waiting    = make([]int, numTasks)
+	)
+
+	// Like Prepare, the transactions of a sender are executed in order when
+	// they don't declare dependencies
+	prevSenderTx := make(map[common.Address]int)
+
+	for i, t := range tasks {
+		deps := t.Dependencies()
+
+		if len(deps) == 0 {
+			if tx, ok := prevSenderTx[t.Sender()]; ok {
+				deps = []int{tx}
+			}
+
+			prevSenderTx[t.Sender()] = i
+		}
+
+		for _, dep := range deps {
+			if dep < 0 || dep >= i {
+				return result, ParallelExecFailedError{fmt.Sprintf("invalid dependency %d of tx %d", dep, i)}
+			}
+
+			dependents[dep] = append(dependents[dep], i)
+			waiting[i]++
+		}
+	}
+
+	var (
+		chTasks   = make(chan ExecVersionView, numTasks)
+		chResults = make(chan ExecResult, numTasks)
+		chSettle  = make(chan int, numTasks)
+
+		workerWg sync.WaitGroup
+		settleWg sync.WaitGroup
+
+		stats      = make(map[int]ExecutionStat, numTasks)
+		statsMutex sync.Mutex
+		begin      = time.Now()
+	)
+
+	workerWg.Add(numProcs + numGoProcs)
+
+	for i := 0; i < numProcs+numGoProcs; i++ {
+		go func(procNum int) {
+			defer workerWg.Done()
+
+			for task := range chTasks {
+				start := time.Since(begin)
+
+				res := task.Execute()
+				if res.err == nil {
+					mvh.FlushMVWriteSet(res.txAllOut)
+				}
+
+				if profile {
+					statsMutex.Lock()
+					stats[res.ver.TxnIndex] = ExecutionStat{
+						TxIdx:       res.ver.TxnIndex,
+						Incarnation: res.ver.Incarnation,
+						Start:       uint64(start),
+						End:         uint64(time.Since(begin)),
+						Worker:      procNum,
+					}
+					statsMutex.Unlock()
+				}
+
+				chResults <- res
+			}
+		}(i)
+	}
+
+	settleWg.Add(1)
+
+	go func() {
+		for tx := range chSettle {
+			tasks[tx].Settle()
+		}
+
+		settleWg.Done()
+	}()
+
+	// Every task is executed once, so the results never block the workers
+	closeExecutor := func() {
+		close(chTasks)
+		workerWg.Wait()
+
+		close(chSettle)
+		settleWg.Wait()
+	}
+
+	executions := 0
+
+	dispatch := func(tx int) {
+		executions++
+		chTasks <- ExecVersionView{ver: Version{tx, 0}, et: tasks[tx], mvh: mvh, sender: tasks[tx].Sender()}
+	}
+
+	for tx := range tasks {
+		if waiting[tx] == 0 {
+			dispatch(tx)
+		}
+	}
+
+	var (
+		executed    = make([]bool, numTasks)
+		lastSettled = -1
+	)
+
+	for lastSettled < numTasks-1 {
+		res := <-chResults
+
+		if interruptCtx != nil && interruptCtx.Err() != nil {
+			closeExecutor()
+			return result, interruptCtx.Err()
+		}
+
+		tx := res.ver.TxnIndex
+
+		if res.err != nil {
+			closeExecutor()
+			return ParallelExecutionResult{Executions: executions, Aborts: executions}, IncompleteDependenciesError{Tx: tx}
+		}
+
+		txIO.recordRead(tx, res.txIn)
+		txIO.recordWrite(tx, res.txOut)
+		txIO.recordAllWrite(tx, res.txAllOut)
+
+		executed[tx] = true
+
+		for _, dependent := range dependents[tx] {
+			waiting[dependent]--
+
+			if waiting[dependent] == 0 {
+				dispatch(dependent)
+			}
+		}
+
+		// Once all the previous tasks are executed, the values a task read are final
+		for lastSettled < numTasks-1 && executed[lastSettled+1] {
+			if !ValidateVersion(lastSettled+1, txIO, mvh) {
+				closeExecutor()
+				return ParallelExecutionResult{Executions: executions, Aborts: executions}, IncompleteDependenciesError{Tx: lastSettled + 1}
+			}
+
+			lastSettled++
+			chSettle <- lastSettled
+		}
+	}
+
+	closeExecutor()
+
+	log.Debug("blockstm trusted exec summary", "execs", executions)
+
+	var (
+		allDeps map[int]map[int]bool
+		deps    DAG
+	)
+
+	if profile {
+		allDeps = GetDep(*txIO)
+		deps = BuildDAG(*txIO)
+	}
+
+	return ParallelExecutionResult{
+		TxIO:       txIO,
+		Stats:      &stats,
+		Deps:       &deps,
+		AllDeps:    allDeps,
+		Executions: executions,
+	}, nil
+}
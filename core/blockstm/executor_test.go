@@ -986,3 +986,56 @@ func TestBreakFromPartialCircularDependency(t *testing.T) {
 		t.Error("Expected cancel error")
 	}
 }
+
+// conflictingTasks returns numTasks tasks from different senders, which all read
+// and write the same key, then keep running so the next task starts before the
+// write is recorded. Each task declares the previous one as a dependency if
+// declareDeps is set.
+func conflictingTasks(numTasks int, declareDeps bool) []ExecTask {
+	var (
+		key   = NewAddressKey(common.BigToAddress(big.NewInt(1)))
+		tasks = make([]ExecTask, numTasks)
+	)
+
+	for i := range tasks {
+		task := NewTestExecTask(i, []Op{
+			{opType: otherType},
+			{opType: readType, key: key},
+			{opType: writeType, key: key, val: i},
+			{opType: otherType, duration: 5 * time.Millisecond},
+		}, common.BigToAddress(big.NewInt(int64(i+2))), 0)
+
+		if declareDeps && i > 0 {
+			task.dependencies = []int{i - 1}
+		}
+
+		tasks[i] = task
+	}
+
+	return tasks
+}
+
+func TestTrustedDependencies(t *testing.T) {
+	t.Parallel()
+
+	result, err := ExecuteParallelTrusted(conflictingTasks(5, true), true, numProcs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Aborts+result.ValidationFailures)
+	assert.Equal(t, 5, result.Executions)
+
+	// Each tx only started once the one it depends on was done
+	for i := 1; i < 5; i++ {
+		assert.GreaterOrEqual(t, (*result.Stats)[i].Start, (*result.Stats)[i-1].End)
+	}
+
+	// The executions of an incomplete trusted execution are all discarded
+	result, err = ExecuteParallelTrusted(conflictingTasks(5, false), false, numProcs, nil)
+	assert.ErrorAs(t, err, &IncompleteDependenciesError{})
+	assert.Positive(t, result.Executions)
+	assert.Equal(t, result.Executions, result.Aborts)
+
+	// Without trusting them, missing dependencies are found by speculation
+	result, err = ExecuteParallel(conflictingTasks(5, false), false, true, numProcs, nil)
+	assert.NoError(t, err)
+	assert.Positive(t, result.Aborts+result.ValidationFailures)
+}
@@ -0,0 +1,178 @@
+package blockstm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// Kinds of the keys of a KeyConflict
+const (
+	KeyKindAddress = "address"
+	KeyKindStorage = "storage"
+	KeyKindSubpath = "subpath"
+)
+
+// KeyConflict is a key written by a transaction and read by later transactions
+// of the same block, which serializes their execution.
+type KeyConflict struct {
+	Kind    string         `json:"kind"`
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`    // Storage slot of a storage key
+	Subpath uint8          `json:"subpath"` // Account field of a subpath key
+	Readers uint64         `json:"readers"` // Transactions which read the value written by an earlier transaction
+}
+
+// ExecutionReport summarizes the parallel execution of a block.
+type ExecutionReport struct {
+	Transactions        uint64        `json:"transactions"`
+	Executions          uint64        `json:"executions"`          // Incarnations executed, including the aborted ones
+	ReExecutions        uint64        `json:"reExecutions"`        // Executions beyond the first one of every transaction
+	AbortedIncarnations uint64        `json:"abortedIncarnations"` // Incarnations aborted on a dependency or failing validation
+	ValidationFailures  uint64        `json:"validationFailures"`
+	SerialGas           uint64        `json:"serialGas"`       // Gas used by all the transactions
+	CriticalPathGas     uint64        `json:"criticalPathGas"` // Gas used along the longest chain of dependent transactions
+	CriticalPath        []uint64      `json:"criticalPath"`    // Indexes of the transactions of the longest chain
+	HotKeys             []KeyConflict `json:"hotKeys"`         // Most read conflicting keys, hottest first
+}
+
+// NewExecutionReport builds the report of a completed parallel execution,
+// given the gas used by every transaction. At most hotKeys conflicting keys
+// are listed.
+func NewExecutionReport(result ParallelExecutionResult, gasUsed []uint64, hotKeys int) *ExecutionReport {
+	report := &ExecutionReport{
+		Transactions:        uint64(len(gasUsed)),
+		Executions:          uint64(result.Executions),
+		AbortedIncarnations: uint64(result.Aborts + result.ValidationFailures),
+		ValidationFailures:  uint64(result.ValidationFailures),
+		CriticalPath:        []uint64{},
+		HotKeys:             []KeyConflict{},
+	}
+
+	if report.Executions > report.Transactions {
+		report.ReExecutions = report.Executions - report.Transactions
+	}
+
+	for _, gas := range gasUsed {
+		report.SerialGas += gas
+	}
+
+	if result.TxIO == nil || len(gasUsed) == 0 {
+		return report
+	}
+
+	path, weight := criticalPath(GetDep(*result.TxIO), gasUsed)
+	report.CriticalPathGas = weight
+
+	for _, tx := range path {
+		report.CriticalPath = append(report.CriticalPath, uint64(tx))
+	}
+
+	report.HotKeys = conflictingKeys(result.TxIO, hotKeys)
+
+	return report
+}
+
+// criticalPath returns the chain of dependent transactions using the most gas.
+// Dependencies always point to earlier transactions, so they are walked in order.
+func criticalPath(deps map[int]map[int]bool, gasUsed []uint64) ([]int, uint64) {
+	var (
+		weights = make([]uint64, len(gasUsed))
+		prev    = make([]int, len(gasUsed))
+		last    = 0
+	)
+
+	for i := range gasUsed {
+		parents := make([]int, 0, len(deps[i]))
+		for j := range deps[i] {
+			if j < i {
+				parents = append(parents, j)
+			}
+		}
+
+		sort.Ints(parents)
+
+		prev[i] = -1
+
+		for _, j := range parents {
+			if prev[i] == -1 || weights[j] > weights[prev[i]] {
+				prev[i] = j
+			}
+		}
+
+		if prev[i] != -1 {
+			weights[i] = weights[prev[i]]
+		}
+
+		weights[i] += gasUsed[i]
+
+		if weights[i] > weights[last] {
+			last = i
+		}
+	}
+
+	path := make([]int, 0)
+	for i := last; i != -1; i = prev[i] {
+		path = append(path, i)
+	}
+
+	// Reverse the path so the transactions are in the ascending order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, weights[last]
+}
+
+// conflictingKeys counts, for every key, the transactions which read the value
+// written by an earlier transaction, and returns the limit most read keys.
+func conflictingKeys(io *TxnInputOutput, limit int) []KeyConflict {
+	readers := make(map[Key]uint64)
+
+	for _, reads := range io.inputs {
+		for _, rd := range reads {
+			if rd.Kind == ReadKindMap {
+				readers[rd.Path]++
+			}
+		}
+	}
+
+	keys := make([]Key, 0, len(readers))
+	for k := range readers {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if readers[keys[i]] != readers[keys[j]] {
+			return readers[keys[i]] > readers[keys[j]]
+		}
+
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	if limit >= 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	conflicts := make([]KeyConflict, 0, len(keys))
+
+	for _, k := range keys {
+		conflict := KeyConflict{Address: k.GetAddress(), Readers: readers[k]}
+
+		switch {
+		case k.IsState():
+			conflict.Kind = KeyKindStorage
+			conflict.Slot = k.GetStateKey()
+		case k.IsSubpath():
+			conflict.Kind = KeyKindSubpath
+			conflict.Subpath = k.GetSubpath()
+		default:
+			conflict.Kind = KeyKindAddress
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts
+}
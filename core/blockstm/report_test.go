@@ -0,0 +1,62 @@
+package blockstm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+func TestExecutionReport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = common.HexToAddress("0x01")
+		slot    = NewStateKey(addr, common.HexToHash("0x02"))
+		balance = NewSubpathKey(addr, 1)
+		other   = NewAddressKey(common.HexToAddress("0x03"))
+	)
+
+	// tx1 and tx2 read the slot written by tx0, tx3 reads the balance written by tx2
+	io := MakeTxnInputOutput(4)
+	io.RecordReadAtOnce([][]ReadDescriptor{
+		{{Path: other, Kind: ReadKindStorage, V: Version{TxnIndex: -1}}},
+		{{Path: slot, Kind: ReadKindMap, V: Version{TxnIndex: 0}}},
+		{{Path: slot, Kind: ReadKindMap, V: Version{TxnIndex: 0}}},
+		{{Path: balance, Kind: ReadKindMap, V: Version{TxnIndex: 2}}},
+	})
+	io.RecordAllWriteAtOnce([][]WriteDescriptor{
+		{{Path: slot}},
+		{},
+		{{Path: balance}},
+		{},
+	})
+
+	result := ParallelExecutionResult{TxIO: io, Executions: 6, Aborts: 1, ValidationFailures: 1}
+
+	report := NewExecutionReport(result, []uint64{30_000, 100_000, 50_000, 21_000}, 1)
+
+	require.Equal(t, uint64(4), report.Transactions)
+	require.Equal(t, uint64(2), report.ReExecutions)
+	require.Equal(t, uint64(2), report.AbortedIncarnations)
+	require.Equal(t, uint64(201_000), report.SerialGas)
+
+	// 0 -> 1 uses 130k gas, 0 -> 2 -> 3 uses 101k
+	require.Equal(t, []uint64{0, 1}, report.CriticalPath)
+	require.Equal(t, uint64(130_000), report.CriticalPathGas)
+
+	require.Equal(t, []KeyConflict{{
+		Kind:    KeyKindStorage,
+		Address: addr,
+		Slot:    common.HexToHash("0x02"),
+		Readers: 2,
+	}}, report.HotKeys)
+
+	report = NewExecutionReport(result, []uint64{30_000, 10_000, 50_000, 21_000}, -1)
+	require.Equal(t, []uint64{0, 2, 3}, report.CriticalPath)
+	require.Equal(t, uint64(101_000), report.CriticalPathGas)
+	require.Len(t, report.HotKeys, 2)
+	require.Equal(t, KeyKindSubpath, report.HotKeys[1].Kind)
+	require.Equal(t, uint8(1), report.HotKeys[1].Subpath)
+}
@@ -352,7 +352,11 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *triedb.Database, g
 	newcfg := genesis.configOrDefault(stored)
 	applyOverrides(newcfg)
 
-	if err := newcfg.CheckConfigForkOrder(); err != nil {
+	// An inconsistent zena config is only reported for chains already started,
+	// the ones started before it was validated must keep running
+	if err := newcfg.CheckConfigForkOrder(); errors.Is(err, params.ErrInvalidZenaConfig) {
+		log.Warn("Inconsistent zena config", "err", err)
+	} else if err != nil {
 		return newcfg, common.Hash{}, err
 	}
 
@@ -19,6 +19,7 @@ package core
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -44,6 +45,29 @@ func TestInvalidCliqueConfig(t *testing.T) {
 	}
 }
 
+func TestInvalidZenaConfig(t *testing.T) {
+	config := *params.ZenaTestChainConfig
+	zena := *config.Zena
+	zena.Sprint = map[string]uint64{"0": 64, "100": 16}
+	config.Zena = &zena
+
+	// A new genesis with an inconsistent zena config is rejected
+	db := rawdb.NewMemoryDatabase()
+	if _, _, err := SetupGenesisBlock(db, triedb.NewDatabase(db, nil), &Genesis{Config: &config}); !errors.Is(err, params.ErrInvalidZenaConfig) {
+		t.Fatalf("Expected error on invalid zena config, got %v", err)
+	}
+
+	// The chains already started keep running
+	db = rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, nil)
+	if _, err := (&Genesis{Config: params.ZenaTestChainConfig}).Commit(db, tdb); err != nil {
+		t.Fatalf("Failed to commit genesis: %v", err)
+	}
+	if _, _, err := SetupGenesisBlock(db, tdb, &Genesis{Config: &config}); err != nil {
+		t.Fatalf("Unexpected error on stored chain with invalid zena config: %v", err)
+	}
+}
+
 func TestSetupGenesis(t *testing.T) {
 	testSetupGenesis(t, rawdb.HashScheme)
 	testSetupGenesis(t, rawdb.PathScheme)
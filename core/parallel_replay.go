@@ -0,0 +1,319 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+)
+
+// ParallelReplay compares the serial and the parallel execution of a block.
+type ParallelReplay struct {
+	Number        uint64         `json:"number"`
+	Hash          common.Hash    `json:"hash"`
+	Transactions  int            `json:"transactions"`
+	SerialRoot    common.Hash    `json:"serialRoot"`
+	ParallelRoot  common.Hash    `json:"parallelRoot"`
+	SerialError   string         `json:"serialError,omitempty"`
+	ParallelError string         `json:"parallelError,omitempty"`
+	Divergences   []TxDivergence `json:"divergences"`
+}
+
+// Diverged reports whether the serial and the parallel executions differ.
+func (r *ParallelReplay) Diverged() bool {
+	return r.SerialRoot != r.ParallelRoot || r.SerialError != r.ParallelError || len(r.Divergences) > 0
+}
+
+// TxDivergence is a transaction whose serial and parallel executions differ.
+type TxDivergence struct {
+	Index   int             `json:"index"`
+	Hash    common.Hash     `json:"hash"`
+	Receipt []string        `json:"receipt"` // Receipt fields which differ
+	State   []StateDiff     `json:"state"`   // Values written by the transaction which differ
+	Reads   []VersionedRead `json:"reads"`   // Values read by the parallel execution of the transaction
+}
+
+// StateDiff is a value written by a transaction, after its serial and its
+// parallel execution.
+type StateDiff struct {
+	Key      string `json:"key"`
+	Serial   string `json:"serial"`
+	Parallel string `json:"parallel"`
+}
+
+// VersionedRead is a value read by the last incarnation of a transaction from
+// the MVHashMap, or from the state of the parent block.
+type VersionedRead struct {
+	Key         string `json:"key"`
+	Source      string `json:"source"` // "mvhashmap" or "storage"
+	TxIndex     int    `json:"txIndex"`
+	Incarnation int    `json:"incarnation"`
+}
+
+// ReplayParallel executes block again on statedb, the state of its parent,
+// with both the serial and the parallel state processor. The receipts and the
+// values written by every transaction are compared, and the MVHashMap versions
+// read by the diverging transactions are listed. statedb is not modified.
+//
+// The parallel execution runs on a processor of its own, so blocks can be
+// replayed whether or not the chain processes them in parallel, without
+// affecting the worker count of the live processor.
+func (bc *BlockChain) ReplayParallel(block *types.Block, statedb *state.StateDB) (*ParallelReplay, error) {
+	procs := bc.parallelSpeculativeProcesses
+	if procs <= 0 {
+		procs = runtime.GOMAXPROCS(0)
+	}
+
+	return bc.replayParallel(block, statedb, newStandaloneParallelStateProcessor(bc, procs).execute)
+}
+
+// replayParallel replays block with execute as the parallel execution.
+func (bc *BlockChain) replayParallel(block *types.Block, statedb *state.StateDB, execute func(*types.Block, *state.StateDB, vm.Config, context.Context) (*parallelExecution, error)) (*ParallelReplay, error) {
+	var (
+		header = block.Header()
+		eip158 = bc.chainConfig.IsEIP158(header.Number)
+		cfg    = bc.vmConfig
+		replay = &ParallelReplay{
+			Number:       block.NumberU64(),
+			Hash:         block.Hash(),
+			Transactions: len(block.Transactions()),
+			Divergences:  []TxDivergence{},
+		}
+	)
+
+	cfg.Tracer = nil
+
+	parallelState := statedb.Copy()
+
+	execution, err := execute(block, parallelState, cfg, nil)
+	if err != nil {
+		replay.ParallelError = err.Error()
+	} else {
+		bc.engine.Finalize(bc, header, parallelState, block.Body())
+		replay.ParallelRoot = parallelState.IntermediateRoot(eip158)
+	}
+
+	// Compare the values written by every transaction as soon as it's executed serially
+	var (
+		serialState = statedb.Copy()
+		stateDiffs  = make(map[int][]StateDiff)
+	)
+
+	cfg.Tracer = &tracing.Hooks{
+		OnTxStart: func(*tracing.VMContext, *types.Transaction, common.Address) {},
+		OnTxEnd: func(receipt *types.Receipt, err error) {
+			if err != nil || execution == nil {
+				return
+			}
+
+			i := int(receipt.TransactionIndex)
+			if i < len(execution.tasks) {
+				if diffs := diffWrites(serialState, execution.tasks[i].(*ExecutionTask)); len(diffs) > 0 {
+					stateDiffs[i] = diffs
+				}
+			}
+		},
+	}
+
+	receipts, _, _, err := NewStateProcessor(bc.chainConfig, bc, bc.hc).Process(block, serialState, cfg, nil)
+	if err != nil {
+		replay.SerialError = err.Error()
+	} else {
+		replay.SerialRoot = serialState.IntermediateRoot(eip158)
+	}
+
+	if execution == nil || receipts == nil {
+		return replay, nil
+	}
+
+	for i, tx := range block.Transactions() {
+		var fields []string
+
+		if i < len(receipts) && i < len(execution.receipts) {
+			fields = diffReceipts(receipts[i], execution.receipts[i])
+		} else {
+			fields = []string{"missing"}
+		}
+
+		if len(fields) == 0 && len(stateDiffs[i]) == 0 {
+			continue
+		}
+
+		divergence := TxDivergence{
+			Index:   i,
+			Hash:    tx.Hash(),
+			Receipt: fields,
+			State:   stateDiffs[i],
+			Reads:   []VersionedRead{},
+		}
+
+		if divergence.Receipt == nil {
+			divergence.Receipt = []string{}
+		}
+
+		if divergence.State == nil {
+			divergence.State = []StateDiff{}
+		}
+
+		if execution.result.TxIO != nil {
+			for _, rd := range execution.result.TxIO.ReadSet(i) {
+				read := VersionedRead{
+					Key:         describeKey(rd.Path),
+					Source:      "storage",
+					TxIndex:     rd.V.TxnIndex,
+					Incarnation: rd.V.Incarnation,
+				}
+
+				if rd.Kind == blockstm.ReadKindMap {
+					read.Source = "mvhashmap"
+				}
+
+				divergence.Reads = append(divergence.Reads, read)
+			}
+		}
+
+		replay.Divergences = append(replay.Divergences, divergence)
+	}
+
+	return replay, nil
+}
+
+// diffReceipts returns the fields of the receipts which differ.
+func diffReceipts(serial, parallel *types.Receipt) []string {
+	var fields []string
+
+	if serial.Status != parallel.Status {
+		fields = append(fields, "status")
+	}
+
+	if serial.GasUsed != parallel.GasUsed {
+		fields = append(fields, "gasUsed")
+	}
+
+	if serial.CumulativeGasUsed != parallel.CumulativeGasUsed {
+		fields = append(fields, "cumulativeGasUsed")
+	}
+
+	if serial.ContractAddress != parallel.ContractAddress {
+		fields = append(fields, "contractAddress")
+	}
+
+	if !bytes.Equal(serial.PostState, parallel.PostState) {
+		fields = append(fields, "root")
+	}
+
+	if serial.Bloom != parallel.Bloom {
+		fields = append(fields, "logsBloom")
+	}
+
+	if !logsEqual(serial.Logs, parallel.Logs) {
+		fields = append(fields, "logs")
+	}
+
+	return fields
+}
+
+func logsEqual(a, b []*types.Log) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Address != b[i].Address || a[i].Index != b[i].Index || !bytes.Equal(a[i].Data, b[i].Data) || len(a[i].Topics) != len(b[i].Topics) {
+			return false
+		}
+
+		for j := range a[i].Topics {
+			if a[i].Topics[j] != b[i].Topics[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// diffWrites compares the values written by the last incarnation of a task
+// with serialState, right after the serial execution of the same transaction.
+// Account creations and self-destructs are left to the state roots, and so are
+// the fee balances while fees are only paid when the task settles.
+func diffWrites(serialState *state.StateDB, task *ExecutionTask) []StateDiff {
+	feeKeys := make(map[blockstm.Key]struct{})
+
+	if *task.shouldDelayFeeCal {
+		feeKeys[blockstm.NewSubpathKey(task.coinbase, state.BalancePath)] = struct{}{}
+		feeKeys[blockstm.NewSubpathKey(task.result.BurntContractAddress, state.BalancePath)] = struct{}{}
+	}
+
+	var diffs []StateDiff
+
+	for _, write := range task.MVFullWriteList() {
+		if _, ok := feeKeys[write.Path]; ok {
+			continue
+		}
+
+		serial, ok := readKey(serialState, write.Path)
+		if !ok {
+			continue
+		}
+
+		if parallel, _ := readKey(task.statedb, write.Path); serial != parallel {
+			diffs = append(diffs, StateDiff{Key: describeKey(write.Path), Serial: serial, Parallel: parallel})
+		}
+	}
+
+	return diffs
+}
+
+// readKey returns the value of the storage slot or account field of k.
+func readKey(s *state.StateDB, k blockstm.Key) (string, bool) {
+	addr := k.GetAddress()
+
+	switch {
+	case k.IsState():
+		return s.GetState(addr, k.GetStateKey()).Hex(), true
+	case k.IsSubpath():
+		switch k.GetSubpath() {
+		case state.BalancePath:
+			return s.GetBalance(addr).String(), true
+		case state.NoncePath:
+			return fmt.Sprint(s.GetNonce(addr)), true
+		case state.CodePath:
+			return s.GetCodeHash(addr).Hex(), true
+		}
+	}
+
+	return "", false
+}
+
+// describeKey returns a readable form of k.
+func describeKey(k blockstm.Key) string {
+	addr := k.GetAddress().Hex()
+
+	switch {
+	case k.IsState():
+		return fmt.Sprintf("%s storage %s", addr, k.GetStateKey().Hex())
+	case k.IsSubpath():
+		switch k.GetSubpath() {
+		case state.BalancePath:
+			return addr + " balance"
+		case state.NoncePath:
+			return addr + " nonce"
+		case state.CodePath:
+			return addr + " code"
+		case state.SuicidePath:
+			return addr + " selfdestruct"
+		}
+
+		return fmt.Sprintf("%s subpath %d", addr, k.GetSubpath())
+	}
+
+	return addr + " account"
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/ethash"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
+)
+
+func TestReplayParallel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		engine  = ethash.NewFaker()
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		funds   = new(big.Int).Mul(common.Big1, big.NewInt(params.Zen))
+		gspec   = &Genesis{
+			Config: params.AllEthashProtocolChanges,
+			Alloc: types.GenesisAlloc{
+				addr1: {Balance: funds},
+				addr2: {Balance: funds},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	// Every block holds independent transfers, and transfers between the senders which conflict with them
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *BlockGen) {
+		transfer := func(key *ecdsa.PrivateKey, from, to common.Address) {
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(from),
+				To:       &to,
+				Value:    big.NewInt(1000),
+				Gas:      params.TxGas,
+				GasPrice: b.BaseFee(),
+			}))
+		}
+
+		transfer(key1, addr1, common.HexToAddress("0x1001"))
+		transfer(key2, addr2, common.HexToAddress("0x1002"))
+		transfer(key1, addr1, addr2)
+		transfer(key2, addr2, addr1)
+	})
+
+	chain, err := NewParallelBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil, nil, 8, false)
+	require.NoError(t, err)
+
+	defer chain.Stop()
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	for _, block := range blocks {
+		statedb, err := chain.StateAt(chain.GetHeaderByHash(block.ParentHash()).Root)
+		require.NoError(t, err)
+
+		replay, err := chain.ReplayParallel(block, statedb)
+		require.NoError(t, err)
+
+		require.False(t, replay.Diverged())
+		require.Equal(t, len(block.Transactions()), replay.Transactions)
+		require.Equal(t, block.Root(), replay.SerialRoot)
+		require.Equal(t, block.Root(), replay.ParallelRoot)
+		require.Empty(t, replay.Divergences)
+	}
+
+	// Divergences injected in the parallel execution are reported
+	statedb, err := chain.StateAt(chain.Genesis().Root())
+	require.NoError(t, err)
+
+	pp := newStandaloneParallelStateProcessor(chain, 8)
+
+	replay, err := chain.replayParallel(blocks[0], statedb, func(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (*parallelExecution, error) {
+		execution, err := pp.execute(block, statedb, cfg, interruptCtx)
+		if err == nil {
+			execution.receipts[1].GasUsed++
+			statedb.AddBalance(common.HexToAddress("0x1002"), uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+		}
+
+		return execution, err
+	})
+	require.NoError(t, err)
+
+	require.True(t, replay.Diverged())
+	require.Equal(t, blocks[0].Root(), replay.SerialRoot)
+	require.NotEqual(t, blocks[0].Root(), replay.ParallelRoot)
+	require.Len(t, replay.Divergences, 1)
+	require.Equal(t, 1, replay.Divergences[0].Index)
+	require.Equal(t, blocks[0].Transactions()[1].Hash(), replay.Divergences[0].Hash)
+	require.Equal(t, []string{"gasUsed"}, replay.Divergences[0].Receipt)
+	require.Empty(t, replay.Divergences[0].State)
+	require.NotEmpty(t, replay.Divergences[0].Reads)
+
+	// Chains processing their blocks serially replay them too
+	serial, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	defer serial.Stop()
+
+	statedb, err = serial.StateAt(serial.Genesis().Root())
+	require.NoError(t, err)
+
+	replay, err = serial.ReplayParallel(blocks[0], statedb)
+	require.NoError(t, err)
+
+	require.False(t, replay.Diverged())
+	require.Equal(t, blocks[0].Root(), replay.ParallelRoot)
+}
@@ -18,8 +18,10 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/zenanetwork/go-zenanet/common"
@@ -41,6 +43,41 @@ type ParallelEVMConfig struct {
 	Enable               bool
 	SpeculativeProcesses int
 	Enforce              bool
+	Reports              bool             // Persist a report of the parallel execution of every block
+	ReportsRetention     uint64           // Number of recent blocks whose reports are kept, 0 for all
+	TxDependencyMode     TxDependencyMode // How the transaction dependencies declared in the block header are used
+	Adaptive             bool             // Size the speculative workers block by block, up to SpeculativeProcesses
+}
+
+// TxDependencyMode sets how the parallel state processor uses the transaction
+// dependencies declared in the header of a block.
+type TxDependencyMode string
+
+const (
+	// TxDependencyTrust schedules the transactions by their declared dependencies
+	// without speculation, falling back to a full Block-STM execution if they are
+	// incomplete.
+	TxDependencyTrust TxDependencyMode = "trust"
+
+	// TxDependencyVerify uses the declared dependencies as scheduling hints,
+	// speculation and validation make up for the missing ones.
+	TxDependencyVerify TxDependencyMode = "verify"
+
+	// TxDependencyIgnore discards the declared dependencies.
+	TxDependencyIgnore TxDependencyMode = "ignore"
+)
+
+// ParseTxDependencyMode parses a transaction dependency mode, the empty string
+// being the default verify mode.
+func ParseTxDependencyMode(mode string) (TxDependencyMode, error) {
+	switch m := TxDependencyMode(mode); m {
+	case "":
+		return TxDependencyVerify, nil
+	case TxDependencyTrust, TxDependencyVerify, TxDependencyIgnore:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid transaction dependency mode %q, expected %q, %q or %q", mode, TxDependencyTrust, TxDependencyVerify, TxDependencyIgnore)
+	}
 }
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -51,6 +88,15 @@ type ParallelStateProcessor struct {
 	config *params.ChainConfig // Chain configuration options
 	bc     *BlockChain         // Canonical block chain
 	engine consensus.Engine    // Consensus engine used for block rewards
+	procs  int                 // Fixed number of workers of a standalone processor, 0 to follow the chain settings
+
+	lastReport atomic.Pointer[parallelReport] // Report of the last processed block, until it is validated
+}
+
+// parallelReport is the report of the parallel execution of a block.
+type parallelReport struct {
+	hash   common.Hash
+	report *blockstm.ExecutionReport
 }
 
 // NewParallelStateProcessor initialises a new StateProcessor.
@@ -62,6 +108,18 @@ func NewParallelStateProcessor(config *params.ChainConfig, bc *BlockChain, engin
 	}
 }
 
+// newStandaloneParallelStateProcessor creates a parallel state processor for the
+// blocks of bc running procs workers, whether or not bc processes its blocks in
+// parallel. It leaves the concurrency statistics of bc untouched.
+func newStandaloneParallelStateProcessor(bc *BlockChain, procs int) *ParallelStateProcessor {
+	return &ParallelStateProcessor{
+		config: bc.chainConfig,
+		bc:     bc,
+		engine: bc.engine,
+		procs:  procs,
+	}
+}
+
 type ExecutionTask struct {
 	msg    Message
 	config *params.ChainConfig
@@ -255,7 +313,27 @@ func (task *ExecutionTask) Settle() {
 	*task.allLogs = append(*task.allLogs, receipt.Logs...)
 }
 
-var parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability", nil)
+var (
+	parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability", nil)
+
+	txDependencyInvalidMeter    = metrics.NewRegisteredMeter("blockstm/txdeps/invalid", nil)    // Blocks with malformed declared dependencies
+	txDependencyTrustedMeter    = metrics.NewRegisteredMeter("blockstm/txdeps/trusted", nil)    // Blocks executed by their declared dependencies only
+	txDependencyIncompleteMeter = metrics.NewRegisteredMeter("blockstm/txdeps/incomplete", nil) // Blocks whose declared dependencies missed a conflict
+	txDependencyFallbackMeter   = metrics.NewRegisteredMeter("blockstm/txdeps/fallback", nil)   // Trusted executions re-run with Block-STM
+)
+
+// parallelReportHotKeys is the number of conflicting keys listed in a parallel execution report.
+const parallelReportHotKeys = 16
+
+// parallelExecution is the outcome of the parallel execution of the
+// transactions of a block, before the block is finalized.
+type parallelExecution struct {
+	receipts types.Receipts
+	logs     []*types.Log
+	usedGas  uint64
+	tasks    []blockstm.ExecTask
+	result   blockstm.ParallelExecutionResult
+}
 
 // Process processes the state changes according to the Zenanet rules by running
 // the transaction messages using the statedb and applying any rewards to both
@@ -264,8 +342,46 @@ var parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-// nolint:gocognit
 func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (types.Receipts, []*types.Log, uint64, error) {
+	execution, err := p.execute(block, statedb, cfg, interruptCtx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if p.bc.parallelExecutionReports {
+		gasUsed := make([]uint64, len(execution.tasks))
+		for i, task := range execution.tasks {
+			gasUsed[i] = task.(*ExecutionTask).result.UsedGas
+		}
+
+		// The report is only persisted once the block is validated
+		p.lastReport.Store(&parallelReport{
+			hash:   block.Hash(),
+			report: blockstm.NewExecutionReport(execution.result, gasUsed, parallelReportHotKeys),
+		})
+	}
+
+	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
+	p.engine.Finalize(p.bc, block.Header(), statedb, block.Body())
+
+	return execution.receipts, execution.logs, execution.usedGas, nil
+}
+
+// takeReport returns the report of the last block processed, if it is the
+// block with the given hash, and forgets it.
+func (p *ParallelStateProcessor) takeReport(hash common.Hash) *blockstm.ExecutionReport {
+	last := p.lastReport.Swap(nil)
+	if last == nil || last.hash != hash {
+		return nil
+	}
+
+	return last.report
+}
+
+// execute runs the transactions of the block with Block-STM on statedb,
+// without finalizing the block.
+// nolint:gocognit
+func (p *ParallelStateProcessor) execute(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (*parallelExecution, error) {
 	var (
 		receipts    types.Receipts
 		header      = block.Header()
@@ -291,7 +407,15 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 
 	deps := GetDeps(blockTxDependency)
 
-	if !VerifyDeps(deps) || len(blockTxDependency) != len(block.Transactions()) {
+	mode := p.bc.parallelTxDependencyMode
+
+	if blockTxDependency != nil && (!VerifyDeps(deps) || len(blockTxDependency) != len(block.Transactions())) {
+		txDependencyInvalidMeter.Mark(1)
+
+		blockTxDependency = nil
+	}
+
+	if blockTxDependency == nil || mode == TxDependencyIgnore {
 		blockTxDependency = nil
 		deps = make(map[int][]int)
 	}
@@ -307,7 +431,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 		msg, err := TransactionToMessage(tx, types.MakeSigner(p.config, header.Number, header.Time), header.BaseFee)
 		if err != nil {
 			log.Error("error creating message", "err", err)
-			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 
 		cleansdb := statedb.Copy()
@@ -345,7 +469,70 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 	backupStateDB := statedb.Copy()
 
 	profile := false
-	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
+
+	concurrency, numProcs := p.bc.parallelConcurrency, p.bc.parallelSpeculativeProcesses
+	if p.procs > 0 {
+		concurrency, numProcs = nil, p.procs
+	}
+
+	if concurrency != nil {
+		numProcs = concurrency.Workers(tasks, metadata)
+	}
+
+	var (
+		result blockstm.ParallelExecutionResult
+		err    error
+		start  = time.Now()
+	)
+
+	// Every execution, the discarded ones included, tells how much the
+	// transactions conflict
+	updateConcurrency := func() {
+		if concurrency != nil {
+			concurrency.Update(result, len(tasks), time.Since(start))
+		}
+
+		start = time.Now()
+	}
+
+	if metadata && mode == TxDependencyTrust {
+		result, err = blockstm.ExecuteParallelTrusted(tasks, profile, numProcs, interruptCtx)
+		updateConcurrency()
+
+		var incomplete blockstm.IncompleteDependenciesError
+		if errors.As(err, &incomplete) {
+			txDependencyIncompleteMeter.Mark(1)
+			txDependencyFallbackMeter.Mark(1)
+			log.Warn("Declared transaction dependencies are incomplete, re-executing block with Block-STM", "number", blockNumber, "hash", blockHash, "tx", incomplete.Tx)
+
+			// Discard the transactions settled so far and drop the declared dependencies
+			// nolint
+			*statedb = *backupStateDB.Copy()
+
+			allLogs = []*types.Log{}
+			receipts = types.Receipts{}
+			*usedGas = 0
+
+			for _, t := range tasks {
+				t := t.(*ExecutionTask)
+				t.dependencies = nil
+				t.shouldRerunWithoutFeeDelay = false
+			}
+
+			metadata = false
+			result, err = blockstm.ExecuteParallel(tasks, profile, metadata, numProcs, interruptCtx)
+			updateConcurrency()
+		} else if err == nil {
+			txDependencyTrustedMeter.Mark(1)
+		}
+	} else {
+		result, err = blockstm.ExecuteParallel(tasks, profile, metadata, numProcs, interruptCtx)
+		updateConcurrency()
+
+		if err == nil && metadata && result.Aborts+result.ValidationFailures > 0 {
+			txDependencyIncompleteMeter.Mark(1)
+		}
+	}
 
 	if err == nil && profile && result.Deps != nil {
 		_, weight := result.Deps.LongestPath(*result.Stats)
@@ -379,20 +566,24 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 				t.totalUsedGas = usedGas
 			}
 
-			_, err = blockstm.ExecuteParallel(tasks, false, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
+			result, err = blockstm.ExecuteParallel(tasks, false, metadata, numProcs, interruptCtx)
+			updateConcurrency()
 
 			break
 		}
 	}
 
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, err
 	}
 
-	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
-	p.engine.Finalize(p.bc, header, statedb, block.Body())
-
-	return receipts, allLogs, *usedGas, nil
+	return &parallelExecution{
+		receipts: receipts,
+		logs:     allLogs,
+		usedGas:  *usedGas,
+		tasks:    tasks,
+		result:   result,
+	}, nil
 }
 
 func GetDeps(txDependency [][]uint64) map[int][]int {
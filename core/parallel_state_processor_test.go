@@ -1,9 +1,20 @@
 package core
 
 import (
+	"crypto/ecdsa"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/ethash"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
 )
 
 func TestMetadata(t *testing.T) {
@@ -28,3 +39,72 @@ func TestMetadata(t *testing.T) {
 	temp = GetDeps(wrongTxDependencyOutOfRange)
 	assert.Equal(t, false, VerifyDeps(temp))
 }
+
+// Tests that the transactions deploying contracts which self-destruct in their
+// constructor are settled like the serial processor does, the self-destruct
+// applying once the other writes created the account.
+func TestParallelSelfDestruct(t *testing.T) {
+	t.Parallel()
+
+	var (
+		engine  = ethash.NewFaker()
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+		funds   = new(big.Int).Mul(common.Big1, big.NewInt(params.Zen))
+		victim  = common.HexToAddress("0x1000") // Sends its balance to the caller and self-destructs
+		gspec   = &Genesis{
+			Config: params.AllEthashProtocolChanges,
+			Alloc: types.GenesisAlloc{
+				addr1:  {Balance: funds},
+				addr2:  {Balance: funds},
+				victim: {Balance: big.NewInt(1000), Code: common.FromHex("0x33ff"), Storage: map[common.Hash]common.Hash{{1}: {1}}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	// The contracts send their balance back to their creator and self-destruct
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *BlockGen) {
+		deploy := func(key *ecdsa.PrivateKey, from common.Address) {
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(from),
+				Value:    big.NewInt(1000),
+				Gas:      100000,
+				GasPrice: b.BaseFee(),
+				Data:     common.FromHex("0x33ff"),
+			}))
+		}
+
+		for j := 0; j < 4; j++ {
+			deploy(key1, addr1)
+			deploy(key2, addr2)
+		}
+
+		b.AddTx(types.MustSignNewTx(key1, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(addr1),
+			To:       &victim,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	defer chain.Stop()
+
+	statedb, err := chain.StateAt(chain.Genesis().Root())
+	require.NoError(t, err)
+
+	serialState := statedb.Copy()
+	_, _, _, err = NewStateProcessor(chain.chainConfig, chain, chain.hc).Process(blocks[0], serialState, vm.Config{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, blocks[0].Root(), serialState.IntermediateRoot(true))
+
+	parallelState := statedb.Copy()
+	_, _, _, err = newStandaloneParallelStateProcessor(chain, 8).Process(blocks[0], parallelState, vm.Config{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, blocks[0].Root(), parallelState.IntermediateRoot(true))
+}
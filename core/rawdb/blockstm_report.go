@@ -0,0 +1,39 @@
+package rawdb
+
+import (
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// parallelExecutionReportPrefix + num (uint64 big endian) + hash -> parallel execution report
+var parallelExecutionReportPrefix = []byte("blockstm-report-")
+
+// ReadParallelExecutionReport retrieves the RLP encoded report of the parallel
+// execution of a block.
+func ReadParallelExecutionReport(db ethdb.KeyValueReader, hash common.Hash, number uint64) []byte {
+	data, _ := db.Get(blockRecordKey(parallelExecutionReportPrefix, number, hash))
+	return data
+}
+
+// WriteParallelExecutionReport stores the RLP encoded report of the parallel
+// execution of a block. Reports are optional, so failures are logged and
+// otherwise ignored.
+func WriteParallelExecutionReport(db ethdb.KeyValueWriter, hash common.Hash, number uint64, report []byte) {
+	if err := db.Put(blockRecordKey(parallelExecutionReportPrefix, number, hash), report); err != nil {
+		log.Error("Failed to store parallel execution report", "number", number, "hash", hash, "err", err)
+	}
+}
+
+// DeleteParallelExecutionReport removes the report of the parallel execution of a block.
+func DeleteParallelExecutionReport(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockRecordKey(parallelExecutionReportPrefix, number, hash)); err != nil {
+		log.Error("Failed to delete parallel execution report", "number", number, "hash", hash, "err", err)
+	}
+}
+
+// DeleteParallelExecutionReportsBefore removes the reports of the parallel
+// execution of all the blocks below the given number.
+func DeleteParallelExecutionReportsBefore(db ethdb.KeyValueStore, number uint64) {
+	deleteBlockRecordsBefore(db, parallelExecutionReportPrefix, number, "parallel execution report")
+}
@@ -0,0 +1,35 @@
+package rawdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// Tests that the parallel execution reports below a block number are pruned,
+// and the others kept.
+func TestDeleteParallelExecutionReportsBefore(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	hashes := make([]common.Hash, 10)
+	for i := range hashes {
+		hashes[i] = common.BigToHash(common.Big1)
+		hashes[i][0] = byte(i)
+
+		WriteParallelExecutionReport(db, hashes[i], uint64(i), []byte{byte(i)})
+	}
+
+	DeleteParallelExecutionReportsBefore(db, 6)
+
+	for i, hash := range hashes {
+		report := ReadParallelExecutionReport(db, hash, uint64(i))
+		if i < 6 && report != nil {
+			t.Fatalf("report %d not pruned", i)
+		}
+
+		if i >= 6 && !bytes.Equal(report, []byte{byte(i)}) {
+			t.Fatalf("report %d mismatch: have %v", i, report)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// nolint
+package rawdb
+
+import (
+	"github.com/zenanetwork/go-zenanet/ethdb"
+)
+
+// finalityAuditPrefix + entry id (uint64 big endian) -> entry, the records of
+// the changes made by the operators to the milestone locks and future milestones.
+var finalityAuditPrefix = []byte("finality-audit-")
+
+// ReadFinalityAuditEntries retrieves the ids and the encodings of all the stored
+// finality audit entries, ordered by id.
+func ReadFinalityAuditEntries(db ethdb.Iteratee) ([]uint64, [][]byte) {
+	return readRecords(db, finalityAuditPrefix)
+}
+
+// WriteFinalityAuditEntry stores the encoding of the finality audit entry with the given id.
+func WriteFinalityAuditEntry(db ethdb.KeyValueWriter, id uint64, data []byte) error {
+	return writeRecord(db, recordKey(finalityAuditPrefix, id), data, "finality audit entry")
+}
+
+// DeleteFinalityAuditEntry removes the finality audit entry with the given id.
+func DeleteFinalityAuditEntry(db ethdb.KeyValueWriter, id uint64) error {
+	return deleteRecord(db, recordKey(finalityAuditPrefix, id), "finality audit entry")
+}
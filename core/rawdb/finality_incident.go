@@ -0,0 +1,26 @@
+// nolint
+package rawdb
+
+import (
+	"github.com/zenanetwork/go-zenanet/ethdb"
+)
+
+// finalityIncidentPrefix + incident id (uint64 big endian) -> incident, the
+// records of the checkpoints and milestones which didn't match the local chain.
+var finalityIncidentPrefix = []byte("finality-incident-")
+
+// ReadFinalityIncidents retrieves the ids and the encodings of all the stored
+// finality incidents, ordered by id.
+func ReadFinalityIncidents(db ethdb.Iteratee) ([]uint64, [][]byte) {
+	return readRecords(db, finalityIncidentPrefix)
+}
+
+// WriteFinalityIncident stores the encoding of the finality incident with the given id.
+func WriteFinalityIncident(db ethdb.KeyValueWriter, id uint64, data []byte) error {
+	return writeRecord(db, recordKey(finalityIncidentPrefix, id), data, "finality incident")
+}
+
+// DeleteFinalityIncident removes the finality incident with the given id.
+func DeleteFinalityIncident(db ethdb.KeyValueWriter, id uint64) error {
+	return deleteRecord(db, recordKey(finalityIncidentPrefix, id), "finality incident")
+}
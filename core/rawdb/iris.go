@@ -0,0 +1,70 @@
+// nolint
+package rawdb
+
+import (
+	"github.com/zenanetwork/go-zenanet/ethdb"
+)
+
+// The iris namespace keeps finalized responses fetched from Iris, so that they
+// don't have to be fetched again on restarts and resyncs.
+var (
+	irisSpanPrefix       = []byte("iris-span-")       // irisSpanPrefix + span id (uint64 big endian) -> span
+	irisCheckpointPrefix = []byte("iris-checkpoint-") // irisCheckpointPrefix + checkpoint number (uint64 big endian) -> checkpoint
+	irisStateSyncPrefix  = []byte("iris-state-sync-") // irisStateSyncPrefix + from id (uint64 big endian) + to time (uint64 big endian) -> event records
+)
+
+// irisStateSyncKey = irisStateSyncPrefix + from id (uint64 big endian) + to time (uint64 big endian)
+func irisStateSyncKey(fromID uint64, to uint64) []byte {
+	return append(recordKey(irisStateSyncPrefix, fromID), encodeBlockNumber(to)...)
+}
+
+// ReadIrisSpan retrieves the cached encoding of the span with the given id.
+func ReadIrisSpan(db ethdb.KeyValueReader, id uint64) []byte {
+	data, _ := db.Get(recordKey(irisSpanPrefix, id))
+	return data
+}
+
+// WriteIrisSpan stores the encoding of the span with the given id.
+func WriteIrisSpan(db ethdb.KeyValueWriter, id uint64, data []byte) error {
+	return writeRecord(db, recordKey(irisSpanPrefix, id), data, "iris span")
+}
+
+// DeleteIrisSpan removes the cached span with the given id.
+func DeleteIrisSpan(db ethdb.KeyValueWriter, id uint64) error {
+	return deleteRecord(db, recordKey(irisSpanPrefix, id), "iris span")
+}
+
+// ReadIrisCheckpoint retrieves the cached encoding of the checkpoint with the given number.
+func ReadIrisCheckpoint(db ethdb.KeyValueReader, number uint64) []byte {
+	data, _ := db.Get(recordKey(irisCheckpointPrefix, number))
+	return data
+}
+
+// WriteIrisCheckpoint stores the encoding of the checkpoint with the given number.
+func WriteIrisCheckpoint(db ethdb.KeyValueWriter, number uint64, data []byte) error {
+	return writeRecord(db, recordKey(irisCheckpointPrefix, number), data, "iris checkpoint")
+}
+
+// DeleteIrisCheckpoint removes the cached checkpoint with the given number.
+func DeleteIrisCheckpoint(db ethdb.KeyValueWriter, number uint64) error {
+	return deleteRecord(db, recordKey(irisCheckpointPrefix, number), "iris checkpoint")
+}
+
+// ReadIrisStateSyncEvents retrieves the cached encoding of the state-sync
+// events starting at fromID and recorded before the to time.
+func ReadIrisStateSyncEvents(db ethdb.KeyValueReader, fromID uint64, to uint64) []byte {
+	data, _ := db.Get(irisStateSyncKey(fromID, to))
+	return data
+}
+
+// WriteIrisStateSyncEvents stores the encoding of the state-sync events
+// starting at fromID and recorded before the to time.
+func WriteIrisStateSyncEvents(db ethdb.KeyValueWriter, fromID uint64, to uint64, data []byte) error {
+	return writeRecord(db, irisStateSyncKey(fromID, to), data, "iris state-sync events")
+}
+
+// DeleteIrisStateSyncEvents removes the cached state-sync events starting at
+// fromID and recorded before the to time.
+func DeleteIrisStateSyncEvents(db ethdb.KeyValueWriter, fromID uint64, to uint64) error {
+	return deleteRecord(db, irisStateSyncKey(fromID, to), "iris state-sync events")
+}
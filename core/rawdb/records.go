@@ -0,0 +1,94 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// Records are opaque values kept under a prefix, either per id or per block.
+// Their owners encode and decode them.
+
+// recordKey = prefix + id (uint64 big endian)
+func recordKey(prefix []byte, id uint64) []byte {
+	return append(prefix, encodeBlockNumber(id)...)
+}
+
+// blockRecordKey = prefix + num (uint64 big endian) + hash
+func blockRecordKey(prefix []byte, number uint64, hash common.Hash) []byte {
+	return append(append(prefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// readRecords retrieves the ids and the values of all the records stored
+// under prefix, ordered by id.
+func readRecords(db ethdb.Iteratee, prefix []byte) ([]uint64, [][]byte) {
+	var (
+		ids  []uint64
+		data [][]byte
+	)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+8 {
+			ids = append(ids, binary.BigEndian.Uint64(key[len(prefix):]))
+			data = append(data, common.CopyBytes(it.Value()))
+		}
+	}
+
+	return ids, data
+}
+
+// writeRecord stores a record, kind names it in the errors.
+func writeRecord(db ethdb.KeyValueWriter, key []byte, data []byte, kind string) error {
+	if err := db.Put(key, data); err != nil {
+		log.Error(fmt.Sprintf("Failed to store the %s", kind), "err", err)
+
+		return fmt.Errorf("%w: %v for %s", ErrDBNotResponding, err, kind)
+	}
+
+	return nil
+}
+
+// deleteRecord removes a record, kind names it in the errors.
+func deleteRecord(db ethdb.KeyValueWriter, key []byte, kind string) error {
+	if err := db.Delete(key); err != nil {
+		log.Error(fmt.Sprintf("Failed to delete the %s", kind), "err", err)
+
+		return fmt.Errorf("%w: %v for %s", ErrDBNotResponding, err, kind)
+	}
+
+	return nil
+}
+
+// deleteBlockRecordsBefore removes the records stored under prefix of all the
+// blocks below the given number.
+func deleteBlockRecordsBefore(db ethdb.KeyValueStore, prefix []byte, number uint64, kind string) {
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+
+		if binary.BigEndian.Uint64(key[len(prefix):]) >= number {
+			break
+		}
+
+		if err := batch.Delete(key); err != nil {
+			log.Error(fmt.Sprintf("Failed to delete the %s", kind), "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Error(fmt.Sprintf("Failed to prune the %ss", kind), "number", number, "err", err)
+	}
+}
@@ -0,0 +1,43 @@
+package rawdb
+
+import (
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// zenaProducerRecordPrefix + num (uint64 big endian) + hash -> producer record,
+// the outcome of the block slot of a canonical block.
+var zenaProducerRecordPrefix = []byte("zena-producer-")
+
+// ReadZenaProducerRecord retrieves the encoding of the producer record of a block.
+func ReadZenaProducerRecord(db ethdb.KeyValueReader, hash common.Hash, number uint64) []byte {
+	data, _ := db.Get(blockRecordKey(zenaProducerRecordPrefix, number, hash))
+	return data
+}
+
+// HasZenaProducerRecord checks whether the producer record of a block is stored.
+func HasZenaProducerRecord(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	has, err := db.Has(blockRecordKey(zenaProducerRecordPrefix, number, hash))
+	return err == nil && has
+}
+
+// WriteZenaProducerRecord stores the encoding of the producer record of a block.
+func WriteZenaProducerRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64, data []byte) {
+	if err := db.Put(blockRecordKey(zenaProducerRecordPrefix, number, hash), data); err != nil {
+		log.Error("Failed to store producer record", "number", number, "hash", hash, "err", err)
+	}
+}
+
+// DeleteZenaProducerRecord removes the producer record of a block.
+func DeleteZenaProducerRecord(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockRecordKey(zenaProducerRecordPrefix, number, hash)); err != nil {
+		log.Error("Failed to delete producer record", "number", number, "hash", hash, "err", err)
+	}
+}
+
+// DeleteZenaProducerRecordsBefore removes the producer records of all the
+// blocks below the given number.
+func DeleteZenaProducerRecordsBefore(db ethdb.KeyValueStore, number uint64) {
+	deleteBlockRecordsBefore(db, zenaProducerRecordPrefix, number, "producer record")
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	cmath "github.com/zenanetwork/go-zenanet/common/math"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
+)
+
+// SpeculativeTx is a transaction executed on a private copy of the state,
+// without paying its fees. As long as none of the values it read were written
+// since the copy was made, its writes can be applied to the state instead of
+// executing it again.
+type SpeculativeTx struct {
+	tx           *types.Transaction
+	msg          *Message
+	blockContext vm.BlockContext
+	statedb      *state.StateDB // Copy of the state the transaction was executed on
+	result       *ExecutionResult
+}
+
+// ExecuteSpeculativeTx executes tx on statedb, a copy of the state the
+// transaction will be applied to with the transaction context set, recording
+// the values it reads and writes. Fees are left out so that transactions only
+// conflicting on the balance of the coinbase can be executed side by side.
+func ExecuteSpeculativeTx(config *params.ChainConfig, blockContext vm.BlockContext, statedb *state.StateDB, header *types.Header, tx *types.Transaction, gasLimit uint64, cfg vm.Config, interruptCtx context.Context) (*SpeculativeTx, error) {
+	msg, err := TransactionToMessage(tx, types.MakeSigner(config, header.Number, header.Time), header.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+
+	statedb.AddEmptyMVHashMap()
+	statedb.ClearReadMap()
+	statedb.ClearWriteMap()
+
+	evm := vm.NewEVM(blockContext, NewEVMTxContext(msg), statedb, config, cfg)
+
+	result, err := ApplyMessageNoFeeBurnOrTip(evm, *msg, new(GasPool).AddGas(gasLimit), interruptCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Err == vm.ErrInterrupt {
+		return nil, result.Err
+	}
+
+	return &SpeculativeTx{
+		tx:           tx,
+		msg:          msg,
+		blockContext: blockContext,
+		statedb:      statedb,
+		result:       result,
+	}, nil
+}
+
+func (s *SpeculativeTx) MVReadMap() map[blockstm.Key]blockstm.ReadDescriptor {
+	return s.statedb.MVReadMap()
+}
+
+func (s *SpeculativeTx) MVReadList() []blockstm.ReadDescriptor {
+	return s.statedb.MVReadList()
+}
+
+func (s *SpeculativeTx) MVFullWriteList() []blockstm.WriteDescriptor {
+	return s.statedb.MVFullWriteList()
+}
+
+// Apply applies the writes of the transaction to statedb and pays its fees,
+// returning its receipt. The caller must ensure statedb only differs from the
+// state the transaction was executed on by values it didn't read, and must set
+// the transaction context of statedb beforehand.
+func (s *SpeculativeTx) Apply(config *params.ChainConfig, gp *GasPool, statedb *state.StateDB, header *types.Header, usedGas *uint64) (*types.Receipt, error) {
+	// Buying the gas requires the full gas limit of the transaction to be available
+	if gp.Gas() < s.msg.GasLimit {
+		return nil, fmt.Errorf("%w: have %d, want %d", ErrGasLimitReached, gp.Gas(), s.msg.GasLimit)
+	}
+
+	if err := gp.SubGas(s.result.UsedGas); err != nil {
+		return nil, err
+	}
+
+	var (
+		blockNumber = header.Number
+		blockHash   = header.Hash()
+		coinbase    = s.blockContext.Coinbase
+	)
+
+	coinbaseBalance := statedb.GetBalance(coinbase)
+
+	statedb.ApplyMVWriteSet(s.statedb.MVFullWriteList())
+
+	for _, l := range s.statedb.GetLogs(s.tx.Hash(), blockNumber.Uint64(), blockHash) {
+		statedb.AddLog(l)
+	}
+
+	for k, v := range s.statedb.Preimages() {
+		statedb.AddPreimage(k, v)
+	}
+
+	if config.IsLondon(blockNumber) {
+		statedb.AddBalance(s.result.BurntContractAddress, cmath.BigIntToUint256Int(s.result.FeeBurnt), tracing.BalanceChangeTransfer)
+	}
+
+	statedb.AddBalance(coinbase, cmath.BigIntToUint256Int(s.result.FeeTipped), tracing.BalanceChangeTransfer)
+	output1 := new(big.Int).SetBytes(s.result.SenderInitBalance.Bytes())
+	output2 := new(big.Int).SetBytes(coinbaseBalance.Bytes())
+
+	// Deprecating transfer log and will be removed in future fork. PLEASE DO NOT USE this transfer log going forward. Parameters won't get updated as expected going forward with EIP1559
+	// add transfer log
+	AddFeeTransferLog(
+		statedb,
+
+		s.msg.From,
+		coinbase,
+
+		s.result.FeeTipped,
+		s.result.SenderInitBalance,
+		coinbaseBalance.ToBig(),
+		output1.Sub(output1, s.result.FeeTipped),
+		output2.Add(output2, s.result.FeeTipped),
+	)
+
+	var root []byte
+
+	if config.IsByzantium(blockNumber) {
+		statedb.Finalise(true)
+	} else {
+		root = statedb.IntermediateRoot(config.IsEIP158(blockNumber)).Bytes()
+	}
+
+	*usedGas += s.result.UsedGas
+
+	receipt := &types.Receipt{Type: s.tx.Type(), PostState: root, CumulativeGasUsed: *usedGas}
+	if s.result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	} else {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+
+	receipt.TxHash = s.tx.Hash()
+	receipt.GasUsed = s.result.UsedGas
+
+	if s.tx.Type() == types.BlobTxType {
+		receipt.BlobGasUsed = uint64(len(s.tx.BlobHashes()) * params.BlobTxBlobGasPerBlob)
+		receipt.BlobGasPrice = s.blockContext.BlobBaseFee
+	}
+
+	if s.msg.To == nil {
+		receipt.ContractAddress = crypto.CreateAddress(s.msg.From, s.tx.Nonce())
+	}
+
+	receipt.Logs = statedb.GetLogs(s.tx.Hash(), blockNumber.Uint64(), blockHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.BlockHash = blockHash
+	receipt.BlockNumber = blockNumber
+	receipt.TransactionIndex = uint(statedb.TxIndex())
+
+	return receipt, nil
+}
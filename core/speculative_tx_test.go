@@ -0,0 +1,131 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
+)
+
+func TestSpeculativeTx(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config   = params.ZenaUnittestChainConfig
+		signer   = types.LatestSigner(config)
+		key1, _  = crypto.GenerateKey()
+		key2, _  = crypto.GenerateKey()
+		addr1    = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2    = crypto.PubkeyToAddress(key2.PublicKey)
+		coinbase = common.HexToAddress("0xc0ffee")
+	)
+
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		GasLimit:   params.GenesisGasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: big.NewInt(1),
+		Coinbase:   coinbase,
+	}
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(addr1, uint256.NewInt(params.Zen), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(params.Zen), tracing.BalanceChangeUnspecified)
+	statedb.Finalise(true)
+
+	transfer := func(key, nonce uint64) *types.Transaction {
+		k := key1
+		if key == 2 {
+			k = key2
+		}
+
+		recipient := common.BigToAddress(new(big.Int).SetUint64(0x1000 + key))
+
+		return types.MustSignNewTx(k, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &recipient,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		})
+	}
+
+	txs := []*types.Transaction{transfer(1, 0), transfer(2, 0)}
+
+	// Execute the transactions one after the other
+	serial := statedb.Copy()
+	serialReceipts := make([]*types.Receipt, len(txs))
+
+	var serialGas uint64
+
+	for i, tx := range txs {
+		serial.SetTxContext(tx.Hash(), i)
+
+		receipt, err := ApplyTransaction(config, nil, &coinbase, new(GasPool).AddGas(header.GasLimit), serial, header, tx, &serialGas, vm.Config{}, nil)
+		require.NoError(t, err)
+
+		serialReceipts[i] = receipt
+	}
+
+	// Execute them side by side on copies of the state, then apply them in order
+	specs := make([]*SpeculativeTx, len(txs))
+
+	for i, tx := range txs {
+		copied := statedb.Copy()
+		copied.SetTxContext(tx.Hash(), i)
+
+		spec, err := ExecuteSpeculativeTx(config, NewEVMBlockContext(header, nil, &coinbase), copied, header, tx, header.GasLimit, vm.Config{}, nil)
+		require.NoError(t, err)
+
+		specs[i] = spec
+	}
+
+	// Transfers between distinct accounts don't conflict, fees aside
+	for _, write := range specs[0].MVFullWriteList() {
+		_, ok := specs[1].MVReadMap()[write.Path]
+		require.False(t, ok)
+	}
+
+	_, ok := specs[1].MVReadMap()[blockstm.NewSubpathKey(addr2, state.NoncePath)]
+	require.True(t, ok)
+
+	var (
+		gp      = new(GasPool).AddGas(header.GasLimit)
+		usedGas uint64
+	)
+
+	for i, spec := range specs {
+		statedb.SetTxContext(txs[i].Hash(), i)
+
+		receipt, err := spec.Apply(config, gp, statedb, header, &usedGas)
+		require.NoError(t, err)
+
+		require.Equal(t, serialReceipts[i].GasUsed, receipt.GasUsed)
+		require.Equal(t, serialReceipts[i].CumulativeGasUsed, receipt.CumulativeGasUsed)
+		require.Equal(t, serialReceipts[i].Status, receipt.Status)
+		require.Len(t, receipt.Logs, len(serialReceipts[i].Logs))
+	}
+
+	require.Equal(t, serialGas, usedGas)
+	require.Equal(t, header.GasLimit-usedGas, gp.Gas())
+	require.Equal(t, serial.GetBalance(coinbase), statedb.GetBalance(coinbase))
+	require.Equal(t, serial.IntermediateRoot(true), statedb.IntermediateRoot(true))
+
+	// Applying needs the full gas limit of the transaction
+	spec, err := ExecuteSpeculativeTx(config, NewEVMBlockContext(header, nil, &coinbase), statedb.Copy(), header, transfer(1, 1), header.GasLimit, vm.Config{}, nil)
+	require.NoError(t, err)
+
+	_, err = spec.Apply(config, new(GasPool).AddGas(params.TxGas-1), statedb, header, &usedGas)
+	require.ErrorIs(t, err, ErrGasLimitReached)
+}
@@ -116,6 +116,11 @@ type StateDB struct {
 	// perspective. This map is populated at the transaction boundaries.
 	mutations map[common.Address]*mutation
 
+	// The state overlaid by this one, whose live objects are copied on first
+	// access, and the accounts which no longer fall back to it
+	overlaid *StateDB
+	detached map[common.Address]struct{}
+
 	// Block-stm related fields
 	mvHashmap    *blockstm.MVHashMap
 	incarnation  int
@@ -404,7 +409,13 @@ func (s *StateDB) FlushMVWriteSet() {
 
 // ApplyMVWriteSet applies entries in a given write set to StateDB. Note that this function does not change MVHashMap nor write set
 // of the current StateDB.
+//
+// The write set is unordered, so self-destructs are applied after the other
+// writes: a transaction may destruct the account it created, which only exists
+// in s once its other writes are applied.
 func (s *StateDB) ApplyMVWriteSet(writes []blockstm.WriteDescriptor) {
+	var destructs []common.Address
+
 	for i := range writes {
 		path := writes[i].Path
 		sr := writes[i].Val.(*StateDB)
@@ -427,15 +438,30 @@ func (s *StateDB) ApplyMVWriteSet(writes []blockstm.WriteDescriptor) {
 			case CodePath:
 				s.SetCode(addr, sr.GetCode(addr))
 			case SuicidePath:
-				stateObject := sr.getStateObject(addr)
-				if stateObject != nil {
-					s.SelfDestruct(addr)
+				if sr.destructed(addr) {
+					destructs = append(destructs, addr)
 				}
 			default:
 				panic(fmt.Errorf("unknown key type: %d", path.GetSubpath()))
 			}
 		}
 	}
+
+	for _, addr := range destructs {
+		s.SelfDestruct(addr)
+	}
+}
+
+// destructed reports whether the account was self-destructed, the object
+// being deleted once the transaction is finalised.
+func (s *StateDB) destructed(addr common.Address) bool {
+	if obj := s.stateObjects[addr]; obj != nil {
+		return obj.selfDestructed
+	}
+
+	_, ok := s.stateObjectsDestruct[addr]
+
+	return ok
 }
 
 type DumpStruct struct {
@@ -989,6 +1015,16 @@ func (s *StateDB) getStateObject(addr common.Address) *stateObject {
 		if obj := s.stateObjects[addr]; obj != nil {
 			return obj
 		}
+		// Then the live objects of the overlaid state, copied on first access.
+		// Accounts this state already held, destructed ones included, are never
+		// read from it again.
+		if _, ok := s.detached[addr]; s.overlaid != nil && !ok {
+			if obj := s.overlaid.stateObjects[addr]; obj != nil {
+				obj = obj.deepCopy(s)
+				s.setStateObject(obj)
+				return obj
+			}
+		}
 		// Short circuit if the account is already destructed in this block.
 		if _, ok := s.stateObjectsDestruct[addr]; ok {
 			return nil
@@ -1049,6 +1085,9 @@ func (s *StateDB) getStateObject(addr common.Address) *stateObject {
 
 func (s *StateDB) setStateObject(object *stateObject) {
 	s.stateObjects[object.Address()] = object
+	if s.overlaid != nil {
+		s.detached[object.Address()] = struct{}{}
+	}
 }
 
 // Exporting so that it can be used by simulated backend for test cases
@@ -1136,6 +1175,8 @@ func (s *StateDB) Copy() *StateDB {
 		stateObjectsDestruct: make(map[common.Address]*stateObject, len(s.stateObjectsDestruct)),
 		revertedKeys:         make(map[blockstm.Key]struct{}),
 		mutations:            make(map[common.Address]*mutation, len(s.mutations)),
+		overlaid:             s.overlaid,
+		detached:             maps.Clone(s.detached),
 		dbErr:                s.dbErr,
 		refund:               s.refund,
 		thash:                s.thash,
@@ -1199,6 +1240,41 @@ func (s *StateDB) Copy() *StateDB {
 	return state
 }
 
+// Overlay creates a state executing on top of s without copying it upfront: the
+// live objects of s are deep copied the first time they are accessed. Several
+// overlays may be used concurrently, as long as s isn't modified meanwhile.
+func (s *StateDB) Overlay() *StateDB {
+	state := &StateDB{
+		db:                   s.db,
+		trie:                 s.db.CopyTrie(s.trie),
+		hasher:               crypto.NewKeccakState(),
+		snaps:                s.snaps,
+		snap:                 s.snap,
+		originalRoot:         s.originalRoot,
+		overlaid:             s,
+		detached:             make(map[common.Address]struct{}),
+		stateObjects:         make(map[common.Address]*stateObject),
+		stateObjectsDestruct: make(map[common.Address]*stateObject, len(s.stateObjectsDestruct)),
+		revertedKeys:         make(map[blockstm.Key]struct{}),
+		mutations:            make(map[common.Address]*mutation),
+		dbErr:                s.dbErr,
+		thash:                s.thash,
+		txIndex:              s.txIndex,
+		logs:                 make(map[common.Hash][]*types.Log),
+		preimages:            make(map[common.Hash][]byte),
+		journal:              newJournal(),
+		accessList:           newAccessList(),
+		transientStorage:     newTransientStorage(),
+	}
+	// Destructed objects are few, and are needed to clear the storage of
+	// resurrected accounts
+	for addr, obj := range s.stateObjectsDestruct {
+		state.stateObjectsDestruct[addr] = obj.deepCopy(state)
+	}
+
+	return state
+}
+
 // Snapshot returns an identifier for the current revision of the state.
 func (s *StateDB) Snapshot() int {
 	id := s.nextRevisionId
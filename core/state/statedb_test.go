@@ -243,6 +243,98 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+// TestOverlay tests that overlays executing concurrently on top of a state
+// never modify it nor each other, as they copy its live objects on access.
+// It's meant to be run with the race detector.
+func TestOverlay(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	orig, _ := New(types.EmptyRootHash, db, nil)
+
+	addr := func(i int) common.Address { return common.BytesToAddress([]byte{byte(i + 1)}) }
+
+	// Accounts with committed storage, then live objects with pending and
+	// dirty storage, and a destructed account
+	for i := 0; i < 16; i++ {
+		orig.SetBalance(addr(i), uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+		orig.SetState(addr(i), common.Hash{1}, common.Hash{byte(i + 1)})
+	}
+	root, _ := orig.Commit(0, true)
+	orig, _ = New(root, db, nil)
+
+	for i := 0; i < 16; i++ {
+		orig.AddBalance(addr(i), uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+		orig.SetState(addr(i), common.Hash{2}, common.Hash{byte(i + 1)})
+	}
+	orig.SelfDestruct(addr(15))
+	orig.Finalise(true)
+
+	for i := 0; i < 8; i++ {
+		orig.SetState(addr(i), common.Hash{3}, common.Hash{byte(i + 1)})
+	}
+	want := orig.IntermediateRoot(true)
+
+	var (
+		overlays = make([]*StateDB, 8)
+		wg       sync.WaitGroup
+	)
+	for i := range overlays {
+		overlays[i] = orig.Overlay()
+	}
+	for n, overlay := range overlays {
+		wg.Add(1)
+
+		go func(n int, s *StateDB) {
+			defer wg.Done()
+
+			for i := 0; i < 16; i++ {
+				s.GetBalance(addr(i))
+				s.GetState(addr(i), common.Hash{1})
+				s.GetState(addr(i), common.Hash{2})
+				s.AddBalance(addr(i), uint256.NewInt(uint64(n)), tracing.BalanceChangeUnspecified)
+				s.SetState(addr(i), common.Hash{1}, common.Hash{byte(n)})
+			}
+			s.SelfDestruct(addr(n))
+			s.Finalise(true)
+			s.IntermediateRoot(true)
+		}(n, overlay)
+	}
+	wg.Wait()
+
+	// The overlaid state is untouched
+	if root := orig.IntermediateRoot(true); root != want {
+		t.Fatalf("overlaid state modified: root %x, want %x", root, want)
+	}
+	for i := 0; i < 15; i++ {
+		if balance := orig.GetBalance(addr(i)); balance.Uint64() != uint64(i+101) {
+			t.Errorf("account %d: balance mismatch: have %v, want %v", i, balance, i+101)
+		}
+		if value := orig.GetState(addr(i), common.Hash{1}); value != (common.Hash{byte(i + 1)}) {
+			t.Errorf("account %d: storage mismatch: have %x, want %x", i, value, common.Hash{byte(i + 1)})
+		}
+	}
+	if orig.Exist(addr(15)) {
+		t.Error("destructed account resurrected")
+	}
+
+	// While every overlay kept its own changes
+	for n, overlay := range overlays {
+		for i := 0; i < 16; i++ {
+			if i == n || i == 15 {
+				continue
+			}
+			if balance := overlay.GetBalance(addr(i)); balance.Uint64() != uint64(i+101+n) {
+				t.Errorf("overlay %d, account %d: balance mismatch: have %v, want %v", n, i, balance, i+101+n)
+			}
+			if value := overlay.GetState(addr(i), common.Hash{1}); value != (common.Hash{byte(n)}) {
+				t.Errorf("overlay %d, account %d: storage mismatch: have %x, want %x", n, i, value, common.Hash{byte(n)})
+			}
+		}
+		if overlay.Exist(addr(n)) {
+			t.Errorf("overlay %d: destructed account exists", n)
+		}
+	}
+}
+
 // TestCopyWithDirtyJournal tests if Copy can correct create a equal copied
 // stateDB with dirty journal present.
 func TestCopyWithDirtyJournal(t *testing.T) {
@@ -0,0 +1,143 @@
+package txpool
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/crypto"
+)
+
+// ErrBundlesUnsupported is returned if a bundle is submitted while none of the
+// subpools accepts them.
+var ErrBundlesUnsupported = errors.New("bundles not supported")
+
+// Statuses of a bundle
+const (
+	BundlePending  = "pending"  // Waiting for its inclusion in a block of its range
+	BundleIncluded = "included" // All its transactions were included in a block
+	BundleExpired  = "expired"  // The last block of its range passed before it was included
+	BundleDropped  = "dropped"  // The nonce of one of its transactions was used outside of it
+)
+
+// Bundle is a list of transactions to be included atomically and in order in
+// one of the blocks of a range, or not at all.
+type Bundle struct {
+	Txs      []*types.Transaction
+	MinBlock uint64 // First block the bundle may be included in
+	MaxBlock uint64 // Last block the bundle may be included in
+
+	// RevertingTxHashes are the transactions of the bundle allowed to revert
+	// without failing the whole bundle.
+	RevertingTxHashes []common.Hash
+}
+
+// Hash returns the hash identifying the bundle, the hash of the hashes of its
+// transactions.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+
+	return crypto.Keccak256Hash(hashes)
+}
+
+// CanRevert returns whether the transaction hash of the bundle is allowed to
+// revert.
+func (b *Bundle) CanRevert(hash common.Hash) bool {
+	return slices.Contains(b.RevertingTxHashes, hash)
+}
+
+// BundleSimulation is the outcome of the simulation of a bundle by the miner
+// against the state of the block it was building.
+type BundleSimulation struct {
+	BlockNumber uint64       `json:"blockNumber"`
+	Success     bool         `json:"success"`
+	GasUsed     uint64       `json:"gasUsed"`            // Gas used by the transactions executed
+	FailedTx    *common.Hash `json:"failedTx,omitempty"` // Transaction which failed the bundle
+	Error       string       `json:"error,omitempty"`
+}
+
+// BundleStatus is the status of a bundle along with its last simulation.
+type BundleStatus struct {
+	Hash        common.Hash       `json:"hash"`
+	Txs         []common.Hash     `json:"txs"`
+	MinBlock    uint64            `json:"minBlock"`
+	MaxBlock    uint64            `json:"maxBlock"`
+	Status      string            `json:"status"`
+	Error       string            `json:"error,omitempty"`       // Why it was dropped
+	BlockNumber uint64            `json:"blockNumber,omitempty"` // Block it was included in
+	Simulations uint64            `json:"simulations"`           // Number of times it was simulated
+	Simulation  *BundleSimulation `json:"simulation,omitempty"`  // Last simulation
+}
+
+// BundleSubPool is implemented by the subpools which hold the bundles. As the
+// private transactions, they must never be announced to the network nor surface
+// through the content, the pending transactions or the events of the subpool.
+type BundleSubPool interface {
+	// AddBundle validates and adds a bundle.
+	AddBundle(bundle *Bundle) (*BundleStatus, error)
+
+	// PendingBundles returns the bundles which may be included in the block
+	// number, in their order of submission.
+	PendingBundles(number uint64) []*Bundle
+
+	// ReportBundle records the outcome of the simulation of a bundle.
+	ReportBundle(hash common.Hash, simulation *BundleSimulation)
+
+	// BundleStatus returns the status of a bundle, nil if it's unknown or was
+	// forgotten.
+	BundleStatus(hash common.Hash) *BundleStatus
+}
+
+// bundleSubPool returns the subpool holding the bundles, nil if there is none.
+func (p *TxPool) bundleSubPool() BundleSubPool {
+	for _, subpool := range p.subpools {
+		if bundles, ok := subpool.(BundleSubPool); ok {
+			return bundles
+		}
+	}
+
+	return nil
+}
+
+// AddBundle adds a bundle, which is never broadcast to the network, to be
+// included atomically by this node.
+func (p *TxPool) AddBundle(bundle *Bundle) (*BundleStatus, error) {
+	bundles := p.bundleSubPool()
+	if bundles == nil {
+		return nil, ErrBundlesUnsupported
+	}
+
+	return bundles.AddBundle(bundle)
+}
+
+// PendingBundles returns the bundles which may be included in the block number,
+// in their order of submission.
+func (p *TxPool) PendingBundles(number uint64) []*Bundle {
+	bundles := p.bundleSubPool()
+	if bundles == nil {
+		return nil
+	}
+
+	return bundles.PendingBundles(number)
+}
+
+// ReportBundle records the outcome of the simulation of a bundle.
+func (p *TxPool) ReportBundle(hash common.Hash, simulation *BundleSimulation) {
+	if bundles := p.bundleSubPool(); bundles != nil {
+		bundles.ReportBundle(hash, simulation)
+	}
+}
+
+// BundleStatus returns the status of a bundle, nil if it's unknown.
+func (p *TxPool) BundleStatus(hash common.Hash) *BundleStatus {
+	bundles := p.bundleSubPool()
+	if bundles == nil {
+		return nil
+	}
+
+	return bundles.BundleStatus(hash)
+}
@@ -0,0 +1,451 @@
+// Package bundlepool implements the pool of the bundles, lists of transactions
+// to be included atomically and in order by the local node, or not at all.
+package bundlepool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/lru"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+const (
+	// txMaxSize is the maximum size a single transaction of a bundle can have,
+	// the same as the one of the legacy pool.
+	txMaxSize = 128 * 1024
+
+	// maxStatuses is the number of bundles which left the pool whose status is
+	// remembered.
+	maxStatuses = 4096
+
+	// maxResetDepth is the number of blocks walked back on a reset to find the
+	// bundles which were included.
+	maxResetDepth = 64
+)
+
+var (
+	// ErrEmptyBundle is returned if a bundle holds no transaction.
+	ErrEmptyBundle = errors.New("empty bundle")
+
+	// ErrBundleTooLarge is returned if a bundle holds more transactions than
+	// allowed by the pool.
+	ErrBundleTooLarge = errors.New("bundle too large")
+
+	// ErrInvalidBlockRange is returned if the range of blocks of a bundle is
+	// empty, already passed or further than the lifetime allowed by the pool.
+	ErrInvalidBlockRange = errors.New("invalid bundle block range")
+
+	// ErrUnknownRevertingTx is returned if a transaction allowed to revert isn't
+	// part of the bundle.
+	ErrUnknownRevertingTx = errors.New("reverting transaction not in bundle")
+
+	// ErrPoolFull is returned if the pool can't hold any more bundle.
+	ErrPoolFull = errors.New("bundle pool is full")
+
+	// errPublicTx is returned if a public transaction is added to the pool.
+	errPublicTx = errors.New("bundle pool only accepts bundles")
+)
+
+var (
+	pendingGauge   = metrics.NewRegisteredGauge("txpool/bundle/pending", nil)
+	includedMeter  = metrics.NewRegisteredMeter("txpool/bundle/included", nil)
+	expiredMeter   = metrics.NewRegisteredMeter("txpool/bundle/expired", nil)
+	droppedMeter   = metrics.NewRegisteredMeter("txpool/bundle/dropped", nil)
+	simulatedMeter = metrics.NewRegisteredMeter("txpool/bundle/simulated", nil)
+	failedMeter    = metrics.NewRegisteredMeter("txpool/bundle/failed", nil)
+)
+
+// bundle is a bundle waiting for its inclusion.
+type bundle struct {
+	*txpool.Bundle
+
+	senders []common.Address     // Senders of the transactions of the bundle
+	status  *txpool.BundleStatus // Status of the bundle, updated by the simulations
+}
+
+// BundlePool is the transaction pool holding the bundles. They are only handed
+// to the local miner, which includes them atomically, and as the private
+// transactions never surface through the content, the pending transactions,
+// the lookups or the events of the pool.
+//
+// The transactions of a bundle may also be pooled by the other subpools: the
+// miner includes the bundles first, and the public duplicates are skipped as
+// their nonce is used.
+type BundlePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	lock  sync.Mutex
+	head  *types.Header
+	state *state.StateDB
+
+	bundles  []*bundle                                     // Bundles waiting for their inclusion, in their order of submission
+	lookup   map[common.Hash]*bundle                       // Bundles waiting for their inclusion, by hash
+	statuses *lru.Cache[common.Hash, *txpool.BundleStatus] // Statuses of the bundles which left the pool
+}
+
+// New creates a new bundle pool.
+func New(config Config, chain BlockChain) *BundlePool {
+	return &BundlePool{
+		config:   config.sanitize(),
+		chain:    chain,
+		signer:   types.LatestSigner(chain.Config()),
+		lookup:   make(map[common.Hash]*bundle),
+		statuses: lru.NewCache[common.Hash, *txpool.BundleStatus](maxStatuses),
+	}
+}
+
+// Filter returns false: the pool only holds the transactions of the bundles
+// explicitly added.
+func (p *BundlePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// Init sets the chain head to allow nonce checks.
+func (p *BundlePool) Init(gasTip uint64, head *types.Header, reserve txpool.AddressReserver) error {
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+
+	p.head, p.state = head, statedb
+
+	return nil
+}
+
+// Close terminates the bundle pool.
+func (p *BundlePool) Close() error {
+	return nil
+}
+
+// Reset drops the bundles which were included, whose range passed with newHead
+// or a nonce of which was used outside of them.
+func (p *BundlePool) Reset(oldHead, newHead *types.Header) {
+	statedb, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset bundle pool state", "err", err)
+		return
+	}
+
+	included := txpool.IncludedTransactions(p.chain.GetBlock, oldHead, newHead, maxResetDepth)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head, p.state = newHead, statedb
+
+	number := newHead.Number.Uint64()
+
+	keep := p.bundles[:0]
+
+	for _, b := range p.bundles {
+		if block := b.includedIn(included); block != 0 {
+			b.status.BlockNumber = block
+			p.drop(b, txpool.BundleIncluded)
+			includedMeter.Mark(1)
+
+			continue
+		}
+
+		if err := b.usedNonce(statedb); err != nil {
+			b.status.Error = err.Error()
+			p.drop(b, txpool.BundleDropped)
+			droppedMeter.Mark(1)
+
+			continue
+		}
+
+		if number >= b.MaxBlock {
+			p.drop(b, txpool.BundleExpired)
+			expiredMeter.Mark(1)
+
+			continue
+		}
+
+		keep = append(keep, b)
+	}
+
+	clear(p.bundles[len(keep):])
+	p.bundles = keep
+
+	pendingGauge.Update(int64(len(p.bundles)))
+}
+
+// includedIn returns the number of the block including all the transactions of
+// the bundle, zero if they weren't.
+func (b *bundle) includedIn(included map[common.Hash]uint64) uint64 {
+	number, ok := included[b.Txs[0].Hash()]
+	if !ok {
+		return 0
+	}
+
+	for _, tx := range b.Txs[1:] {
+		if included[tx.Hash()] != number {
+			return 0
+		}
+	}
+
+	return number
+}
+
+// usedNonce returns an error if the nonce of a transaction of the bundle was
+// used in statedb.
+func (b *bundle) usedNonce(statedb *state.StateDB) error {
+	for i, tx := range b.Txs {
+		if next := statedb.GetNonce(b.senders[i]); tx.Nonce() < next {
+			return fmt.Errorf("%w: transaction %d, next nonce %v, tx nonce %v", core.ErrNonceTooLow, i, next, tx.Nonce())
+		}
+	}
+
+	return nil
+}
+
+// drop removes a bundle from the lookup and remembers its final status. The
+// caller is responsible for removing it from the list of bundles.
+func (p *BundlePool) drop(b *bundle, status string) {
+	b.status.Status = status
+
+	delete(p.lookup, b.status.Hash)
+	p.statuses.Add(b.status.Hash, b.status)
+
+	log.Debug("Bundle left the pool", "hash", b.status.Hash, "status", status, "err", b.status.Error)
+}
+
+// SetGasTip does nothing: the bundles are submitted to the local node on purpose
+// and aren't held to its minimum tip.
+func (p *BundlePool) SetGasTip(tip *big.Int) {}
+
+// Has returns false: the bundles are never revealed to the lookups used to answer
+// the network.
+func (p *BundlePool) Has(hash common.Hash) bool {
+	return false
+}
+
+// Get returns nil: the bundles are never revealed to the lookups used to answer
+// the network.
+func (p *BundlePool) Get(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+// Add rejects all the transactions: the bundles are added with AddBundle.
+func (p *BundlePool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = errPublicTx
+	}
+	return errs
+}
+
+// AddBundle validates and adds a bundle. Its range defaults to the next block
+// if it has no first block, and to its first block if it has no last one.
+func (p *BundlePool) AddBundle(txBundle *txpool.Bundle) (*txpool.BundleStatus, error) {
+	if len(txBundle.Txs) == 0 {
+		return nil, ErrEmptyBundle
+	}
+
+	if uint64(len(txBundle.Txs)) > p.config.MaxTxs {
+		return nil, fmt.Errorf("%w: %d transactions, limit %d", ErrBundleTooLarge, len(txBundle.Txs), p.config.MaxTxs)
+	}
+
+	hash := txBundle.Hash()
+
+	for _, reverting := range txBundle.RevertingTxHashes {
+		if !slices.ContainsFunc(txBundle.Txs, func(tx *types.Transaction) bool { return tx.Hash() == reverting }) {
+			return nil, fmt.Errorf("%w: %x", ErrUnknownRevertingTx, reverting)
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.lookup[hash]; ok {
+		return nil, txpool.ErrAlreadyKnown
+	}
+
+	// Keep the bundle of the caller untouched
+	cpy := *txBundle
+
+	head := p.head.Number.Uint64()
+	if cpy.MinBlock == 0 {
+		cpy.MinBlock = head + 1
+	}
+
+	if cpy.MaxBlock == 0 {
+		cpy.MaxBlock = cpy.MinBlock
+	}
+
+	if cpy.MinBlock > cpy.MaxBlock || cpy.MaxBlock <= head || cpy.MaxBlock > head+p.config.Lifetime {
+		return nil, fmt.Errorf("%w: blocks %d to %d, head %d, lifetime %d", ErrInvalidBlockRange, cpy.MinBlock, cpy.MaxBlock, head, p.config.Lifetime)
+	}
+
+	if uint64(len(p.bundles)) >= p.config.GlobalSlots {
+		return nil, ErrPoolFull
+	}
+
+	b := &bundle{
+		Bundle:  &cpy,
+		senders: make([]common.Address, len(cpy.Txs)),
+		status: &txpool.BundleStatus{
+			Hash:     hash,
+			Txs:      make([]common.Hash, len(cpy.Txs)),
+			MinBlock: cpy.MinBlock,
+			MaxBlock: cpy.MaxBlock,
+			Status:   txpool.BundlePending,
+		},
+	}
+
+	for i, tx := range cpy.Txs {
+		from, err := p.validateTx(tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		b.senders[i] = from
+		b.status.Txs[i] = tx.Hash()
+	}
+
+	if err := b.usedNonce(p.state); err != nil {
+		return nil, err
+	}
+
+	p.bundles = append(p.bundles, b)
+	p.lookup[hash] = b
+	pendingGauge.Update(int64(len(p.bundles)))
+
+	log.Debug("Added bundle", "hash", hash, "txs", len(cpy.Txs), "minBlock", cpy.MinBlock, "maxBlock", cpy.MaxBlock)
+
+	return b.copyStatus(), nil
+}
+
+// validateTx checks whether a transaction of a bundle is valid according to the
+// consensus rules, returning its sender. The state dependent checks are left to
+// the simulation of the bundle.
+func (p *BundlePool) validateTx(tx *types.Transaction) (common.Address, error) {
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  new(big.Int),
+	}
+	if err := txpool.ValidateTransaction(tx, p.head, p.signer, opts); err != nil {
+		return common.Address{}, err
+	}
+
+	return types.Sender(p.signer, tx)
+}
+
+// copyStatus returns a copy of the status of a bundle.
+func (b *bundle) copyStatus() *txpool.BundleStatus {
+	status := *b.status
+	return &status
+}
+
+// PendingBundles returns the bundles which may be included in the block number,
+// in their order of submission.
+func (p *BundlePool) PendingBundles(number uint64) []*txpool.Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var bundles []*txpool.Bundle
+
+	for _, b := range p.bundles {
+		if b.MinBlock <= number && number <= b.MaxBlock {
+			bundles = append(bundles, b.Bundle)
+		}
+	}
+
+	return bundles
+}
+
+// ReportBundle records the outcome of the simulation of a bundle.
+func (p *BundlePool) ReportBundle(hash common.Hash, simulation *txpool.BundleSimulation) {
+	simulatedMeter.Mark(1)
+
+	if !simulation.Success {
+		failedMeter.Mark(1)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if b, ok := p.lookup[hash]; ok {
+		b.status.Simulations++
+		b.status.Simulation = simulation
+	}
+}
+
+// BundleStatus returns the status of a bundle, nil if it's unknown or was
+// forgotten.
+func (p *BundlePool) BundleStatus(hash common.Hash) *txpool.BundleStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if b, ok := p.lookup[hash]; ok {
+		return b.copyStatus()
+	}
+
+	status, _ := p.statuses.Peek(hash)
+
+	return status
+}
+
+// Pending returns nothing: the bundles are only retrieved by the miner through
+// PendingBundles.
+func (p *BundlePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return map[common.Address][]*txpool.LazyTransaction{}
+}
+
+// SubscribeTransactions returns a subscription which never fires: the bundles
+// are never announced.
+func (p *BundlePool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// Nonce returns zero: the bundles don't hold the accounts of their transactions.
+func (p *BundlePool) Nonce(addr common.Address) uint64 {
+	return 0
+}
+
+// Stats returns no transaction: the bundles aren't revealed.
+func (p *BundlePool) Stats() (int, int) {
+	return 0, 0
+}
+
+// Content returns no transaction: the bundles aren't revealed.
+func (p *BundlePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom returns no transaction: the bundles aren't revealed.
+func (p *BundlePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return []*types.Transaction{}, []*types.Transaction{}
+}
+
+// Locals returns no account: the bundles aren't revealed.
+func (p *BundlePool) Locals() []common.Address {
+	return []common.Address{}
+}
+
+// Status returns unknown: the bundles aren't revealed, their status is available
+// through BundleStatus.
+func (p *BundlePool) Status(hash common.Hash) txpool.TxStatus {
+	return txpool.TxStatusUnknown
+}
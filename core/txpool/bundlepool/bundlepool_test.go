@@ -0,0 +1,151 @@
+package bundlepool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
+	"github.com/zenanetwork/go-zenanet/trie"
+)
+
+type testBlockChain struct {
+	statedb *state.StateDB
+	blocks  map[common.Hash]*types.Block
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+// addBlock adds a block holding txs on top of parent.
+func (bc *testBlockChain) addBlock(parent *types.Header, txs ...*types.Transaction) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		BaseFee:    parent.BaseFee,
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+	bc.blocks[block.Hash()] = block
+
+	return block.Header()
+}
+
+func bundleTransaction(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(10 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(1),
+	})
+}
+
+func TestBundlePool(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = crypto.PubkeyToAddress(key2.PublicKey)
+	)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(addr1, uint256.NewInt(params.Zen), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(params.Zen), tracing.BalanceChangeUnspecified)
+
+	var (
+		chain = &testBlockChain{statedb: statedb, blocks: make(map[common.Hash]*types.Block)}
+		head  = &types.Header{Number: big.NewInt(10), GasLimit: 30_000_000, BaseFee: big.NewInt(params.GWei)}
+	)
+
+	pool := New(DefaultConfig, chain)
+	require.NoError(t, pool.Init(0, head, nil))
+
+	// Invalid bundles are rejected
+	_, err := pool.AddBundle(&txpool.Bundle{})
+	require.ErrorIs(t, err, ErrEmptyBundle)
+
+	_, err = pool.AddBundle(&txpool.Bundle{
+		Txs:               []*types.Transaction{bundleTransaction(key1, 0)},
+		RevertingTxHashes: []common.Hash{{0x01}},
+	})
+	require.ErrorIs(t, err, ErrUnknownRevertingTx)
+
+	_, err = pool.AddBundle(&txpool.Bundle{Txs: []*types.Transaction{bundleTransaction(key1, 0)}, MinBlock: 12, MaxBlock: 11})
+	require.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	_, err = pool.AddBundle(&txpool.Bundle{Txs: []*types.Transaction{bundleTransaction(key1, 0)}, MaxBlock: 10})
+	require.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	// A bundle without range targets the next block
+	included := &txpool.Bundle{Txs: []*types.Transaction{bundleTransaction(key1, 0), bundleTransaction(key2, 0)}}
+
+	status, err := pool.AddBundle(included)
+	require.NoError(t, err)
+	require.Equal(t, txpool.BundlePending, status.Status)
+	require.Equal(t, included.Hash(), status.Hash)
+	require.Equal(t, uint64(11), status.MinBlock)
+	require.Equal(t, uint64(11), status.MaxBlock)
+
+	_, err = pool.AddBundle(included)
+	require.ErrorIs(t, err, txpool.ErrAlreadyKnown)
+
+	expired := &txpool.Bundle{Txs: []*types.Transaction{bundleTransaction(key1, 1)}, MinBlock: 12, MaxBlock: 12}
+
+	_, err = pool.AddBundle(expired)
+	require.NoError(t, err)
+
+	// Bundles are only handed out to the miner for their range
+	require.Len(t, pool.PendingBundles(11), 1)
+	require.Len(t, pool.PendingBundles(12), 1)
+	require.Empty(t, pool.PendingBundles(13))
+	require.Empty(t, pool.Pending(txpool.PendingFilter{}))
+
+	pool.ReportBundle(included.Hash(), &txpool.BundleSimulation{BlockNumber: 11, Success: true, GasUsed: 2 * params.TxGas})
+
+	status = pool.BundleStatus(included.Hash())
+	require.Equal(t, uint64(1), status.Simulations)
+	require.True(t, status.Simulation.Success)
+
+	// The first bundle is included in the next block
+	next := chain.addBlock(head, included.Txs...)
+	statedb.SetNonce(addr1, 1)
+	statedb.SetNonce(addr2, 1)
+
+	pool.Reset(head, next)
+
+	status = pool.BundleStatus(included.Hash())
+	require.Equal(t, txpool.BundleIncluded, status.Status)
+	require.Equal(t, uint64(11), status.BlockNumber)
+	require.Equal(t, txpool.BundlePending, pool.BundleStatus(expired.Hash()).Status)
+
+	// The second one isn't included in its block
+	head, next = next, chain.addBlock(next)
+	pool.Reset(head, next)
+
+	require.Equal(t, txpool.BundleExpired, pool.BundleStatus(expired.Hash()).Status)
+	require.Empty(t, pool.PendingBundles(13))
+}
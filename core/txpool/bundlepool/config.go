@@ -0,0 +1,38 @@
+package bundlepool
+
+import (
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// Config are the configuration parameters of the bundle pool.
+type Config struct {
+	Lifetime    uint64 // Maximum number of blocks between the head and the last block of a bundle
+	MaxTxs      uint64 // Maximum number of transactions of a single bundle
+	GlobalSlots uint64 // Maximum number of bundles in the pool
+}
+
+// DefaultConfig contains the default configurations for the bundle pool.
+var DefaultConfig = Config{
+	Lifetime:    64,
+	MaxTxs:      16,
+	GlobalSlots: 256,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.Lifetime < 1 {
+		log.Warn("Sanitizing invalid bundlepool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
+		conf.Lifetime = DefaultConfig.Lifetime
+	}
+	if conf.MaxTxs < 1 {
+		log.Warn("Sanitizing invalid bundlepool max transactions", "provided", conf.MaxTxs, "updated", DefaultConfig.MaxTxs)
+		conf.MaxTxs = DefaultConfig.MaxTxs
+	}
+	if conf.GlobalSlots < 1 {
+		log.Warn("Sanitizing invalid bundlepool global slots", "provided", conf.GlobalSlots, "updated", DefaultConfig.GlobalSlots)
+		conf.GlobalSlots = DefaultConfig.GlobalSlots
+	}
+	return conf
+}
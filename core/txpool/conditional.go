@@ -0,0 +1,64 @@
+package txpool
+
+import (
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// Reasons of the eviction of a conditional transaction (PIP-15)
+const (
+	EvictionBlockNumberExpired = "blockNumberExpired"   // The head reached BlockNumberMax
+	EvictionTimestampExpired   = "timestampExpired"     // The head reached TimestampMax
+	EvictionKnownAccounts      = "knownAccountsChanged" // A known account or storage slot changed
+)
+
+// ConditionalEviction is a conditional transaction (PIP-15) evicted from the
+// pool because its options could no longer hold.
+type ConditionalEviction struct {
+	Hash        common.Hash    `json:"hash"`
+	From        common.Address `json:"from"`
+	Nonce       uint64         `json:"nonce"`
+	Reason      string         `json:"reason"`
+	Error       string         `json:"error"`
+	BlockNumber uint64         `json:"blockNumber"` // Head of the chain the options were checked against
+	Time        uint64         `json:"time"`        // Unix time of the eviction
+}
+
+// ConditionalEvicter is implemented by the subpools which evict the conditional
+// transactions whose options can no longer hold.
+type ConditionalEvicter interface {
+	// ConditionalEvictions returns the conditional transactions recently evicted,
+	// the most recent first.
+	ConditionalEvictions() []*ConditionalEviction
+
+	// ConditionalEviction returns the eviction of a conditional transaction, nil
+	// if it wasn't recently evicted.
+	ConditionalEviction(hash common.Hash) *ConditionalEviction
+}
+
+// ConditionalEvictions returns the conditional transactions recently evicted
+// from the subpools, the most recent of every subpool first.
+func (p *TxPool) ConditionalEvictions() []*ConditionalEviction {
+	evictions := make([]*ConditionalEviction, 0)
+
+	for _, subpool := range p.subpools {
+		if evicter, ok := subpool.(ConditionalEvicter); ok {
+			evictions = append(evictions, evicter.ConditionalEvictions()...)
+		}
+	}
+
+	return evictions
+}
+
+// ConditionalEviction returns the eviction of a conditional transaction from
+// any of the subpools, nil if it wasn't recently evicted.
+func (p *TxPool) ConditionalEviction(hash common.Hash) *ConditionalEviction {
+	for _, subpool := range p.subpools {
+		if evicter, ok := subpool.(ConditionalEvicter); ok {
+			if eviction := evicter.ConditionalEviction(hash); eviction != nil {
+				return eviction
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package txpool
+
+import (
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/types"
+)
+
+// IncludedTransactions returns the number of the block including every
+// transaction of the blocks from newHead back to oldHead, walking back at most
+// depth blocks. It's used by the subpools which report the inclusion of their
+// transactions on reset.
+func IncludedTransactions(getBlock func(hash common.Hash, number uint64) *types.Block, oldHead, newHead *types.Header, depth int) map[common.Hash]uint64 {
+	included := make(map[common.Hash]uint64)
+
+	var oldNumber uint64
+	if oldHead != nil {
+		oldNumber = oldHead.Number.Uint64()
+	}
+
+	hash, number := newHead.Hash(), newHead.Number.Uint64()
+
+	for i := 0; i < depth && number > oldNumber; i++ {
+		block := getBlock(hash, number)
+		if block == nil {
+			break
+		}
+
+		for _, tx := range block.Transactions() {
+			included[tx.Hash()] = number
+		}
+
+		hash, number = block.ParentHash(), number-1
+	}
+
+	return included
+}
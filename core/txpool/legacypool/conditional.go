@@ -0,0 +1,106 @@
+package legacypool
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+// maxConditionalEvictions is the number of evicted conditional transactions
+// remembered with the reason of their eviction.
+const maxConditionalEvictions = 4096
+
+var (
+	conditionalExpiredMeter       = metrics.NewRegisteredMeter("txpool/conditional/expired", nil)       // Dropped as their block number or timestamp bound passed
+	conditionalKnownAccountsMeter = metrics.NewRegisteredMeter("txpool/conditional/knownaccounts", nil) // Dropped as their known accounts changed
+)
+
+// checkTxConditional returns the reason why the options of a conditional
+// transaction can no longer hold in the blocks following head, along with the
+// detailed error, or an empty reason if they still can. Lower bounds which
+// aren't reached yet are left to the miner.
+func checkTxConditional(tx *types.Transaction, statedb *state.StateDB, head *types.Header) (string, error) {
+	options := tx.GetOptions()
+	if options == nil {
+		return "", nil
+	}
+
+	if options.BlockNumberMax != nil && head.Number.Cmp(options.BlockNumberMax) >= 0 {
+		return txpool.EvictionBlockNumberExpired, fmt.Errorf("next block number %v is greater than maximum block number: %v", new(big.Int).Add(head.Number, common.Big1), options.BlockNumberMax)
+	}
+
+	if options.TimestampMax != nil && head.Time >= *options.TimestampMax {
+		return txpool.EvictionTimestampExpired, fmt.Errorf("current block time %v is not less than maximum timestamp: %v", head.Time, *options.TimestampMax)
+	}
+
+	if err := statedb.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+		return txpool.EvictionKnownAccounts, err
+	}
+
+	return "", nil
+}
+
+// newConditionalEviction creates the record of the eviction of tx.
+func newConditionalEviction(tx *types.Transaction, head *types.Header, reason string, err error) *txpool.ConditionalEviction {
+	return &txpool.ConditionalEviction{
+		Hash:        tx.Hash(),
+		Nonce:       tx.Nonce(),
+		Reason:      reason,
+		Error:       err.Error(),
+		BlockNumber: head.Number.Uint64(),
+		Time:        uint64(time.Now().Unix()),
+	}
+}
+
+// removeConditionals drops the evicted conditional transactions of an account
+// from the lookup, and remembers why they were evicted.
+func (pool *LegacyPool) removeConditionals(addr common.Address, txs types.Transactions, evictions map[common.Hash]*txpool.ConditionalEviction) {
+	for _, tx := range txs {
+		hash := tx.Hash()
+		pool.all.Remove(hash)
+
+		eviction := evictions[hash]
+		eviction.From = addr
+
+		if eviction.Reason == txpool.EvictionKnownAccounts {
+			conditionalKnownAccountsMeter.Mark(1)
+		} else {
+			conditionalExpiredMeter.Mark(1)
+		}
+
+		pool.conditionalEvictions.Add(hash, eviction)
+		log.Trace("Removed invalid conditional transaction", "hash", hash, "reason", eviction.Reason, "err", eviction.Error)
+	}
+}
+
+// ConditionalEvictions returns the conditional transactions recently evicted,
+// the most recent first.
+func (pool *LegacyPool) ConditionalEvictions() []*txpool.ConditionalEviction {
+	hashes := pool.conditionalEvictions.Keys()
+	slices.Reverse(hashes)
+
+	evictions := make([]*txpool.ConditionalEviction, 0, len(hashes))
+
+	for _, hash := range hashes {
+		if eviction, ok := pool.conditionalEvictions.Peek(hash); ok {
+			evictions = append(evictions, eviction)
+		}
+	}
+
+	return evictions
+}
+
+// ConditionalEviction returns the eviction of a conditional transaction, nil
+// if it wasn't recently evicted.
+func (pool *LegacyPool) ConditionalEviction(hash common.Hash) *txpool.ConditionalEviction {
+	eviction, _ := pool.conditionalEvictions.Peek(hash)
+	return eviction
+}
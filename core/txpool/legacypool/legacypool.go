@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/lru"
 	"github.com/zenanetwork/go-zenanet/common/prque"
 	"github.com/zenanetwork/go-zenanet/consensus/misc/eip1559"
 	"github.com/zenanetwork/go-zenanet/core"
@@ -227,6 +228,8 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	conditionalEvictions *lru.Cache[common.Hash, *txpool.ConditionalEviction] // Recently evicted conditional transactions
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -266,6 +269,8 @@ func New(config Config, chain BlockChain, options ...func(pool *LegacyPool)) *Le
 		reorgDoneCh:     make(chan chan struct{}),
 		reorgShutdownCh: make(chan struct{}),
 		initDoneCh:      make(chan struct{}),
+
+		conditionalEvictions: lru.NewCache[common.Hash, *txpool.ConditionalEviction](maxConditionalEvictions),
 	}
 	pool.locals = newAccountSet(pool.signer)
 	for _, addr := range config.Locals {
@@ -1489,6 +1494,10 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
+		// Drop all transactions whose TxOptions expired or no longer match the state
+		conditionals, evictions := list.FilterTxConditional(pool.currentState, pool.currentHead.Load())
+		pool.removeConditionals(addr, conditionals, evictions)
+
 		// Gather all executable transactions and promote them
 		readies := list.Ready(pool.pendingNonces.get(addr))
 		for _, tx := range readies {
@@ -1512,10 +1521,10 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
 		// Mark all the items dropped as removed
-		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
-		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
+		pool.priced.Removed(len(forwards) + len(drops) + len(conditionals) + len(caps))
+		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(conditionals) + len(caps)))
 		if pool.locals.contains(addr) {
-			localGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
+			localGauge.Dec(int64(len(forwards) + len(drops) + len(conditionals) + len(caps)))
 		}
 		// Delete the entire queue entry if it became empty.
 		if list.Empty() {
@@ -1697,14 +1706,9 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
 		}
-		// Drop all transactions that no longer have valid TxOptions
-		txConditionalsRemoved := list.FilterTxConditional(pool.currentState, currentHeader)
-
-		for _, tx := range txConditionalsRemoved {
-			hash := tx.Hash()
-			pool.all.Remove(hash)
-			log.Trace("Removed invalid conditional transaction", "hash", hash)
-		}
+		// Drop all transactions whose TxOptions expired or no longer match the state
+		txConditionalsRemoved, evictions := list.FilterTxConditional(pool.currentState, currentHeader)
+		pool.removeConditionals(addr, txConditionalsRemoved, evictions)
 
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids) + len(txConditionalsRemoved)))
 		if pool.locals.contains(addr) {
@@ -28,8 +28,8 @@ import (
 
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
-	"github.com/zenanetwork/go-zenanet/log"
 	"github.com/holiman/uint256"
 )
 
@@ -427,42 +427,35 @@ func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64) (types.Transactio
 	return removed, invalids
 }
 
-// FilterTxConditional returns the conditional transactions with invalid PIP15 options
-func (l *list) FilterTxConditional(state *state.StateDB, header *types.Header) types.Transactions {
+// FilterTxConditional removes the conditional transactions whose PIP15 options
+// can no longer hold in the blocks following header, returning them along with
+// the record of their eviction.
+func (l *list) FilterTxConditional(state *state.StateDB, header *types.Header) (types.Transactions, map[common.Hash]*txpool.ConditionalEviction) {
 	if state == nil || header == nil {
-		return nil
+		return nil, nil
 	}
 
-	removed := l.txs.filter(func(tx *types.Transaction) bool {
-		if options := tx.GetOptions(); options != nil {
-			if err := state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
-				log.Debug("Error while Filtering Tx Conditional's known accounts", "err", err)
-				return true
-			}
-
-			if err := header.ValidateBlockNumberOptionsPIP15(options.BlockNumberMin, options.BlockNumberMax); err != nil {
-				log.Debug("Error while Filtering Tx Conditional's block number options", "err", err)
-				return true
-			}
-
-			if err := header.ValidateTimestampOptionsPIP15(options.TimestampMin, options.TimestampMax); err != nil {
-				log.Debug("Error while Filtering Tx Conditional's timestamp options", "err", err)
-				return true
-			}
+	evictions := make(map[common.Hash]*txpool.ConditionalEviction)
 
+	removed := l.txs.filter(func(tx *types.Transaction) bool {
+		reason, err := checkTxConditional(tx, state, header)
+		if reason == "" {
 			return false
 		}
 
-		return false
+		evictions[tx.Hash()] = newConditionalEviction(tx, header, reason, err)
+
+		return true
 	})
 
 	if len(removed) == 0 {
-		return nil
+		return nil, nil
 	}
 
+	l.subTotalCost(removed)
 	l.txs.reheap()
 
-	return removed
+	return removed, evictions
 }
 
 // Cap places a hard limit on the number of items, returning all transactions
@@ -28,6 +28,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state"
 	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/crypto"
 	"github.com/holiman/uint256"
@@ -122,7 +123,7 @@ func TestFilterTxConditionalKnownAccounts(t *testing.T) {
 
 	// There should be no drops at this point.
 	// No state has been modified.
-	drops := list.FilterTxConditional(state, header)
+	drops, _ := list.FilterTxConditional(state, header)
 
 	count := len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
@@ -155,7 +156,7 @@ func TestFilterTxConditionalKnownAccounts(t *testing.T) {
 	list.Add(tx2, DefaultConfig.PriceBump)
 
 	// There should still be no drops as no state has been modified.
-	drops = list.FilterTxConditional(state, header)
+	drops, _ = list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
@@ -169,12 +170,13 @@ func TestFilterTxConditionalKnownAccounts(t *testing.T) {
 	fmt.Println("after2", trie.Hash())
 
 	// tx2 should be the single transaction filtered out
-	drops = list.FilterTxConditional(state, header)
+	drops, evictions := list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 1, count, "got %d filtered by TxOptions when there should be a single one", count)
 
 	require.Equal(t, tx2, drops[0], "Got %x, expected %x", drops[0].Hash(), tx2.Hash())
+	require.Equal(t, txpool.EvictionKnownAccounts, evictions[tx2.Hash()].Reason)
 }
 
 func TestFilterTxConditionalBlockNumber(t *testing.T) {
@@ -202,7 +204,7 @@ func TestFilterTxConditionalBlockNumber(t *testing.T) {
 
 	// There should be no drops at this point.
 	// No state has been modified.
-	drops := list.FilterTxConditional(state, header)
+	drops, _ := list.FilterTxConditional(state, header)
 
 	count := len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
@@ -219,21 +221,29 @@ func TestFilterTxConditionalBlockNumber(t *testing.T) {
 	list.Add(tx2, DefaultConfig.PriceBump)
 
 	// There should still be no drops as no state has been modified.
-	drops = list.FilterTxConditional(state, header)
+	drops, _ = list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
 
+	// The minimum block number not being reached yet is left to the miner
+	header.Number = big.NewInt(80)
+
+	drops, _ = list.FilterTxConditional(state, header)
+	require.Empty(t, drops)
+
 	// Set block number that conflicts with tx2's policy
-	header.Number = big.NewInt(120)
+	header.Number = big.NewInt(110)
 
-	// tx2 should be the single transaction filtered out
-	drops = list.FilterTxConditional(state, header)
+	// tx2 should be the single transaction filtered out, the next block is past the maximum
+	drops, evictions := list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 1, count, "got %d filtered by TxOptions when there should be a single one", count)
 
 	require.Equal(t, tx2, drops[0], "Got %x, expected %x", drops[0].Hash(), tx2.Hash())
+	require.Equal(t, txpool.EvictionBlockNumberExpired, evictions[tx2.Hash()].Reason)
+	require.Equal(t, uint64(110), evictions[tx2.Hash()].BlockNumber)
 }
 
 func TestFilterTxConditionalTimestamp(t *testing.T) {
@@ -262,7 +272,7 @@ func TestFilterTxConditionalTimestamp(t *testing.T) {
 
 	// There should be no drops at this point.
 	// No state has been modified.
-	drops := list.FilterTxConditional(state, header)
+	drops, _ := list.FilterTxConditional(state, header)
 
 	count := len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
@@ -282,21 +292,28 @@ func TestFilterTxConditionalTimestamp(t *testing.T) {
 	list.Add(tx2, DefaultConfig.PriceBump)
 
 	// There should still be no drops as no state has been modified.
-	drops = list.FilterTxConditional(state, header)
+	drops, _ = list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
 
+	// The minimum timestamp not being reached yet is left to the miner
+	header.Time = 80
+
+	drops, _ = list.FilterTxConditional(state, header)
+	require.Empty(t, drops)
+
 	// Set timestamp that conflicts with tx2's policy
 	header.Time = 120
 
 	// tx2 should be the single transaction filtered out
-	drops = list.FilterTxConditional(state, header)
+	drops, evictions := list.FilterTxConditional(state, header)
 
 	count = len(drops)
 	require.Equal(t, 1, count, "got %d filtered by TxOptions when there should be a single one", count)
 
 	require.Equal(t, tx2, drops[0], "Got %x, expected %x", drops[0].Hash(), tx2.Hash())
+	require.Equal(t, txpool.EvictionTimestampExpired, evictions[tx2.Hash()].Reason)
 }
 
 func BenchmarkListCapOneTx(b *testing.B) {
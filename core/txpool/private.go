@@ -0,0 +1,94 @@
+package txpool
+
+import (
+	"errors"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/types"
+)
+
+// ErrPrivateTxsUnsupported is returned if a private transaction is submitted
+// while none of the subpools accepts them.
+var ErrPrivateTxsUnsupported = errors.New("private transactions not supported")
+
+// Statuses of a private transaction
+const (
+	PrivateTxPending  = "pending"  // Waiting for inclusion in a block sealed by this node
+	PrivateTxIncluded = "included" // Included in a block
+	PrivateTxExpired  = "expired"  // The deadline passed before it was included
+	PrivateTxDropped  = "dropped"  // Its nonce was used by another transaction or it became invalid
+)
+
+// PrivateTxStatus is the status of a private transaction, submitted to be only
+// included by this node and never broadcast to the network.
+type PrivateTxStatus struct {
+	Hash        common.Hash    `json:"hash"`
+	From        common.Address `json:"from"`
+	Nonce       uint64         `json:"nonce"`
+	MaxBlock    uint64         `json:"maxBlock"` // Last block the transaction may be included in
+	Status      string         `json:"status"`
+	Error       string         `json:"error,omitempty"`       // Why it was dropped
+	BlockNumber uint64         `json:"blockNumber,omitempty"` // Block it was included in
+}
+
+// PrivateSubPool is implemented by the subpools which hold the private
+// transactions. They must never be announced to the network nor surface through
+// the content, the pending transactions or the events of the subpool.
+type PrivateSubPool interface {
+	// AddPrivate validates and adds a private transaction which may be included
+	// up to the block maxBlock, or the default lifetime of the subpool if zero.
+	AddPrivate(tx *types.Transaction, maxBlock uint64) (*PrivateTxStatus, error)
+
+	// PendingPrivate retrieves the private transactions which are currently
+	// processable, grouped by origin account and sorted by nonce.
+	PendingPrivate(filter PendingFilter) map[common.Address][]*LazyTransaction
+
+	// PrivateStatus returns the status of a private transaction, nil if it's
+	// unknown or was forgotten.
+	PrivateStatus(hash common.Hash) *PrivateTxStatus
+}
+
+// privateSubPool returns the subpool holding the private transactions, nil if
+// there is none.
+func (p *TxPool) privateSubPool() PrivateSubPool {
+	for _, subpool := range p.subpools {
+		if private, ok := subpool.(PrivateSubPool); ok {
+			return private
+		}
+	}
+
+	return nil
+}
+
+// AddPrivate adds a private transaction, which is never broadcast to the
+// network, to be included by this node up to the block maxBlock.
+func (p *TxPool) AddPrivate(tx *types.Transaction, maxBlock uint64) (*PrivateTxStatus, error) {
+	private := p.privateSubPool()
+	if private == nil {
+		return nil, ErrPrivateTxsUnsupported
+	}
+
+	return private.AddPrivate(tx, maxBlock)
+}
+
+// PendingPrivate retrieves the private transactions which are currently
+// processable, grouped by origin account and sorted by nonce.
+func (p *TxPool) PendingPrivate(filter PendingFilter) map[common.Address][]*LazyTransaction {
+	private := p.privateSubPool()
+	if private == nil {
+		return nil
+	}
+
+	return private.PendingPrivate(filter)
+}
+
+// PrivateStatus returns the status of a private transaction, nil if it's
+// unknown.
+func (p *TxPool) PrivateStatus(hash common.Hash) *PrivateTxStatus {
+	private := p.privateSubPool()
+	if private == nil {
+		return nil
+	}
+
+	return private.PrivateStatus(hash)
+}
@@ -0,0 +1,44 @@
+package privatepool
+
+import (
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// Config are the configuration parameters of the private transaction pool.
+type Config struct {
+	Lifetime     uint64 // Maximum number of blocks a private transaction may wait for its inclusion
+	AccountSlots uint64 // Maximum number of private transactions of a single account
+	GlobalSlots  uint64 // Maximum number of private transactions in the pool
+	PriceBump    uint64 // Minimum price bump percentage to replace an already existing nonce
+}
+
+// DefaultConfig contains the default configurations for the private transaction pool.
+var DefaultConfig = Config{
+	Lifetime:     64,
+	AccountSlots: 16,
+	GlobalSlots:  1024,
+	PriceBump:    10,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.Lifetime < 1 {
+		log.Warn("Sanitizing invalid privatepool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
+		conf.Lifetime = DefaultConfig.Lifetime
+	}
+	if conf.AccountSlots < 1 {
+		log.Warn("Sanitizing invalid privatepool account slots", "provided", conf.AccountSlots, "updated", DefaultConfig.AccountSlots)
+		conf.AccountSlots = DefaultConfig.AccountSlots
+	}
+	if conf.GlobalSlots < 1 {
+		log.Warn("Sanitizing invalid privatepool global slots", "provided", conf.GlobalSlots, "updated", DefaultConfig.GlobalSlots)
+		conf.GlobalSlots = DefaultConfig.GlobalSlots
+	}
+	if conf.PriceBump < 1 {
+		log.Warn("Sanitizing invalid privatepool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
+		conf.PriceBump = DefaultConfig.PriceBump
+	}
+	return conf
+}
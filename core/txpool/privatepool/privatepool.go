@@ -0,0 +1,487 @@
+// Package privatepool implements the pool of the private transactions, which are
+// submitted to be included by the local node only and never broadcast.
+package privatepool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/lru"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+const (
+	// txMaxSize is the maximum size a single private transaction can have, the
+	// same as the one of the legacy pool.
+	txMaxSize = 128 * 1024
+
+	// maxStatuses is the number of private transactions which left the pool
+	// whose status is remembered.
+	maxStatuses = 4096
+
+	// maxResetDepth is the number of blocks walked back on a reset to find the
+	// private transactions which were included.
+	maxResetDepth = 64
+)
+
+var (
+	// ErrDeadlinePassed is returned if the deadline of a private transaction is
+	// not after the current head.
+	ErrDeadlinePassed = errors.New("private transaction deadline already passed")
+
+	// ErrDeadlineTooFar is returned if the deadline of a private transaction is
+	// further than the lifetime allowed by the pool.
+	ErrDeadlineTooFar = errors.New("private transaction deadline too far")
+
+	// ErrPoolFull is returned if the pool can't hold any more private transaction.
+	ErrPoolFull = errors.New("private transaction pool is full")
+
+	// errPublicTx is returned if a public transaction is added to the pool.
+	errPublicTx = errors.New("private pool only accepts private transactions")
+)
+
+var (
+	pendingGauge  = metrics.NewRegisteredGauge("txpool/private/pending", nil)
+	includedMeter = metrics.NewRegisteredMeter("txpool/private/included", nil)
+	expiredMeter  = metrics.NewRegisteredMeter("txpool/private/expired", nil)
+	droppedMeter  = metrics.NewRegisteredMeter("txpool/private/dropped", nil)
+)
+
+// privateTx is a private transaction waiting for its inclusion.
+type privateTx struct {
+	tx       *types.Transaction
+	from     common.Address
+	maxBlock uint64    // Last block the transaction may be included in
+	time     time.Time // Time when the transaction was submitted
+}
+
+// PrivatePool is the transaction pool holding the private transactions. They
+// are only handed to the local miner, with priority over the public ones, and
+// never surface through the content, the pending transactions, the lookups or
+// the events of the pool, so that they are neither gossiped nor leaked through
+// the txpool and pending transaction filter APIs.
+//
+// The transactions of an account are kept gapless from its state nonce, and the
+// account is reserved while it has any: its public transactions are rejected
+// until the private ones are included or dropped.
+type PrivatePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	reserve txpool.AddressReserver
+
+	lock  sync.Mutex
+	head  *types.Header
+	state *state.StateDB
+
+	txs      map[common.Address][]*privateTx                  // Transactions of every account, sorted by nonce
+	lookup   map[common.Hash]*privateTx                       // Transactions waiting for their inclusion
+	statuses *lru.Cache[common.Hash, *txpool.PrivateTxStatus] // Statuses of the transactions which left the pool
+}
+
+// New creates a new private transaction pool.
+func New(config Config, chain BlockChain) *PrivatePool {
+	return &PrivatePool{
+		config:   config.sanitize(),
+		chain:    chain,
+		signer:   types.LatestSigner(chain.Config()),
+		txs:      make(map[common.Address][]*privateTx),
+		lookup:   make(map[common.Hash]*privateTx),
+		statuses: lru.NewCache[common.Hash, *txpool.PrivateTxStatus](maxStatuses),
+	}
+}
+
+// Filter returns false: the pool only holds the transactions explicitly added
+// as private, never the public ones.
+func (p *PrivatePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// Init sets the gas price needed to keep a transaction in the pool and the chain
+// head to allow balance / nonce checks.
+func (p *PrivatePool) Init(gasTip uint64, head *types.Header, reserve txpool.AddressReserver) error {
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+
+	p.reserve = reserve
+	p.head, p.state = head, statedb
+
+	return nil
+}
+
+// Close terminates the private pool.
+func (p *PrivatePool) Close() error {
+	return nil
+}
+
+// Reset drops the private transactions which were included, whose nonce was
+// used or whose deadline passed with newHead.
+func (p *PrivatePool) Reset(oldHead, newHead *types.Header) {
+	statedb, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset private pool state", "err", err)
+		return
+	}
+
+	included := txpool.IncludedTransactions(p.chain.GetBlock, oldHead, newHead, maxResetDepth)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head, p.state = newHead, statedb
+
+	number := newHead.Number.Uint64()
+
+	for addr, list := range p.txs {
+		var (
+			nonce = statedb.GetNonce(addr)
+			keep  []*privateTx
+			gap   bool
+		)
+
+		for _, ptx := range list {
+			hash := ptx.tx.Hash()
+
+			switch {
+			case ptx.tx.Nonce() < nonce:
+				if block, ok := included[hash]; ok {
+					status := p.newStatus(ptx, txpool.PrivateTxIncluded)
+					status.BlockNumber = block
+
+					p.drop(hash, status)
+					includedMeter.Mark(1)
+				} else {
+					status := p.newStatus(ptx, txpool.PrivateTxDropped)
+					status.Error = fmt.Sprintf("nonce %d already used", ptx.tx.Nonce())
+
+					p.drop(hash, status)
+					droppedMeter.Mark(1)
+				}
+
+			case gap:
+				status := p.newStatus(ptx, txpool.PrivateTxDropped)
+				status.Error = "previous nonce dropped"
+
+				p.drop(hash, status)
+				droppedMeter.Mark(1)
+
+			case number >= ptx.maxBlock:
+				// The following transactions of the account can't be included anymore
+				gap = true
+
+				p.drop(hash, p.newStatus(ptx, txpool.PrivateTxExpired))
+				expiredMeter.Mark(1)
+
+			default:
+				keep = append(keep, ptx)
+			}
+		}
+
+		if len(keep) > 0 {
+			p.txs[addr] = keep
+			continue
+		}
+
+		delete(p.txs, addr)
+
+		if err := p.reserve(addr, false); err != nil {
+			log.Error("Failed to release private account reservation", "address", addr, "err", err)
+		}
+	}
+
+	pendingGauge.Update(int64(len(p.lookup)))
+}
+
+// newStatus creates the status of a private transaction.
+func (p *PrivatePool) newStatus(ptx *privateTx, status string) *txpool.PrivateTxStatus {
+	return &txpool.PrivateTxStatus{
+		Hash:     ptx.tx.Hash(),
+		From:     ptx.from,
+		Nonce:    ptx.tx.Nonce(),
+		MaxBlock: ptx.maxBlock,
+		Status:   status,
+	}
+}
+
+// drop removes a transaction from the lookup and remembers its final status.
+// The caller is responsible for removing it from the list of its account.
+func (p *PrivatePool) drop(hash common.Hash, status *txpool.PrivateTxStatus) {
+	delete(p.lookup, hash)
+	p.statuses.Add(hash, status)
+
+	log.Debug("Private transaction left the pool", "hash", hash, "status", status.Status, "err", status.Error)
+}
+
+// SetGasTip does nothing: the private transactions are submitted to the local
+// node on purpose and aren't held to its minimum tip.
+func (p *PrivatePool) SetGasTip(tip *big.Int) {}
+
+// Has returns false: the private transactions are never revealed to the lookups
+// used to answer the network.
+func (p *PrivatePool) Has(hash common.Hash) bool {
+	return false
+}
+
+// Get returns nil: the private transactions are never revealed to the lookups
+// used to answer the network.
+func (p *PrivatePool) Get(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+// Add rejects all the transactions: the private ones are added with AddPrivate.
+func (p *PrivatePool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = errPublicTx
+	}
+	return errs
+}
+
+// AddPrivate validates and adds a private transaction which may be included up
+// to the block maxBlock, or for the lifetime of the pool if zero.
+func (p *PrivatePool) AddPrivate(tx *types.Transaction, maxBlock uint64) (*txpool.PrivateTxStatus, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hash := tx.Hash()
+	if _, ok := p.lookup[hash]; ok {
+		return nil, txpool.ErrAlreadyKnown
+	}
+
+	head := p.head.Number.Uint64()
+	if maxBlock == 0 {
+		maxBlock = head + p.config.Lifetime
+	}
+
+	if maxBlock <= head {
+		return nil, fmt.Errorf("%w: deadline %d, head %d", ErrDeadlinePassed, maxBlock, head)
+	}
+
+	if maxBlock > head+p.config.Lifetime {
+		return nil, fmt.Errorf("%w: deadline %d, head %d, lifetime %d", ErrDeadlineTooFar, maxBlock, head, p.config.Lifetime)
+	}
+
+	if err := p.validateTx(tx); err != nil {
+		return nil, err
+	}
+
+	from, _ := types.Sender(p.signer, tx) // already validated
+
+	var (
+		list = p.txs[from]
+		ptx  = &privateTx{tx: tx, from: from, maxBlock: maxBlock, time: time.Now()}
+	)
+
+	if i := tx.Nonce() - p.state.GetNonce(from); i < uint64(len(list)) {
+		// Replace the transaction of the same nonce if the new one pays enough
+		old := list[i]
+		if !bumped(old.tx, tx, p.config.PriceBump) {
+			return nil, txpool.ErrReplaceUnderpriced
+		}
+
+		status := p.newStatus(old, txpool.PrivateTxDropped)
+		status.Error = "replaced by " + hash.Hex()
+
+		p.drop(old.tx.Hash(), status)
+		droppedMeter.Mark(1)
+
+		list[i] = ptx
+	} else {
+		if uint64(len(p.lookup)) >= p.config.GlobalSlots {
+			return nil, ErrPoolFull
+		}
+
+		if len(list) == 0 {
+			if err := p.reserve(from, true); err != nil {
+				return nil, err
+			}
+		}
+
+		p.txs[from] = append(list, ptx)
+	}
+
+	p.lookup[hash] = ptx
+	pendingGauge.Update(int64(len(p.lookup)))
+
+	log.Debug("Added private transaction", "hash", hash, "from", from, "nonce", tx.Nonce(), "maxBlock", maxBlock)
+
+	return p.newStatus(ptx, txpool.PrivateTxPending), nil
+}
+
+// validateTx checks whether a private transaction is valid according to the
+// consensus rules and can be executed after the private transactions of its
+// account already pooled.
+func (p *PrivatePool) validateTx(tx *types.Transaction) error {
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  new(big.Int),
+	}
+	if err := txpool.ValidateTransaction(tx, p.head, p.signer, opts); err != nil {
+		return err
+	}
+
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State: p.state,
+
+		FirstNonceGap: func(addr common.Address) uint64 {
+			return p.state.GetNonce(addr) + uint64(len(p.txs[addr]))
+		},
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			have := len(p.txs[addr])
+			return have, int(p.config.AccountSlots) - have
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			spent := new(big.Int)
+			for _, ptx := range p.txs[addr] {
+				spent.Add(spent, ptx.tx.Cost())
+			}
+			return spent
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			for _, ptx := range p.txs[addr] {
+				if ptx.tx.Nonce() == nonce {
+					return ptx.tx.Cost()
+				}
+			}
+			return nil
+		},
+	}
+
+	return txpool.ValidateTransactionWithState(tx, p.signer, stateOpts)
+}
+
+// bumped returns whether tx pays enough over old to replace it.
+func bumped(old, tx *types.Transaction, priceBump uint64) bool {
+	threshold := func(price *big.Int) *big.Int {
+		bump := new(big.Int).Mul(price, new(big.Int).SetUint64(100+priceBump))
+		return bump.Div(bump, big.NewInt(100))
+	}
+
+	return tx.GasFeeCap().Cmp(threshold(old.GasFeeCap())) >= 0 && tx.GasTipCap().Cmp(threshold(old.GasTipCap())) >= 0
+}
+
+// Pending returns nothing: the private transactions are only retrieved by the
+// miner through PendingPrivate.
+func (p *PrivatePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return map[common.Address][]*txpool.LazyTransaction{}
+}
+
+// PendingPrivate retrieves the private transactions which are currently
+// processable, grouped by origin account and sorted by nonce. The miner tip
+// isn't enforced on them.
+func (p *PrivatePool) PendingPrivate(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	pending := make(map[common.Address][]*txpool.LazyTransaction)
+
+	// The pool holds no blob transactions
+	if filter.OnlyBlobTxs {
+		return pending
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for addr, list := range p.txs {
+		lazies := make([]*txpool.LazyTransaction, 0, len(list))
+
+		for _, ptx := range list {
+			if filter.BaseFee != nil && ptx.tx.GasFeeCapIntCmp(filter.BaseFee.ToBig()) < 0 {
+				break
+			}
+
+			lazies = append(lazies, &txpool.LazyTransaction{
+				Pool:      p,
+				Hash:      ptx.tx.Hash(),
+				Tx:        ptx.tx,
+				Time:      ptx.time,
+				GasFeeCap: uint256.MustFromBig(ptx.tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(ptx.tx.GasTipCap()),
+				Gas:       ptx.tx.Gas(),
+			})
+		}
+
+		if len(lazies) > 0 {
+			pending[addr] = lazies
+		}
+	}
+
+	return pending
+}
+
+// PrivateStatus returns the status of a private transaction, nil if it's
+// unknown or was forgotten.
+func (p *PrivatePool) PrivateStatus(hash common.Hash) *txpool.PrivateTxStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if ptx, ok := p.lookup[hash]; ok {
+		return p.newStatus(ptx, txpool.PrivateTxPending)
+	}
+
+	status, _ := p.statuses.Peek(hash)
+
+	return status
+}
+
+// SubscribeTransactions returns a subscription which never fires: the private
+// transactions are never announced.
+func (p *PrivatePool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// Nonce returns the state nonce of an account: its private transactions aren't
+// revealed.
+func (p *PrivatePool) Nonce(addr common.Address) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.state.GetNonce(addr)
+}
+
+// Stats returns no transaction: the private ones aren't revealed.
+func (p *PrivatePool) Stats() (int, int) {
+	return 0, 0
+}
+
+// Content returns no transaction: the private ones aren't revealed.
+func (p *PrivatePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom returns no transaction: the private ones aren't revealed.
+func (p *PrivatePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return []*types.Transaction{}, []*types.Transaction{}
+}
+
+// Locals returns no account: the private transactions aren't revealed.
+func (p *PrivatePool) Locals() []common.Address {
+	return []common.Address{}
+}
+
+// Status returns unknown: the private transactions aren't revealed, their status
+// is available through PrivateStatus.
+func (p *PrivatePool) Status(hash common.Hash) txpool.TxStatus {
+	return txpool.TxStatusUnknown
+}
@@ -0,0 +1,157 @@
+package privatepool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/params"
+	"github.com/zenanetwork/go-zenanet/trie"
+)
+
+type testBlockChain struct {
+	statedb *state.StateDB
+	blocks  map[common.Hash]*types.Block
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+// addBlock adds a block holding txs on top of parent.
+func (bc *testBlockChain) addBlock(parent *types.Header, txs ...*types.Transaction) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		BaseFee:    parent.BaseFee,
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+	bc.blocks[block.Hash()] = block
+
+	return block.Header()
+}
+
+func privateTransaction(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(10 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(1),
+	})
+}
+
+func TestPrivatePool(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(addr, uint256.NewInt(params.Zen), tracing.BalanceChangeUnspecified)
+
+	var (
+		chain = &testBlockChain{statedb: statedb, blocks: make(map[common.Hash]*types.Block)}
+		head  = &types.Header{Number: big.NewInt(10), GasLimit: 30_000_000, BaseFee: big.NewInt(params.GWei)}
+
+		reserved = make(map[common.Address]bool)
+		reserve  = func(addr common.Address, reserve bool) error {
+			if reserved[addr] == reserve {
+				return txpool.ErrAlreadyReserved
+			}
+			reserved[addr] = reserve
+			return nil
+		}
+	)
+
+	pool := New(DefaultConfig, chain)
+	require.NoError(t, pool.Init(0, head, reserve))
+
+	// A transaction without deadline lives for the lifetime of the pool
+	tx0 := privateTransaction(key, 0)
+
+	status, err := pool.AddPrivate(tx0, 0)
+	require.NoError(t, err)
+	require.Equal(t, txpool.PrivateTxPending, status.Status)
+	require.Equal(t, addr, status.From)
+	require.Equal(t, 10+DefaultConfig.Lifetime, status.MaxBlock)
+	require.True(t, reserved[addr])
+
+	_, err = pool.AddPrivate(tx0, 0)
+	require.ErrorIs(t, err, txpool.ErrAlreadyKnown)
+
+	// Nothing is revealed but to the miner
+	require.Empty(t, pool.Pending(txpool.PendingFilter{}))
+	require.Nil(t, pool.Get(tx0.Hash()))
+	require.False(t, pool.Has(tx0.Hash()))
+	require.Equal(t, txpool.TxStatusUnknown, pool.Status(tx0.Hash()))
+	require.Equal(t, uint64(0), pool.Nonce(addr))
+
+	pending, queued := pool.Content()
+	require.Empty(t, pending)
+	require.Empty(t, queued)
+
+	pendingPrivate := pool.PendingPrivate(txpool.PendingFilter{BaseFee: uint256.NewInt(params.GWei)})
+	require.Len(t, pendingPrivate[addr], 1)
+	require.Equal(t, tx0, pendingPrivate[addr][0].Tx)
+
+	require.Empty(t, pool.PendingPrivate(txpool.PendingFilter{BaseFee: uint256.NewInt(100 * params.GWei)}))
+
+	// Gapped nonces and invalid deadlines are rejected
+	_, err = pool.AddPrivate(privateTransaction(key, 2), 0)
+	require.ErrorIs(t, err, core.ErrNonceTooHigh)
+
+	_, err = pool.AddPrivate(privateTransaction(key, 1), 10)
+	require.ErrorIs(t, err, ErrDeadlinePassed)
+
+	_, err = pool.AddPrivate(privateTransaction(key, 1), 11+DefaultConfig.Lifetime)
+	require.ErrorIs(t, err, ErrDeadlineTooFar)
+
+	tx1 := privateTransaction(key, 1)
+
+	_, err = pool.AddPrivate(tx1, 12)
+	require.NoError(t, err)
+
+	// The first transaction is included in the next block
+	next := chain.addBlock(head, tx0)
+	statedb.SetNonce(addr, 1)
+
+	pool.Reset(head, next)
+
+	status = pool.PrivateStatus(tx0.Hash())
+	require.Equal(t, txpool.PrivateTxIncluded, status.Status)
+	require.Equal(t, uint64(11), status.BlockNumber)
+	require.Equal(t, txpool.PrivateTxPending, pool.PrivateStatus(tx1.Hash()).Status)
+
+	// The second one reaches its deadline, releasing the account
+	head, next = next, chain.addBlock(next)
+	pool.Reset(head, next)
+
+	require.Equal(t, txpool.PrivateTxExpired, pool.PrivateStatus(tx1.Hash()).Status)
+	require.Empty(t, pool.PendingPrivate(txpool.PendingFilter{}))
+	require.False(t, reserved[addr])
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/core/types"
 )
 
@@ -21,3 +22,20 @@ type Chain2HeadEvent struct {
 	OldChain []*types.Block
 	Type     string
 }
+
+// MilestoneEvent is posted when a new milestone from Iris is verified and whitelisted
+type MilestoneEvent struct {
+	Number uint64      `json:"number"` // End block of the milestone
+	Hash   common.Hash `json:"hash"`   // Hash of the end block
+}
+
+// CheckpointEvent is posted when a new checkpoint from Iris is verified and whitelisted
+type CheckpointEvent struct {
+	Number uint64      `json:"number"` // End block of the checkpoint
+	Hash   common.Hash `json:"hash"`   // Hash of the end block
+}
+
+// NoAckMilestoneEvent is posted when Iris reports a milestone as not acknowledged
+type NoAckMilestoneEvent struct {
+	MilestoneID string `json:"milestoneID"`
+}
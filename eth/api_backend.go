@@ -385,6 +385,30 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) TxPoolConditionalEvictions() []*txpool.ConditionalEviction {
+	return b.eth.txPool.ConditionalEvictions()
+}
+
+func (b *EthAPIBackend) TxPoolConditionalEviction(hash common.Hash) *txpool.ConditionalEviction {
+	return b.eth.txPool.ConditionalEviction(hash)
+}
+
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, maxBlock uint64) (*txpool.PrivateTxStatus, error) {
+	return b.eth.txPool.AddPrivate(signedTx, maxBlock)
+}
+
+func (b *EthAPIBackend) PrivateTxStatus(hash common.Hash) *txpool.PrivateTxStatus {
+	return b.eth.txPool.PrivateStatus(hash)
+}
+
+func (b *EthAPIBackend) SendBundle(ctx context.Context, bundle *txpool.Bundle) (*txpool.BundleStatus, error) {
+	return b.eth.txPool.AddBundle(bundle)
+}
+
+func (b *EthAPIBackend) BundleStatus(hash common.Hash) *txpool.BundleStatus {
+	return b.eth.txPool.BundleStatus(hash)
+}
+
 func (b *EthAPIBackend) TxPool() *txpool.TxPool {
 	return b.eth.txPool
 }
@@ -24,6 +24,8 @@ import (
 
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state"
 	"github.com/zenanetwork/go-zenanet/core/types"
@@ -443,3 +445,94 @@ func (api *DebugAPI) GetTrieFlushInterval() (string, error) {
 	}
 	return api.eth.blockchain.GetTrieFlushInterval().String(), nil
 }
+
+// GetParallelExecutionReport returns the report of the Block STM execution of
+// a block: the re-executions, the critical path and the hottest conflicting
+// keys. Reports are only stored when enabled with parallelevm.reports, for the
+// blocks executed by the parallel processor.
+func (api *DebugAPI) GetParallelExecutionReport(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*blockstm.ExecutionReport, error) {
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("block not found")
+	}
+	report := api.eth.blockchain.GetParallelExecutionReport(header.Hash(), header.Number.Uint64())
+	if report == nil {
+		return nil, fmt.Errorf("no parallel execution report for block %d", header.Number.Uint64())
+	}
+	return report, nil
+}
+
+const (
+	// maxParallelReplayBlocks is the number of blocks replayed at most by a
+	// single ReplayParallel call.
+	maxParallelReplayBlocks = 256
+
+	// parallelReplayReexec is the number of blocks re-executed at most to
+	// regenerate the state a replayed block is executed on.
+	parallelReplayReexec = uint64(128)
+)
+
+// ReplayParallel executes the blocks from the from to the to block (inclusive)
+// again with both the serial and the parallel state processor, on their
+// historical state, and reports the transactions whose receipts or written
+// values differ along with the MVHashMap versions they read.
+func (api *DebugAPI) ReplayParallel(ctx context.Context, from, to rpc.BlockNumber) ([]*core.ParallelReplay, error) {
+	resolve := func(num rpc.BlockNumber) (*types.Block, error) {
+		block, err := api.eth.APIBackend.BlockByNumber(ctx, num)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", num)
+		}
+		return block, nil
+	}
+	start, err := resolve(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	if start.NumberU64() == 0 {
+		return nil, errors.New("genesis is not replayable")
+	}
+	if start.NumberU64() > end.NumberU64() {
+		return nil, fmt.Errorf("end block #%d is before start block #%d", end.NumberU64(), start.NumberU64())
+	}
+	if end.NumberU64()-start.NumberU64() >= maxParallelReplayBlocks {
+		return nil, fmt.Errorf("at most %d blocks can be replayed at once", maxParallelReplayBlocks)
+	}
+	replays := make([]*core.ParallelReplay, 0, end.NumberU64()-start.NumberU64()+1)
+	for number := start.NumberU64(); number <= end.NumberU64(); number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block := api.eth.blockchain.GetBlockByNumber(number)
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		parent := api.eth.blockchain.GetBlock(block.ParentHash(), number-1)
+		if parent == nil {
+			return nil, fmt.Errorf("parent %#x of block #%d not found", block.ParentHash(), number)
+		}
+		statedb, release, err := api.eth.stateAtBlock(ctx, parent, parallelReplayReexec, nil, true, false)
+		if err != nil {
+			return nil, err
+		}
+		replay, err := api.eth.blockchain.ReplayParallel(block, statedb)
+		release()
+		if err != nil {
+			return nil, err
+		}
+		if replay.Diverged() {
+			log.Warn("Parallel execution diverged", "number", replay.Number, "hash", replay.Hash, "txs", len(replay.Divergences))
+		}
+		replays = append(replays, replay)
+	}
+	return replays, nil
+}
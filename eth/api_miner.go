@@ -20,6 +20,7 @@ import (
 	"math/big"
 
 	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/miner"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -56,3 +57,10 @@ func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
 	api.e.Miner().SetGasCeil(uint64(gasLimit))
 	return true
 }
+
+// GetBuildTrace returns why each transaction considered during the last build
+// of a block built locally was, or wasn't, included. It returns null if the
+// trace of the block isn't kept.
+func (api *MinerAPI) GetBuildTrace(number hexutil.Uint64) *miner.BuildTrace {
+	return api.e.Miner().BuildTrace(uint64(number))
+}
@@ -0,0 +1,78 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/rpc"
+)
+
+// finalityEventChanSize is the size of the channels receiving finality events
+// for a subscription.
+const finalityEventChanSize = 16
+
+// ZenaAPI provides the zena subscriptions to the finality changes fetched from Iris.
+// It extends the zena namespace of the consensus engine.
+type ZenaAPI struct {
+	e *Zenanet
+}
+
+// NewZenaAPI creates a new ZenaAPI instance.
+func NewZenaAPI(e *Zenanet) *ZenaAPI {
+	return &ZenaAPI{e}
+}
+
+// Milestones sends a notification each time a new milestone is whitelisted.
+func (api *ZenaAPI) Milestones(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeFinality(ctx, api.e.SubscribeMilestoneEvent)
+}
+
+// Checkpoints sends a notification each time a new checkpoint is whitelisted.
+func (api *ZenaAPI) Checkpoints(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeFinality(ctx, api.e.SubscribeCheckpointEvent)
+}
+
+// NoAckMilestones sends a notification each time Iris reports a milestone as
+// not acknowledged.
+func (api *ZenaAPI) NoAckMilestones(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeFinality(ctx, api.e.SubscribeNoAckMilestoneEvent)
+}
+
+// GetFinalityIncidents returns the recorded checkpoints and milestones which
+// didn't match the local chain, oldest first, along with the action taken.
+func (api *ZenaAPI) GetFinalityIncidents() ([]*FinalityIncident, error) {
+	return api.e.rewindGuard.incidents()
+}
+
+// subscribeFinality forwards the events of a finality feed to an RPC subscription.
+func subscribeFinality[T core.MilestoneEvent | core.CheckpointEvent | core.NoAckMilestoneEvent](ctx context.Context, subscribe func(chan<- T) event.Subscription) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan T, finalityEventChanSize)
+		sub := subscribe(events)
+
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				_ = notifier.Notify(rpcSub.ID, ev)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
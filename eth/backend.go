@@ -40,7 +40,9 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state/pruner"
 	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/bundlepool"
 	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/privatepool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/eth/downloader"
@@ -110,6 +112,12 @@ type Zenanet struct {
 
 	closeCh chan struct{} // Channel to signal the background processes to exit
 
+	finality    finalityFeed // Publishes the milestones and checkpoints fetched from Iris
+	rewindGuard *rewindGuard // Decides what to do with the milestones and checkpoints not matching the local chain
+
+	whitelist     *whitelist.Service // Checkpoints and milestones the chain and the peers are validated against
+	finalityAudit *finalityAudit     // Records the changes made by the operators to the whitelisting state
+
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
 }
 
@@ -251,11 +259,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Zenanet, error) {
 	}
 
 	checker := whitelist.NewService(chainDb)
+	eth.whitelist = checker
 
 	// check if Parallel EVM is enabled
 	// if enabled, use parallel state processor
 	if config.ParallelEVM.Enable {
 		eth.blockchain, err = core.NewParallelBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, eth.shouldPreserve, &config.TxLookupLimit, checker, config.ParallelEVM.SpeculativeProcesses, config.ParallelEVM.Enforce)
+		if err == nil {
+			eth.blockchain.SetParallelExecutionReports(config.ParallelEVM.Reports, config.ParallelEVM.ReportsRetention)
+			eth.blockchain.SetParallelTxDependencyMode(config.ParallelEVM.TxDependencyMode)
+			eth.blockchain.SetParallelAdaptiveProcesses(config.ParallelEVM.Adaptive)
+		}
 	} else {
 		eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, eth.shouldPreserve, &config.TxLookupLimit, checker)
 	}
@@ -282,10 +296,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Zenanet, error) {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	privatePool := privatepool.New(config.PrivatePool, eth.blockchain)
+	bundlePool := bundlepool.New(config.BundlePool, eth.blockchain)
 
 	// ZENA changes
 	// Blob pool is removed from Subpool for Zena
-	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool})
+	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool, privatePool, bundlePool})
 	if err != nil {
 		return nil, err
 	}
@@ -308,10 +324,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Zenanet, error) {
 		EthAPI:              blockChainAPI,
 		checker:             checker,
 		enableBlockTracking: eth.config.EnableBlockTracking,
+		banPeer:             eth.banPeer,
 	}); err != nil {
 		return nil, err
 	}
 
+	if eth.rewindGuard, err = newRewindGuard(chainDb, config.FinalityRewindPolicy, config.FinalityRewindThreshold); err != nil {
+		return nil, err
+	}
+
+	eth.finalityAudit = newFinalityAudit(chainDb)
+
 	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -404,6 +427,9 @@ func (s *Zenanet) APIs() []rpc.API {
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
+		}, {
+			Namespace: "zena",
+			Service:   NewZenaAPI(s),
 		},
 	}...)
 }
@@ -588,6 +614,25 @@ func (s *Zenanet) Synced() bool                       { return s.handler.synced.
 func (s *Zenanet) SetSynced()                         { s.handler.enableSyncedFeatures() }
 func (s *Zenanet) ArchiveMode() bool                  { return s.config.NoPruning }
 func (s *Zenanet) BloomIndexer() *core.ChainIndexer   { return s.bloomIndexer }
+func (s *Zenanet) Whitelist() *whitelist.Service      { return s.whitelist }
+
+// PeerScore returns the reputation of a peer.
+func (s *Zenanet) PeerScore(id string) *PeerScore { return s.handler.reputation.score(id) }
+
+// PeerScores returns the reputation of the peers which served invalid chains,
+// the lowest scores first.
+func (s *Zenanet) PeerScores() []*PeerScore { return s.handler.reputation.scores() }
+
+// banPeer refuses the connections to and from a peer for a while.
+func (s *Zenanet) banPeer(id string, duration time.Duration) {
+	nodeID, err := enode.ParseID(id)
+	if err != nil {
+		log.Warn("Failed to ban peer", "peer", id, "err", err)
+		return
+	}
+
+	s.p2pServer.BanPeer(nodeID, duration)
+}
 
 // SetAuthorized sets the authorized bool variable
 // denoting that consensus has been authorized while creation
@@ -633,6 +678,10 @@ func (s *Zenanet) Start() error {
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
 
+	if zena, ok := s.engine.(*zena.Zena); ok {
+		zena.StartProducerRecorder(s.blockchain)
+	}
+
 	go s.startCheckpointWhitelistService()
 	go s.startMilestoneWhitelistService()
 	go s.startNoAckMilestoneService()
@@ -747,6 +796,7 @@ func (s *Zenanet) handleWhitelistCheckpoint(ctx context.Context, ethHandler *eth
 	}
 
 	ethHandler.downloader.ProcessCheckpoint(blockNum, blockHash)
+	s.finality.sendCheckpoint(blockNum, blockHash)
 
 	return nil
 }
@@ -775,6 +825,7 @@ func (s *Zenanet) handleMilestone(ctx context.Context, ethHandler *ethHandler, z
 	}
 
 	ethHandler.downloader.ProcessMilestone(num, hash)
+	s.finality.sendMilestone(num, hash)
 
 	return nil
 }
@@ -791,6 +842,7 @@ func (s *Zenanet) handleNoAckMilestone(ctx context.Context, ethHandler *ethHandl
 	}
 
 	ethHandler.downloader.RemoveMilestoneID(milestoneID)
+	s.finality.sendNoAckMilestone(milestoneID)
 
 	return nil
 }
@@ -803,6 +855,7 @@ func (s *Zenanet) handleNoAckMilestoneByID(ctx context.Context, ethHandler *ethH
 		err := ethHandler.fetchNoAckMilestoneByID(ctx, zena, milestoneID)
 		if err == nil {
 			ethHandler.downloader.RemoveMilestoneID(milestoneID)
+			s.finality.sendNoAckMilestone(milestoneID)
 		}
 	}
 
@@ -844,6 +897,7 @@ func (s *Zenanet) Stop() error {
 
 	// Close all bg processes
 	close(s.closeCh)
+	s.finality.scope.Close()
 
 	s.txPool.Close()
 	s.miner.Close()
@@ -1,6 +1,10 @@
 package whitelist
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/common/flags"
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
@@ -26,6 +30,10 @@ type milestoneService interface {
 	finalityService
 
 	GetMilestoneIDsList() []string
+	GetLockedSprint() (bool, uint64, common.Hash, []string)
+	GetFutureMilestones() []Entry
+	UnlockMilestoneIDs(ids []string) ([]string, error)
+	PurgeFutureMilestones(numbers []uint64) ([]Entry, error)
 	RemoveMilestoneID(milestoneId string)
 	LockMutex(endBlockNum uint64) bool
 	UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash)
@@ -235,6 +243,125 @@ func (m *milestone) GetMilestoneIDsList() []string {
 	return keys
 }
 
+// GetLockedSprint returns the locked sprint, along with the sorted ids of the
+// milestones which locked it.
+func (m *milestone) GetLockedSprint() (bool, uint64, common.Hash, []string) {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	ids := make([]string, 0, len(m.LockedMilestoneIDs))
+	for id := range m.LockedMilestoneIDs {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, ids
+}
+
+// GetFutureMilestones returns the future milestones in the order they were received.
+func (m *milestone) GetFutureMilestones() []Entry {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	entries := make([]Entry, 0, len(m.FutureMilestoneOrder))
+	for _, number := range m.FutureMilestoneOrder {
+		entries = append(entries, Entry{Number: number, Hash: m.FutureMilestoneList[number]})
+	}
+
+	return entries
+}
+
+// UnlockMilestoneIDs removes the given milestone ids from the locked sprint, or
+// all of them if none is given, unlocking the sprint once no id is left. It fails
+// without changing anything if one of the ids doesn't lock the sprint. The ids
+// removed are returned, sorted.
+func (m *milestone) UnlockMilestoneIDs(ids []string) ([]string, error) {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	if len(ids) == 0 {
+		for id := range m.LockedMilestoneIDs {
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := m.LockedMilestoneIDs[id]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownMilestoneID, id)
+		}
+	}
+
+	lockedMilestoneIDs := make(map[string]struct{}, len(m.LockedMilestoneIDs))
+	for id := range m.LockedMilestoneIDs {
+		lockedMilestoneIDs[id] = struct{}{}
+	}
+
+	for _, id := range ids {
+		delete(lockedMilestoneIDs, id)
+	}
+
+	locked := m.Locked && len(lockedMilestoneIDs) > 0
+
+	if err := rawdb.WriteLockField(m.db, locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, lockedMilestoneIDs); err != nil {
+		return nil, err
+	}
+
+	m.Locked = locked
+	m.LockedMilestoneIDs = lockedMilestoneIDs
+
+	MilestoneIdsLengthMeter.Update(int64(len(m.LockedMilestoneIDs)))
+
+	removed := append([]string(nil), ids...)
+	sort.Strings(removed)
+
+	return slices.Compact(removed), nil
+}
+
+// PurgeFutureMilestones removes the future milestones ending at the given block
+// numbers, or all of them if none is given. It fails without changing anything
+// if one of the numbers isn't a future milestone. The milestones removed are
+// returned in the order they were received.
+func (m *milestone) PurgeFutureMilestones(numbers []uint64) ([]Entry, error) {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	purge := make(map[uint64]struct{}, len(numbers))
+
+	for _, number := range numbers {
+		if _, ok := m.FutureMilestoneList[number]; !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownFutureMilestone, number)
+		}
+
+		purge[number] = struct{}{}
+	}
+
+	var (
+		order   = make([]uint64, 0, len(m.FutureMilestoneOrder))
+		list    = make(map[uint64]common.Hash, len(m.FutureMilestoneList))
+		removed = make([]Entry, 0, len(m.FutureMilestoneOrder))
+	)
+
+	for _, number := range m.FutureMilestoneOrder {
+		if _, ok := purge[number]; ok || len(numbers) == 0 {
+			removed = append(removed, Entry{Number: number, Hash: m.FutureMilestoneList[number]})
+			continue
+		}
+
+		order = append(order, number)
+		list[number] = m.FutureMilestoneList[number]
+	}
+
+	if err := rawdb.WriteFutureMilestoneList(m.db, order, list); err != nil {
+		return nil, err
+	}
+
+	m.FutureMilestoneOrder = order
+	m.FutureMilestoneList = list
+
+	return removed, nil
+}
+
 // This is remove the milestoneIDs stored in the list.
 func (m *milestone) purgeMilestoneIDsList() {
 	m.LockedMilestoneIDs = make(map[string]struct{})
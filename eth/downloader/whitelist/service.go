@@ -17,6 +17,9 @@ var (
 	ErrCheckpointMismatch = errors.New("checkpoint mismatch")
 	ErrLongFutureChain    = errors.New("received future chain of unacceptable length")
 	ErrNoRemoteCheckpoint = errors.New("remote peer doesn't have a checkpoint")
+
+	ErrUnknownMilestoneID     = errors.New("milestone id doesn't lock the sprint")
+	ErrUnknownFutureMilestone = errors.New("no future milestone ends at the block")
 )
 
 type Service struct {
@@ -143,6 +146,14 @@ func (s *Service) GetMilestoneIDsList() []string {
 	return s.milestoneService.GetMilestoneIDsList()
 }
 
+func (s *Service) UnlockMilestoneIDs(ids []string) ([]string, error) {
+	return s.milestoneService.UnlockMilestoneIDs(ids)
+}
+
+func (s *Service) PurgeFutureMilestones(numbers []uint64) ([]Entry, error) {
+	return s.milestoneService.PurgeFutureMilestones(numbers)
+}
+
 func splitChain(current uint64, chain []*types.Header) ([]*types.Header, []*types.Header) {
 	var (
 		pastChain   []*types.Header
@@ -249,6 +249,116 @@ func TestMilestone(t *testing.T) {
 	require.Equal(t, milestone.FutureMilestoneOrder[capacity-1], uint64(16*capacity), "expected value is", uint64(16*capacity), "but got", milestone.FutureMilestoneOrder[capacity-1])
 }
 
+// TestStatus checks the snapshot of the whitelisting state
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	s := NewMockService(rawdb.NewMemoryDatabase())
+
+	status := s.Status()
+	require.Nil(t, status.Checkpoint)
+	require.Nil(t, status.Milestone)
+	require.False(t, status.Locked)
+	require.Empty(t, status.LockedMilestoneIDs)
+	require.Empty(t, status.FutureMilestones)
+
+	s.ProcessCheckpoint(10, common.Hash{1})
+	s.ProcessMilestone(12, common.Hash{2})
+
+	s.ProcessFutureMilestone(40, common.Hash{5})
+	s.ProcessFutureMilestone(30, common.Hash{4})
+
+	milestone := s.milestoneService.(*milestone)
+	milestone.LockMutex(20)
+	milestone.UnlockMutex(true, "milestoneID2", 20, common.Hash{3})
+	milestone.LockedMilestoneIDs["milestoneID1"] = struct{}{}
+
+	status = s.Status()
+	require.Equal(t, &Entry{Number: 10, Hash: common.Hash{1}}, status.Checkpoint)
+	require.Equal(t, &Entry{Number: 12, Hash: common.Hash{2}}, status.Milestone)
+	require.True(t, status.Locked)
+	require.Equal(t, uint64(20), status.LockedMilestoneNumber)
+	require.Equal(t, common.Hash{3}, status.LockedMilestoneHash)
+	require.Equal(t, []string{"milestoneID1", "milestoneID2"}, status.LockedMilestoneIDs)
+	require.Equal(t, []Entry{{Number: 40, Hash: common.Hash{5}}, {Number: 30, Hash: common.Hash{4}}}, status.FutureMilestones)
+}
+
+// TestUnlockMilestoneIDs checks the removal of the milestone ids locking the sprint
+func TestUnlockMilestoneIDs(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	s := NewMockService(db)
+
+	milestone := s.milestoneService.(*milestone)
+	milestone.LockMutex(20)
+	milestone.UnlockMutex(true, "milestoneID1", 20, common.Hash{3})
+	milestone.LockedMilestoneIDs["milestoneID2"] = struct{}{}
+
+	// Unknown ids leave the lock untouched
+	_, err := s.UnlockMilestoneIDs([]string{"milestoneID1", "milestoneID3"})
+	require.ErrorIs(t, err, ErrUnknownMilestoneID)
+	require.Equal(t, []string{"milestoneID1", "milestoneID2"}, s.Status().LockedMilestoneIDs)
+
+	removed, err := s.UnlockMilestoneIDs([]string{"milestoneID2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"milestoneID2"}, removed)
+	require.True(t, s.Status().Locked)
+
+	locked, _, _, ids, err := rawdb.ReadLockField(db)
+	require.NoError(t, err)
+	require.True(t, locked)
+	require.Len(t, ids, 1)
+
+	// A stale lock without any id is released as well
+	milestone.LockedMilestoneIDs = make(map[string]struct{})
+
+	removed, err = s.UnlockMilestoneIDs(nil)
+	require.NoError(t, err)
+	require.Empty(t, removed)
+	require.False(t, s.Status().Locked)
+
+	locked, _, _, _, err = rawdb.ReadLockField(db)
+	require.NoError(t, err)
+	require.False(t, locked)
+}
+
+// TestPurgeFutureMilestones checks the removal of the future milestones
+func TestPurgeFutureMilestones(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	s := NewMockService(db)
+
+	s.ProcessFutureMilestone(30, common.Hash{3})
+	s.ProcessFutureMilestone(40, common.Hash{4})
+	s.ProcessFutureMilestone(50, common.Hash{5})
+
+	// Unknown numbers leave the list untouched
+	_, err := s.PurgeFutureMilestones([]uint64{40, 45})
+	require.ErrorIs(t, err, ErrUnknownFutureMilestone)
+	require.Len(t, s.Status().FutureMilestones, 3)
+
+	removed, err := s.PurgeFutureMilestones([]uint64{40})
+	require.NoError(t, err)
+	require.Equal(t, []Entry{{Number: 40, Hash: common.Hash{4}}}, removed)
+	require.Equal(t, []Entry{{Number: 30, Hash: common.Hash{3}}, {Number: 50, Hash: common.Hash{5}}}, s.Status().FutureMilestones)
+
+	order, list, err := rawdb.ReadFutureMilestoneList(db)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{30, 50}, order)
+	require.Len(t, list, 2)
+
+	removed, err = s.PurgeFutureMilestones(nil)
+	require.NoError(t, err)
+	require.Len(t, removed, 2)
+	require.Empty(t, s.Status().FutureMilestones)
+
+	order, _, err = rawdb.ReadFutureMilestoneList(db)
+	require.NoError(t, err)
+	require.Empty(t, order)
+}
+
 // TestIsValidPeer checks the IsValidPeer function in isolation
 // for different cases by providing a mock fetchHeadersByNumber function
 func TestIsValidPeer(t *testing.T) {
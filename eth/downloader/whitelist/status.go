@@ -0,0 +1,42 @@
+package whitelist
+
+import "github.com/zenanetwork/go-zenanet/common"
+
+// Entry is a block whitelisted by a checkpoint or a milestone.
+type Entry struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Status is a snapshot of the whitelisted checkpoint and milestone, and of the
+// locks the milestones hold on the chain.
+type Status struct {
+	Checkpoint *Entry `json:"checkpoint"` // Nil if no checkpoint is whitelisted
+	Milestone  *Entry `json:"milestone"`  // Nil if no milestone is whitelisted
+
+	Locked                bool        `json:"locked"`                // Whether a sprint is locked
+	LockedMilestoneNumber uint64      `json:"lockedMilestoneNumber"` // End block of the locked sprint
+	LockedMilestoneHash   common.Hash `json:"lockedMilestoneHash"`   // Hash of the end block of the locked sprint
+	LockedMilestoneIDs    []string    `json:"lockedMilestoneIDs"`    // Milestones locking the sprint, sorted
+
+	FutureMilestones []Entry `json:"futureMilestones"` // Milestones ahead of the local chain, in the order received
+}
+
+// Status returns a snapshot of the whitelisting state.
+func (s *Service) Status() *Status {
+	status := &Status{
+		FutureMilestones: s.milestoneService.GetFutureMilestones(),
+	}
+
+	if exists, number, hash := s.checkpointService.Get(); exists {
+		status.Checkpoint = &Entry{Number: number, Hash: hash}
+	}
+
+	if exists, number, hash := s.milestoneService.Get(); exists {
+		status.Milestone = &Entry{Number: number, Hash: hash}
+	}
+
+	status.Locked, status.LockedMilestoneNumber, status.LockedMilestoneHash, status.LockedMilestoneIDs = s.milestoneService.GetLockedSprint()
+
+	return status
+}
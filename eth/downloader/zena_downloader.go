@@ -86,6 +86,10 @@ var (
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
 
+// peerReportFn is a callback type for reporting a peer which served a chain
+// failing the validation against the whitelisted checkpoints and milestones.
+type peerReportFn func(id string, err error)
+
 // badBlockFn is a callback for the async beacon sync to notify the caller that
 // the origin header requested to sync to, produced a chain with a bad block.
 type badBlockFn func(invalid *types.Header, origin *types.Header)
@@ -115,8 +119,9 @@ type Downloader struct {
 	blockchain BlockChain
 
 	// Callbacks
-	dropPeer peerDropFn // Drops a peer for misbehaving
-	badBlock badBlockFn // Reports a block as rejected by the chain
+	dropPeer   peerDropFn   // Drops a peer for misbehaving
+	reportPeer peerReportFn // Reports a peer failing the chain validation, may be nil
+	badBlock   badBlockFn   // Reports a block as rejected by the chain
 
 	// Status
 	synchroniseMock func(id string, hash common.Hash) error // Replacement for synchronise during testing
@@ -149,7 +154,8 @@ type Downloader struct {
 
 	// Validation
 	zenanet.ChainValidator
-	maxValidationThreshold uint64 // Number of block difference from remote peer to start validation
+	maxValidationThreshold uint64         // Number of block difference from remote peer to start validation
+	rejections             peerRejections // Peers refused by the chain validator
 
 	// Testing hooks
 	syncInitHook     func(uint64, uint64)  // Method to call upon initiating a new sync run
@@ -224,7 +230,7 @@ type BlockChain interface {
 
 // New creates a new downloader to fetch hashes and blocks from remote peers.
 // nolint: staticcheck
-func New(stateDb ethdb.Database, mux *event.TypeMux, chain BlockChain, lightchain LightChain, dropPeer peerDropFn, success func(), whitelistService zenanet.ChainValidator) *Downloader {
+func New(stateDb ethdb.Database, mux *event.TypeMux, chain BlockChain, lightchain LightChain, dropPeer peerDropFn, success func(), whitelistService zenanet.ChainValidator, reportPeer peerReportFn) *Downloader {
 	if lightchain == nil {
 		lightchain = chain
 	}
@@ -237,6 +243,7 @@ func New(stateDb ethdb.Database, mux *event.TypeMux, chain BlockChain, lightchai
 		blockchain:             chain,
 		lightchain:             lightchain,
 		dropPeer:               dropPeer,
+		reportPeer:             reportPeer,
 		headerProcCh:           make(chan *headerTask, 1),
 		quitCh:                 make(chan struct{}),
 		SnapSyncer:             snap.NewSyncer(stateDb, chain.TrieDB().Scheme()),
@@ -356,6 +363,12 @@ func (d *Downloader) LegacySync(id string, head common.Hash, td, ttd *big.Int, m
 		return err
 	}
 
+	// Lower the reputation of the peers serving chains the whitelist refuses
+	if d.reportPeer != nil && (errors.Is(err, whitelist.ErrNoRemote) || errors.Is(err, whitelist.ErrMismatch) ||
+		errors.Is(err, whitelist.ErrLongFutureChain)) {
+		d.reportPeer(id, err)
+	}
+
 	if errors.Is(err, errInvalidChain) || errors.Is(err, errBadPeer) || errors.Is(err, errTimeout) ||
 		errors.Is(err, errStallingPeer) || errors.Is(err, errUnsyncedPeer) || errors.Is(err, errEmptyHeaderSet) ||
 		errors.Is(err, errPeersUnavailable) || errors.Is(err, errTooOld) || errors.Is(err, errInvalidAncestor) {
@@ -917,6 +930,7 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 	if d.ChainValidator != nil {
 		_, err := d.IsValidPeer(d.getFetchHeadersByNumber(p))
 		if errors.Is(err, whitelist.ErrMismatch) {
+			d.rejections.add(p.id, err)
 			return 0, err
 		}
 
@@ -925,6 +939,8 @@ func (d *Downloader) findAncestor(p *peerConnection, remoteHeader *types.Header)
 			// our local height and remote peer's height is less than `maxValidationThreshold`
 			if localHeight >= remoteHeight-d.maxValidationThreshold {
 				log.Info("Remote peer didn't respond", "id", p.id, "local", localHeight, "remote", remoteHeight, "err", err)
+				d.rejections.add(p.id, err)
+
 				return 0, err
 			}
 
@@ -94,7 +94,7 @@ func newTesterWithNotification(t *testing.T, success func()) *downloadTester {
 	}
 
 	//nolint: staticcheck
-	tester.downloader = New(db, new(event.TypeMux), tester.chain, nil, tester.dropPeer, success, whitelist.NewService(db))
+	tester.downloader = New(db, new(event.TypeMux), tester.chain, nil, tester.dropPeer, success, whitelist.NewService(db), nil)
 
 	return tester
 }
@@ -1553,6 +1553,13 @@ func TestFakedSyncProgress67WhitelistMismatch(t *testing.T) {
 	if err := tester.sync("light", nil, mode); err == nil {
 		t.Fatal("succeeded attacker synchronisation")
 	}
+
+	// The peer should be reported as rejected
+	rejections := tester.downloader.PeerRejections()
+	assert.Equal(t, 1, len(rejections))
+	assert.Equal(t, "light", rejections[0].Peer)
+	assert.Equal(t, whitelist.ErrMismatch.Error(), rejections[0].Reason)
+	assert.Equal(t, uint64(1), rejections[0].Count)
 }
 
 // TestFakedSyncProgress67WhitelistMatch tests if in case of whitelisted
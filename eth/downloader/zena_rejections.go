@@ -0,0 +1,82 @@
+package downloader
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxPeerRejections is the number of peers whose rejections are remembered, the
+// ones rejected the longest ago being forgotten first.
+const maxPeerRejections = 64
+
+// PeerRejection describes why a peer was refused as a sync source because its
+// chain didn't match the whitelisted checkpoint or milestone.
+type PeerRejection struct {
+	Peer   string    `json:"peer"`
+	Reason string    `json:"reason"` // Error of the last rejection
+	Count  uint64    `json:"count"`  // Number of rejections since the peer was first seen
+	Time   time.Time `json:"time"`   // Time of the last rejection
+}
+
+// peerRejections tracks the peers rejected by the chain validator.
+type peerRejections struct {
+	peers map[string]*PeerRejection
+	lock  sync.Mutex
+}
+
+// add records the rejection of a peer.
+func (r *peerRejections) add(peer string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.peers == nil {
+		r.peers = make(map[string]*PeerRejection)
+	}
+
+	rejection, ok := r.peers[peer]
+	if !ok {
+		if len(r.peers) >= maxPeerRejections {
+			var oldest *PeerRejection
+
+			for _, rejection := range r.peers {
+				if oldest == nil || rejection.Time.Before(oldest.Time) {
+					oldest = rejection
+				}
+			}
+
+			delete(r.peers, oldest.Peer)
+		}
+
+		rejection = &PeerRejection{Peer: peer}
+		r.peers[peer] = rejection
+	}
+
+	rejection.Reason = err.Error()
+	rejection.Count++
+	rejection.Time = time.Now()
+}
+
+// list returns a copy of the rejections, the most recent first.
+func (r *peerRejections) list() []*PeerRejection {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rejections := make([]*PeerRejection, 0, len(r.peers))
+	for _, rejection := range r.peers {
+		rejection := *rejection
+		rejections = append(rejections, &rejection)
+	}
+
+	sort.Slice(rejections, func(i, j int) bool {
+		return rejections[i].Time.After(rejections[j].Time)
+	})
+
+	return rejections
+}
+
+// PeerRejections returns the peers refused as sync sources because their chain
+// didn't match the whitelisted checkpoint or milestone, the most recent first.
+func (d *Downloader) PeerRejections() []*PeerRejection {
+	return d.rejections.list()
+}
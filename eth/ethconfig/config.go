@@ -19,7 +19,9 @@ package ethconfig
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/zenanetwork/go-zenanet/common"
@@ -32,10 +34,15 @@ import (
 	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
 	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
 	"github.com/zenanetwork/go-zenanet/consensus/zena/irisapp"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iriscache"
 	"github.com/zenanetwork/go-zenanet/consensus/zena/irisgrpc"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/irispool"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/irisreplay"
 	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/core/txpool/blobpool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/bundlepool"
 	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/privatepool"
 	"github.com/zenanetwork/go-zenanet/eth/downloader"
 	"github.com/zenanetwork/go-zenanet/eth/gasprice"
 	"github.com/zenanetwork/go-zenanet/ethdb"
@@ -72,6 +79,8 @@ var Defaults = Config{
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
 	BlobPool:           blobpool.DefaultConfig,
+	PrivatePool:        privatepool.DefaultConfig,
+	BundlePool:         bundlepool.DefaultConfig,
 	RPCGasCap:          50000000,
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
@@ -148,8 +157,10 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool   legacypool.Config
-	BlobPool blobpool.Config
+	TxPool      legacypool.Config
+	BlobPool    blobpool.Config
+	PrivatePool privatepool.Config
+	BundlePool  bundlepool.Config
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
@@ -201,6 +212,32 @@ type Config struct {
 	// Use child iris process to fetch data, Only works when RunIris is true
 	UseIrisApp bool
 
+	// Additional Iris endpoints to fail over to. Entries are either http(s)
+	// URLs, grpc://<address> for gRPC servers or "app" for the child iris process
+	IrisEndpoints []string `toml:",omitempty"`
+
+	// Number of Iris endpoints which have to agree on spans, checkpoints and
+	// milestones. Values lower than 2 disable the agreement check
+	IrisQuorum int `toml:",omitempty"`
+
+	// Keep finalized spans, checkpoints and state-sync events fetched from
+	// Iris in the local database
+	IrisCache bool `toml:",omitempty"`
+
+	// Record every Iris response to the given fixture file
+	IrisRecordFile string `toml:",omitempty"`
+
+	// Serve Iris responses from the given fixture file instead of a live Iris
+	IrisReplayFile string `toml:",omitempty"`
+
+	// Policy applied when a checkpoint or a milestone doesn't match the local
+	// chain: auto, halt or consecutive. Defaults to auto
+	FinalityRewindPolicy string `toml:",omitempty"`
+
+	// Number of consecutive mismatches rewinding the chain with the
+	// consecutive policy
+	FinalityRewindThreshold uint64 `toml:",omitempty"`
+
 	// Zena logs flag
 	ZenaLogs bool
 
@@ -236,13 +273,21 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 				log.Warn("Sanitizing DevFakeAuthor", "Use DevFakeAuthor with", "--zena.withoutiris")
 			}
 
-			var irisClient zena.IIrisClient
-			if ethConfig.RunIris && ethConfig.UseIrisApp {
-				irisClient = irisapp.NewIrisAppClient()
-			} else if ethConfig.IrisgRPCAddress != "" {
-				irisClient = irisgrpc.NewIrisGRPCClient(ethConfig.IrisgRPCAddress)
-			} else {
-				irisClient = iris.NewIrisClient(ethConfig.IrisURL)
+			irisClient, err := newIrisClient(ethConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			if ethConfig.IrisCache {
+				irisClient = iriscache.NewIrisCacheClient(irisClient, db)
+			}
+
+			// The recorder wraps the cache, so the responses it serves are recorded too
+			if ethConfig.IrisRecordFile != "" {
+				irisClient, err = irisreplay.NewRecordingClient(irisClient, ethConfig.IrisRecordFile)
+				if err != nil {
+					return nil, err
+				}
 			}
 
 			return zena.New(chainConfig, db, blockchainAPI, spanner, irisClient, genesisContractsClient, false), nil
@@ -256,3 +301,58 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 	}
 	return beacon.New(ethash.NewFaker()), nil
 }
+
+// newIrisClient creates the client used to talk to Iris. When additional
+// endpoints are configured, every endpoint becomes a member of a failover pool.
+func newIrisClient(ethConfig *Config) (zena.IIrisClient, error) {
+	if ethConfig.IrisReplayFile != "" {
+		log.Info("Serving Iris responses from fixture", "path", ethConfig.IrisReplayFile)
+		return irisreplay.NewReplayClientFromFile(ethConfig.IrisReplayFile)
+	}
+
+	// A single endpoint can't agree with anyone
+	if ethConfig.IrisQuorum > 1 && len(ethConfig.IrisEndpoints) == 0 {
+		return nil, fmt.Errorf("iris quorum %d requires additional iris endpoints", ethConfig.IrisQuorum)
+	}
+
+	var primary irispool.Member
+	if ethConfig.RunIris && ethConfig.UseIrisApp {
+		primary = newIrisPoolMember(irisAppEndpoint)
+	} else if ethConfig.IrisgRPCAddress != "" {
+		primary = newIrisPoolMember(irisGRPCScheme + ethConfig.IrisgRPCAddress)
+	} else {
+		primary = newIrisPoolMember(ethConfig.IrisURL)
+	}
+
+	if len(ethConfig.IrisEndpoints) == 0 {
+		return primary.Client, nil
+	}
+
+	members := []irispool.Member{primary}
+	for _, endpoint := range ethConfig.IrisEndpoints {
+		members = append(members, newIrisPoolMember(endpoint))
+	}
+
+	return irispool.NewIrisPoolClient(members, irispool.Config{Quorum: ethConfig.IrisQuorum})
+}
+
+const (
+	irisAppEndpoint = "app"
+	irisGRPCScheme  = "grpc://"
+)
+
+// newIrisPoolMember creates the client matching the given endpoint.
+func newIrisPoolMember(endpoint string) irispool.Member {
+	var client zena.IIrisClient
+
+	switch {
+	case endpoint == irisAppEndpoint:
+		client = irisapp.NewIrisAppClient()
+	case strings.HasPrefix(endpoint, irisGRPCScheme):
+		client = irisgrpc.NewIrisGRPCClient(strings.TrimPrefix(endpoint, irisGRPCScheme))
+	default:
+		client = iris.NewIrisClient(endpoint)
+	}
+
+	return irispool.Member{Name: endpoint, Client: client}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/core/txpool/blobpool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/bundlepool"
 	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/privatepool"
 	"github.com/zenanetwork/go-zenanet/eth/downloader"
 	"github.com/zenanetwork/go-zenanet/eth/gasprice"
 	"github.com/zenanetwork/go-zenanet/miner"
@@ -51,6 +53,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                                miner.Config
 		TxPool                               legacypool.Config
 		BlobPool                             blobpool.Config
+		PrivatePool                          privatepool.Config
+		BundlePool                           bundlepool.Config
 		GPO                                  gasprice.Config
 		EnablePreimageRecording              bool
 		EnableWitnessCollection bool `toml:"-"`
@@ -68,6 +72,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RunIris                          bool
 		RunIrisArgs                      string
 		UseIrisApp                       bool
+		IrisEndpoints                    []string `toml:",omitempty"`
+		IrisQuorum                       int      `toml:",omitempty"`
+		IrisCache                        bool     `toml:",omitempty"`
+		IrisRecordFile                   string   `toml:",omitempty"`
+		IrisReplayFile                   string   `toml:",omitempty"`
+		FinalityRewindPolicy             string   `toml:",omitempty"`
+		FinalityRewindThreshold          uint64   `toml:",omitempty"`
 		ZenaLogs                              bool
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
@@ -107,6 +118,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.PrivatePool = c.PrivatePool
+	enc.BundlePool = c.BundlePool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableWitnessCollection = c.EnableWitnessCollection
@@ -124,6 +137,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RunIris = c.RunIris
 	enc.RunIrisArgs = c.RunIrisArgs
 	enc.UseIrisApp = c.UseIrisApp
+	enc.IrisEndpoints = c.IrisEndpoints
+	enc.IrisQuorum = c.IrisQuorum
+	enc.IrisCache = c.IrisCache
+	enc.IrisRecordFile = c.IrisRecordFile
+	enc.IrisReplayFile = c.IrisReplayFile
+	enc.FinalityRewindPolicy = c.FinalityRewindPolicy
+	enc.FinalityRewindThreshold = c.FinalityRewindThreshold
 	enc.ZenaLogs = c.ZenaLogs
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
@@ -167,6 +187,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                                *miner.Config
 		TxPool                               *legacypool.Config
 		BlobPool                             *blobpool.Config
+		PrivatePool                          *privatepool.Config
+		BundlePool                           *bundlepool.Config
 		GPO                                  *gasprice.Config
 		EnablePreimageRecording              *bool
 		EnableWitnessCollection *bool `toml:"-"`
@@ -184,6 +206,13 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RunIris                          *bool
 		RunIrisArgs                      *string
 		UseIrisApp                       *bool
+		IrisEndpoints                    []string `toml:",omitempty"`
+		IrisQuorum                       *int     `toml:",omitempty"`
+		IrisCache                        *bool    `toml:",omitempty"`
+		IrisRecordFile                   *string  `toml:",omitempty"`
+		IrisReplayFile                   *string  `toml:",omitempty"`
+		FinalityRewindPolicy             *string  `toml:",omitempty"`
+		FinalityRewindThreshold          *uint64  `toml:",omitempty"`
 		ZenaLogs                              *bool
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
@@ -292,6 +321,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.PrivatePool != nil {
+		c.PrivatePool = *dec.PrivatePool
+	}
+	if dec.BundlePool != nil {
+		c.BundlePool = *dec.BundlePool
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -343,6 +378,27 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.UseIrisApp != nil {
 		c.UseIrisApp = *dec.UseIrisApp
 	}
+	if dec.IrisEndpoints != nil {
+		c.IrisEndpoints = dec.IrisEndpoints
+	}
+	if dec.IrisQuorum != nil {
+		c.IrisQuorum = *dec.IrisQuorum
+	}
+	if dec.IrisCache != nil {
+		c.IrisCache = *dec.IrisCache
+	}
+	if dec.IrisRecordFile != nil {
+		c.IrisRecordFile = *dec.IrisRecordFile
+	}
+	if dec.IrisReplayFile != nil {
+		c.IrisReplayFile = *dec.IrisReplayFile
+	}
+	if dec.FinalityRewindPolicy != nil {
+		c.FinalityRewindPolicy = *dec.FinalityRewindPolicy
+	}
+	if dec.FinalityRewindThreshold != nil {
+		c.FinalityRewindThreshold = *dec.FinalityRewindThreshold
+	}
 	if dec.ZenaLogs != nil {
 		c.ZenaLogs = *dec.ZenaLogs
 	}
@@ -96,9 +96,10 @@ type handlerConfig struct {
 	BloomCache          uint64              // Megabytes to alloc for snap sync bloom
 	EventMux            *event.TypeMux      // Legacy event mux, deprecate for `feed`
 	checker             zenanet.ChainValidator
-	RequiredBlocks      map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
-	EthAPI              *ethapi.BlockChainAPI  // EthAPI to interact
-	enableBlockTracking bool                   // Whether to log information collected while tracking block lifecycle
+	RequiredBlocks      map[uint64]common.Hash                  // Hard coded map of required block hashes for sync challenges
+	EthAPI              *ethapi.BlockChainAPI                   // EthAPI to interact
+	enableBlockTracking bool                                    // Whether to log information collected while tracking block lifecycle
+	banPeer             func(id string, duration time.Duration) // Refuses a peer for a while
 }
 
 type handler struct {
@@ -115,6 +116,7 @@ type handler struct {
 	maxPeers int
 
 	downloader   *downloader.Downloader
+	reputation   *peerReputation
 	blockFetcher *fetcher.BlockFetcher
 	txFetcher    *fetcher.TxFetcher
 	peers        *peerSet
@@ -201,7 +203,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return nil, errors.New("snap sync not supported with snapshots disabled")
 	}
 	// Construct the downloader (long sync)
-	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, nil, h.removePeer, h.enableSyncedFeatures, config.checker)
+	h.reputation = newPeerReputation(h.removePeer, config.banPeer)
+	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, nil, h.removePeer, h.enableSyncedFeatures, config.checker, h.reputation.report)
 	if ttd := h.chain.Config().TerminalTotalDifficulty; ttd != nil {
 		if h.chain.Config().TerminalTotalDifficultyPassed {
 			log.Info("Chain post-merge, sync via beacon client")
@@ -251,7 +254,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return nil, errors.New("snap sync not supported with snapshots disabled")
 	}
 
-	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.removePeer, h.enableBlockTracking)
+	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.removePeer, h.reputation.report, h.enableBlockTracking)
 
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
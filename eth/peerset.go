@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/zenanetwork/go-zenanet/common"
@@ -205,6 +206,21 @@ func (ps *peerSet) peer(id string) *ethPeer {
 	return ps.peers[id]
 }
 
+// ids retrieves the sorted ids of the registered peers.
+func (ps *peerSet) ids() []string {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	ids := make([]string, 0, len(ps.peers))
+	for id := range ps.peers {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
 // peersWithoutBlock retrieves a list of peers that do not have a given block in
 // their set of known hashes so it might be propagated to them.
 func (ps *peerSet) peersWithoutBlock(hash common.Hash) []*ethPeer {
@@ -258,19 +274,26 @@ func (ps *peerSet) snapLen() int {
 }
 
 // peerWithHighestTD retrieves the known peer with the currently highest total
-// difficulty, but below the given PoS switchover threshold.
-func (ps *peerSet) peerWithHighestTD() *eth.Peer {
+// difficulty, but below the given PoS switchover threshold. The deprioritised
+// peers, if a filter is given, are only picked if no other peer is known.
+func (ps *peerSet) peerWithHighestTD(deprioritised func(id string) bool) *eth.Peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
 	var (
-		bestPeer *eth.Peer
-		bestTd   *big.Int
+		bestPeer          *eth.Peer
+		bestTd            *big.Int
+		bestDeprioritised bool
 	)
 
-	for _, p := range ps.peers {
-		if _, td := p.Head(); bestPeer == nil || td.Cmp(bestTd) > 0 {
-			bestPeer, bestTd = p.Peer, td
+	for id, p := range ps.peers {
+		low := deprioritised != nil && deprioritised(id)
+		if low && bestPeer != nil && !bestDeprioritised {
+			continue
+		}
+
+		if _, td := p.Head(); bestPeer == nil || (bestDeprioritised && !low) || td.Cmp(bestTd) > 0 {
+			bestPeer, bestTd, bestDeprioritised = p.Peer, td, low
 		}
 	}
 
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/log"
 	"github.com/zenanetwork/go-zenanet/metrics"
 	"github.com/zenanetwork/go-zenanet/rpc"
@@ -123,17 +125,43 @@ func zenaVerify(ctx context.Context, eth *Zenanet, handler *ethHandler, start ui
 			rewindTo = head - maxRewindLen
 		}
 
-		if isCheckpoint {
-			log.Info("Rewinding chain due to checkpoint root hash mismatch", "number", rewindTo)
-		} else {
-			log.Info("Rewinding chain due to milestone endblock hash mismatch", "number", rewindTo)
+		incident := &FinalityIncident{
+			Time:         time.Now(),
+			Kind:         str,
+			StartBlock:   start,
+			EndBlock:     end,
+			ExpectedHash: common.HexToHash(hash),
+			LocalHash:    common.HexToHash(localHash),
+			Head:         head,
+			RewindTo:     rewindTo,
+			Peers:        eth.handler.peers.ids(),
 		}
 
-		rewindBack(eth, head, rewindTo)
+		switch eth.rewindGuard.handle(incident) {
+		case incidentHalted:
+			log.Error(fmt.Sprintf("Halting block production due to %s hash mismatch, manual intervention required", str),
+				"incident", incident.ID, "start", start, "end", end, "expected", hash, "local", localHash, "head", head)
+
+			finalityHaltMeter.Mark(1)
+			haltMining(eth)
+		case incidentDeferred:
+			log.Warn(fmt.Sprintf("Deferring rewind due to %s hash mismatch", str),
+				"incident", incident.ID, "consecutive", incident.Consecutive, "number", rewindTo)
+		default:
+			if isCheckpoint {
+				log.Info("Rewinding chain due to checkpoint root hash mismatch", "incident", incident.ID, "number", rewindTo)
+			} else {
+				log.Info("Rewinding chain due to milestone endblock hash mismatch", "incident", incident.ID, "number", rewindTo)
+			}
+
+			rewindBack(eth, head, rewindTo)
+		}
 
 		return hash, errHashMismatch
 	}
 
+	eth.rewindGuard.matched(str)
+
 	// fetch the end block hash
 	block, err := handler.ethAPI.GetBlockByNumber(ctx, rpc.BlockNumber(end), false)
 	if err != nil {
@@ -146,6 +174,17 @@ func zenaVerify(ctx context.Context, eth *Zenanet, handler *ethHandler, start ui
 	return hash, nil
 }
 
+// haltMining stops the miner if the mining process is running, leaving it
+// stopped until the operator restarts it.
+func haltMining(eth *Zenanet) {
+	if eth.Miner().Mining() {
+		ch := make(chan struct{})
+		eth.Miner().Stop(ch)
+
+		<-ch
+	}
+}
+
 // Stop the miner if the mining process is running and rewind back the chain
 func rewindBack(eth *Zenanet, head uint64, rewindTo uint64) {
 	if eth.Miner().Mining() {
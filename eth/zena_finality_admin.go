@@ -0,0 +1,149 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/eth/downloader/whitelist"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+)
+
+// Changes made by the operators to the whitelisting state
+const (
+	AuditUnlockMilestones      = "unlock-milestones"       // Milestone ids removed from the locked sprint
+	AuditPurgeFutureMilestones = "purge-future-milestones" // Future milestones removed
+)
+
+// maxFinalityAuditEntries is the number of audit entries kept in the database,
+// the oldest ones being dropped first.
+const maxFinalityAuditEntries = 1024
+
+// FinalityAuditEntry records a change made by an operator to the milestone locks
+// or to the future milestones.
+type FinalityAuditEntry struct {
+	ID               uint64            `json:"id"`
+	Time             time.Time         `json:"time"`
+	Action           string            `json:"action"`
+	Reason           string            `json:"reason"` // Reason given by the operator
+	MilestoneIDs     []string          `json:"milestoneIDs,omitempty"`
+	FutureMilestones []whitelist.Entry `json:"futureMilestones,omitempty"`
+	Status           *whitelist.Status `json:"status"` // Whitelisting state after the change
+}
+
+// finalityAudit keeps the record of the changes made by the operators to the
+// whitelisting state in the database.
+type finalityAudit struct {
+	db ethdb.Database
+
+	mu     sync.Mutex
+	nextID uint64
+}
+
+// newFinalityAudit creates an audit log numbering the entries after the ones
+// already stored.
+func newFinalityAudit(db ethdb.Database) *finalityAudit {
+	audit := &finalityAudit{db: db}
+
+	if ids, _ := rawdb.ReadFinalityAuditEntries(db); len(ids) > 0 {
+		audit.nextID = ids[len(ids)-1] + 1
+	}
+
+	return audit
+}
+
+// record logs and stores an audit entry, dropping the oldest entries beyond the limit.
+func (a *finalityAudit) record(entry *FinalityAuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry.ID = a.nextID
+	entry.Time = time.Now()
+
+	log.Warn("Whitelisting state changed by the operator", "id", entry.ID, "action", entry.Action, "reason", entry.Reason,
+		"milestoneIDs", entry.MilestoneIDs, "futureMilestones", len(entry.FutureMilestones))
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := rawdb.WriteFinalityAuditEntry(a.db, entry.ID, data); err != nil {
+		return err
+	}
+
+	a.nextID++
+
+	if entry.ID >= maxFinalityAuditEntries {
+		_ = rawdb.DeleteFinalityAuditEntry(a.db, entry.ID-maxFinalityAuditEntries)
+	}
+
+	return nil
+}
+
+// entries returns the stored audit entries, oldest first.
+func (a *finalityAudit) entries() ([]*FinalityAuditEntry, error) {
+	ids, data := rawdb.ReadFinalityAuditEntries(a.db)
+
+	entries := make([]*FinalityAuditEntry, 0, len(data))
+	for i, blob := range data {
+		entry := new(FinalityAuditEntry)
+		if err := json.Unmarshal(blob, entry); err != nil {
+			return nil, fmt.Errorf("finality audit entry %d: %w", ids[i], err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// FinalityStatus returns the whitelisted checkpoint and milestone, the locked
+// sprint and the future milestones.
+func (api *AdminAPI) FinalityStatus() *whitelist.Status {
+	return api.eth.Whitelist().Status()
+}
+
+// UnlockMilestones removes the given milestone ids from the locked sprint, or all
+// of them if none is given, unlocking the sprint once no id is left. The change
+// is recorded in the finality audit log along with the reason.
+func (api *AdminAPI) UnlockMilestones(ids []string, reason string) (*FinalityAuditEntry, error) {
+	removed, err := api.eth.Whitelist().UnlockMilestoneIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.audit(&FinalityAuditEntry{Action: AuditUnlockMilestones, Reason: reason, MilestoneIDs: removed})
+}
+
+// PurgeFutureMilestones removes the future milestones ending at the given block
+// numbers, or all of them if none is given. The change is recorded in the finality
+// audit log along with the reason.
+func (api *AdminAPI) PurgeFutureMilestones(numbers []uint64, reason string) (*FinalityAuditEntry, error) {
+	removed, err := api.eth.Whitelist().PurgeFutureMilestones(numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.audit(&FinalityAuditEntry{Action: AuditPurgeFutureMilestones, Reason: reason, FutureMilestones: removed})
+}
+
+// FinalityAuditLog returns the changes made by the operators to the whitelisting
+// state, oldest first.
+func (api *AdminAPI) FinalityAuditLog() ([]*FinalityAuditEntry, error) {
+	return api.eth.finalityAudit.entries()
+}
+
+// audit records a change already applied to the whitelisting state.
+func (api *AdminAPI) audit(entry *FinalityAuditEntry) (*FinalityAuditEntry, error) {
+	entry.Status = api.eth.Whitelist().Status()
+
+	if err := api.eth.finalityAudit.record(entry); err != nil {
+		return nil, fmt.Errorf("whitelisting state changed but not audited: %w", err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/eth/downloader/whitelist"
+)
+
+func TestFinalityAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	service := whitelist.NewService(db)
+
+	service.ProcessFutureMilestone(30, common.Hash{3})
+	service.ProcessFutureMilestone(40, common.Hash{4})
+
+	api := NewAdminAPI(&Zenanet{whitelist: service, finalityAudit: newFinalityAudit(db)})
+
+	entry, err := api.PurgeFutureMilestones([]uint64{30}, "stale milestone")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), entry.ID)
+	require.Equal(t, AuditPurgeFutureMilestones, entry.Action)
+	require.Equal(t, "stale milestone", entry.Reason)
+	require.Equal(t, []whitelist.Entry{{Number: 30, Hash: common.Hash{3}}}, entry.FutureMilestones)
+	require.Equal(t, []whitelist.Entry{{Number: 40, Hash: common.Hash{4}}}, entry.Status.FutureMilestones)
+
+	// Failed changes aren't audited
+	_, err = api.UnlockMilestones([]string{"unknown"}, "typo")
+	require.ErrorIs(t, err, whitelist.ErrUnknownMilestoneID)
+
+	entry, err = api.UnlockMilestones(nil, "stale lock")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), entry.ID)
+	require.Equal(t, AuditUnlockMilestones, entry.Action)
+	require.False(t, entry.Status.Locked)
+
+	entries, err := api.FinalityAuditLog()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "stale milestone", entries[0].Reason)
+	require.Equal(t, "stale lock", entries[1].Reason)
+
+	// The entries are numbered after the stored ones on restarts
+	require.Equal(t, uint64(2), newFinalityAudit(db).nextID)
+}
@@ -0,0 +1,90 @@
+package eth
+
+import (
+	"sync"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/event"
+)
+
+// finalityFeed publishes the finality changes fetched from Iris. As Iris is
+// polled, the same milestone or checkpoint is fetched many times, every
+// change is only sent once.
+type finalityFeed struct {
+	milestoneFeed      event.Feed
+	checkpointFeed     event.Feed
+	noAckMilestoneFeed event.Feed
+	scope              event.SubscriptionScope
+
+	lock           sync.Mutex
+	lastMilestone  core.MilestoneEvent
+	lastCheckpoint core.CheckpointEvent
+	lastNoAck      string
+}
+
+// sendMilestone publishes a whitelisted milestone, unless it was the last one sent.
+func (f *finalityFeed) sendMilestone(number uint64, hash common.Hash) {
+	ev := core.MilestoneEvent{Number: number, Hash: hash}
+
+	f.lock.Lock()
+	if f.lastMilestone == ev {
+		f.lock.Unlock()
+		return
+	}
+
+	f.lastMilestone = ev
+	f.lock.Unlock()
+
+	f.milestoneFeed.Send(ev)
+}
+
+// sendCheckpoint publishes a whitelisted checkpoint, unless it was the last one sent.
+func (f *finalityFeed) sendCheckpoint(number uint64, hash common.Hash) {
+	ev := core.CheckpointEvent{Number: number, Hash: hash}
+
+	f.lock.Lock()
+	if f.lastCheckpoint == ev {
+		f.lock.Unlock()
+		return
+	}
+
+	f.lastCheckpoint = ev
+	f.lock.Unlock()
+
+	f.checkpointFeed.Send(ev)
+}
+
+// sendNoAckMilestone publishes a milestone not acknowledged by Iris, unless it
+// was the last one sent.
+func (f *finalityFeed) sendNoAckMilestone(milestoneID string) {
+	if milestoneID == "" {
+		return
+	}
+
+	f.lock.Lock()
+	if f.lastNoAck == milestoneID {
+		f.lock.Unlock()
+		return
+	}
+
+	f.lastNoAck = milestoneID
+	f.lock.Unlock()
+
+	f.noAckMilestoneFeed.Send(core.NoAckMilestoneEvent{MilestoneID: milestoneID})
+}
+
+// SubscribeMilestoneEvent registers a subscription of MilestoneEvent.
+func (s *Zenanet) SubscribeMilestoneEvent(ch chan<- core.MilestoneEvent) event.Subscription {
+	return s.finality.scope.Track(s.finality.milestoneFeed.Subscribe(ch))
+}
+
+// SubscribeCheckpointEvent registers a subscription of CheckpointEvent.
+func (s *Zenanet) SubscribeCheckpointEvent(ch chan<- core.CheckpointEvent) event.Subscription {
+	return s.finality.scope.Track(s.finality.checkpointFeed.Subscribe(ch))
+}
+
+// SubscribeNoAckMilestoneEvent registers a subscription of NoAckMilestoneEvent.
+func (s *Zenanet) SubscribeNoAckMilestoneEvent(ch chan<- core.NoAckMilestoneEvent) event.Subscription {
+	return s.finality.scope.Track(s.finality.noAckMilestoneFeed.Subscribe(ch))
+}
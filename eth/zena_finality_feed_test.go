@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core"
+)
+
+func TestFinalityFeed(t *testing.T) {
+	t.Parallel()
+
+	s := &Zenanet{}
+
+	var (
+		milestones  = make(chan core.MilestoneEvent, 10)
+		checkpoints = make(chan core.CheckpointEvent, 10)
+		noAcks      = make(chan core.NoAckMilestoneEvent, 10)
+	)
+
+	milestoneSub := s.SubscribeMilestoneEvent(milestones)
+	checkpointSub := s.SubscribeCheckpointEvent(checkpoints)
+	noAckSub := s.SubscribeNoAckMilestoneEvent(noAcks)
+
+	// Polling the same milestone again doesn't publish it twice
+	s.finality.sendMilestone(16, common.HexToHash("0x01"))
+	s.finality.sendMilestone(16, common.HexToHash("0x01"))
+	s.finality.sendMilestone(32, common.HexToHash("0x02"))
+
+	s.finality.sendCheckpoint(256, common.HexToHash("0x03"))
+	s.finality.sendCheckpoint(256, common.HexToHash("0x03"))
+
+	s.finality.sendNoAckMilestone("")
+	s.finality.sendNoAckMilestone("milestone-1")
+	s.finality.sendNoAckMilestone("milestone-1")
+
+	require.Len(t, milestones, 2)
+	require.Equal(t, core.MilestoneEvent{Number: 16, Hash: common.HexToHash("0x01")}, <-milestones)
+	require.Equal(t, core.MilestoneEvent{Number: 32, Hash: common.HexToHash("0x02")}, <-milestones)
+
+	require.Len(t, checkpoints, 1)
+	require.Equal(t, core.CheckpointEvent{Number: 256, Hash: common.HexToHash("0x03")}, <-checkpoints)
+
+	require.Len(t, noAcks, 1)
+	require.Equal(t, core.NoAckMilestoneEvent{MilestoneID: "milestone-1"}, <-noAcks)
+
+	// Closing the scope ends the subscriptions
+	s.finality.scope.Close()
+
+	for _, sub := range []interface{ Err() <-chan error }{milestoneSub, checkpointSub, noAckSub} {
+		_, ok := <-sub.Err()
+		require.False(t, ok)
+	}
+}
@@ -0,0 +1,182 @@
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/ethdb"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+// Policies applied when a checkpoint or a milestone doesn't match the local chain
+const (
+	RewindPolicyAuto        = "auto"        // Rewind the chain on every mismatch
+	RewindPolicyHalt        = "halt"        // Stop mining and alert the operator, without rewinding
+	RewindPolicyConsecutive = "consecutive" // Rewind the chain once the mismatches repeat a number of times in a row
+)
+
+// RewindPolicies are the supported rewind policies.
+var RewindPolicies = []string{RewindPolicyAuto, RewindPolicyHalt, RewindPolicyConsecutive}
+
+// Actions taken on a finality incident
+const (
+	incidentRewound  = "rewound"  // The chain was rewound
+	incidentHalted   = "halted"   // The miner was stopped, the chain left as is
+	incidentDeferred = "deferred" // Nothing done until the mismatch repeats
+)
+
+const (
+	// defaultRewindThreshold is the number of consecutive mismatches rewinding
+	// the chain with the consecutive policy, unless configured otherwise.
+	defaultRewindThreshold = 3
+
+	// maxFinalityIncidents is the number of finality incidents kept in the
+	// database, the oldest ones being dropped first.
+	maxFinalityIncidents = 1024
+)
+
+var (
+	// finalityIncidentMeter counts the checkpoints and milestones not matching the local chain
+	finalityIncidentMeter = metrics.NewRegisteredMeter("chain/finality/incidents", nil)
+
+	// finalityHaltMeter counts the mismatches which stopped the miner
+	finalityHaltMeter = metrics.NewRegisteredMeter("chain/finality/halts", nil)
+)
+
+// FinalityIncident records a checkpoint or a milestone fetched from Iris which
+// didn't match the local chain, and what was done about it.
+type FinalityIncident struct {
+	ID           uint64      `json:"id"`
+	Time         time.Time   `json:"time"`
+	Kind         string      `json:"kind"` // Either checkpoint or milestone
+	StartBlock   uint64      `json:"startBlock"`
+	EndBlock     uint64      `json:"endBlock"`
+	ExpectedHash common.Hash `json:"expectedHash"` // Root hash of the checkpoint or end block hash of the milestone
+	LocalHash    common.Hash `json:"localHash"`
+	Head         uint64      `json:"head"`     // Local head when the mismatch was detected
+	RewindTo     uint64      `json:"rewindTo"` // Block the chain is rewound to, if the action is a rewind
+	Peers        []string    `json:"peers"`    // Peers connected when the mismatch was detected
+	Policy       string      `json:"policy"`
+	Consecutive  uint64      `json:"consecutive"` // Number of mismatches in a row of the same kind, this one included
+	Action       string      `json:"action"`
+}
+
+// rewindGuard decides what to do with the checkpoints and milestones which don't
+// match the local chain, keeping a record of each of them in the database.
+type rewindGuard struct {
+	db        ethdb.Database
+	policy    string
+	threshold uint64
+
+	mu          sync.Mutex
+	consecutive map[string]uint64 // Mismatches in a row by kind
+	nextID      uint64
+}
+
+// newRewindGuard creates a rewind guard applying the given policy, the incidents
+// being numbered after the ones already stored.
+func newRewindGuard(db ethdb.Database, policy string, threshold uint64) (*rewindGuard, error) {
+	switch policy {
+	case "":
+		policy = RewindPolicyAuto
+	case RewindPolicyAuto, RewindPolicyHalt, RewindPolicyConsecutive:
+	default:
+		return nil, fmt.Errorf("unknown rewind policy %q, expected one of %v", policy, RewindPolicies)
+	}
+
+	if threshold == 0 {
+		threshold = defaultRewindThreshold
+	}
+
+	guard := &rewindGuard{
+		db:          db,
+		policy:      policy,
+		threshold:   threshold,
+		consecutive: make(map[string]uint64),
+	}
+
+	if ids, _ := rawdb.ReadFinalityIncidents(db); len(ids) > 0 {
+		guard.nextID = ids[len(ids)-1] + 1
+	}
+
+	return guard, nil
+}
+
+// handle records an incident and returns the action to take about it. The
+// incident is stored before anything is done, so that it's known even if the
+// action fails. A nil guard always rewinds.
+func (g *rewindGuard) handle(incident *FinalityIncident) string {
+	if g == nil {
+		return incidentRewound
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.consecutive[incident.Kind]++
+
+	incident.ID = g.nextID
+	incident.Policy = g.policy
+	incident.Consecutive = g.consecutive[incident.Kind]
+
+	switch {
+	case g.policy == RewindPolicyHalt:
+		incident.Action = incidentHalted
+	case g.policy == RewindPolicyConsecutive && incident.Consecutive < g.threshold:
+		incident.Action = incidentDeferred
+	default:
+		incident.Action = incidentRewound
+		g.consecutive[incident.Kind] = 0
+	}
+
+	g.nextID++
+
+	finalityIncidentMeter.Mark(1)
+
+	if data, err := json.Marshal(incident); err != nil {
+		log.Error("Failed to encode the finality incident", "id", incident.ID, "err", err)
+	} else if err := rawdb.WriteFinalityIncident(g.db, incident.ID, data); err == nil && incident.ID >= maxFinalityIncidents {
+		_ = rawdb.DeleteFinalityIncident(g.db, incident.ID-maxFinalityIncidents)
+	}
+
+	return incident.Action
+}
+
+// matched resets the count of the consecutive mismatches of a kind once a
+// checkpoint or a milestone matches the local chain.
+func (g *rewindGuard) matched(kind string) {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.consecutive[kind] = 0
+}
+
+// incidents returns the stored incidents, oldest first.
+func (g *rewindGuard) incidents() ([]*FinalityIncident, error) {
+	if g == nil {
+		return []*FinalityIncident{}, nil
+	}
+
+	ids, data := rawdb.ReadFinalityIncidents(g.db)
+
+	incidents := make([]*FinalityIncident, 0, len(data))
+	for i, blob := range data {
+		incident := new(FinalityIncident)
+		if err := json.Unmarshal(blob, incident); err != nil {
+			return nil, fmt.Errorf("finality incident %d: %w", ids[i], err)
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
@@ -0,0 +1,97 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+)
+
+func TestRewindGuardPolicies(t *testing.T) {
+	t.Parallel()
+
+	incident := func(kind string) *FinalityIncident {
+		return &FinalityIncident{Kind: kind, StartBlock: 1, EndBlock: 16, ExpectedHash: common.Hash{0x01}, LocalHash: common.Hash{0x02}}
+	}
+
+	auto, err := newRewindGuard(rawdb.NewMemoryDatabase(), "", 0)
+	require.NoError(t, err)
+	require.Equal(t, incidentRewound, auto.handle(incident("milestone")))
+	require.Equal(t, incidentRewound, auto.handle(incident("milestone")))
+
+	halt, err := newRewindGuard(rawdb.NewMemoryDatabase(), RewindPolicyHalt, 0)
+	require.NoError(t, err)
+	require.Equal(t, incidentHalted, halt.handle(incident("checkpoint")))
+
+	consecutive, err := newRewindGuard(rawdb.NewMemoryDatabase(), RewindPolicyConsecutive, 2)
+	require.NoError(t, err)
+	require.Equal(t, incidentDeferred, consecutive.handle(incident("milestone")))
+
+	// A match resets the count of its kind only
+	consecutive.matched("milestone")
+	require.Equal(t, incidentDeferred, consecutive.handle(incident("milestone")))
+	require.Equal(t, incidentDeferred, consecutive.handle(incident("checkpoint")))
+	require.Equal(t, incidentRewound, consecutive.handle(incident("milestone")))
+
+	// The count starts over after a rewind
+	require.Equal(t, incidentDeferred, consecutive.handle(incident("milestone")))
+
+	_, err = newRewindGuard(rawdb.NewMemoryDatabase(), "unknown", 0)
+	require.Error(t, err)
+
+	// Without a guard the chain is always rewound
+	var disabled *rewindGuard
+
+	require.Equal(t, incidentRewound, disabled.handle(incident("milestone")))
+}
+
+func TestRewindGuardIncidents(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	guard, err := newRewindGuard(db, RewindPolicyConsecutive, 3)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 3; i++ {
+		guard.handle(&FinalityIncident{Kind: "checkpoint", StartBlock: i, EndBlock: i + 1, Peers: []string{"peer"}})
+	}
+
+	incidents, err := guard.incidents()
+	require.NoError(t, err)
+	require.Len(t, incidents, 3)
+
+	for i, incident := range incidents {
+		require.Equal(t, uint64(i), incident.ID)
+		require.Equal(t, uint64(i), incident.StartBlock)
+		require.Equal(t, uint64(i+1), incident.Consecutive)
+		require.Equal(t, RewindPolicyConsecutive, incident.Policy)
+		require.Equal(t, []string{"peer"}, incident.Peers)
+	}
+
+	require.Equal(t, incidentRewound, incidents[2].Action)
+
+	// The incidents are numbered after the stored ones on restarts
+	guard, err = newRewindGuard(db, RewindPolicyAuto, 0)
+	require.NoError(t, err)
+
+	guard.handle(&FinalityIncident{Kind: "milestone"})
+
+	incidents, err = guard.incidents()
+	require.NoError(t, err)
+	require.Len(t, incidents, 4)
+	require.Equal(t, uint64(3), incidents[3].ID)
+	require.Equal(t, "milestone", incidents[3].Kind)
+
+	// The oldest incidents are dropped beyond the limit
+	for i := 0; i < maxFinalityIncidents; i++ {
+		guard.handle(&FinalityIncident{Kind: "milestone"})
+	}
+
+	incidents, err = guard.incidents()
+	require.NoError(t, err)
+	require.Len(t, incidents, maxFinalityIncidents)
+	require.Equal(t, uint64(4), incidents[0].ID)
+}
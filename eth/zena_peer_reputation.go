@@ -0,0 +1,274 @@
+package eth
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/eth/downloader/whitelist"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+const (
+	// maxPeerScore is the score of the peers which never served a chain conflicting
+	// with the whitelisted checkpoints and milestones, or have recovered since.
+	maxPeerScore = 100
+
+	// deprioritisedPeerScore is the score below which a peer is only synced
+	// from if no better peer is available.
+	deprioritisedPeerScore = 60
+
+	// droppedPeerScore is the score below which a peer is disconnected.
+	droppedPeerScore = 30
+
+	// peerScoreRecovery is the time it takes a score to recover one point.
+	peerScoreRecovery = time.Minute
+
+	// peerBanDuration is the time a peer is refused once its score drops to zero.
+	peerBanDuration = time.Hour
+
+	// maxPeerScores is the number of peers whose scores are tracked, the fully
+	// recovered ones being forgotten first.
+	maxPeerScores = 1024
+)
+
+// peerPenalties are the points a peer loses for each kind of validation failure.
+// A peer missing the whitelisted block (whitelist.ErrNoRemote) isn't penalised:
+// honest peers lagging behind the milestones fail the same way.
+var peerPenalties = []struct {
+	err     error
+	penalty int64
+}{
+	{whitelist.ErrMismatch, 20},        // The chain conflicts with a checkpoint or a milestone
+	{whitelist.ErrLongFutureChain, 10}, // The chain goes too far beyond the last milestone
+}
+
+var (
+	// peerPenaltyMeter counts the validation failures lowering a peer score
+	peerPenaltyMeter = metrics.NewRegisteredMeter("eth/reputation/penalties", nil)
+
+	// peerDropMeter counts the peers disconnected for their low score
+	peerDropMeter = metrics.NewRegisteredMeter("eth/reputation/drops", nil)
+
+	// peerBanMeter counts the peers banned for their low score
+	peerBanMeter = metrics.NewRegisteredMeter("eth/reputation/bans", nil)
+)
+
+// PeerScore is the reputation of a peer, built from the validation of the chains
+// it served against the whitelisted checkpoints and milestones.
+type PeerScore struct {
+	Peer          string    `json:"peer"`
+	Score         int64     `json:"score"`
+	Deprioritised bool      `json:"deprioritised"` // Whether other peers are synced from first
+	Failures      uint64    `json:"failures"`      // Number of validation failures reported
+	LastFailure   string    `json:"lastFailure"`
+	LastReport    time.Time `json:"lastReport"`
+	BannedUntil   time.Time `json:"bannedUntil"` // Zero if the peer was never banned
+}
+
+// peerScore is the tracked state of a peer reputation.
+type peerScore struct {
+	score       int64     // Score as of the last update
+	updated     time.Time // Time of the last update
+	failures    uint64
+	lastFailure string
+	bannedUntil time.Time
+}
+
+// current returns the score, recovered since the last update.
+func (s *peerScore) current(now time.Time) int64 {
+	score := s.score + int64(now.Sub(s.updated)/peerScoreRecovery)
+	if score > maxPeerScore {
+		score = maxPeerScore
+	}
+
+	return score
+}
+
+// peerReputation scores the peers from the chain validation failures reported by
+// the downloader and the block fetcher, deprioritising, disconnecting and then
+// banning the peers which keep serving conflicting chains.
+type peerReputation struct {
+	peers map[string]*peerScore
+	lock  sync.Mutex
+
+	drop func(id string)                         // Disconnects a peer
+	ban  func(id string, duration time.Duration) // Refuses a peer for a while, may be nil
+}
+
+// newPeerReputation creates a reputation tracker with the given disconnection and
+// ban methods.
+func newPeerReputation(drop func(id string), ban func(id string, duration time.Duration)) *peerReputation {
+	return &peerReputation{
+		peers: make(map[string]*peerScore),
+		drop:  drop,
+		ban:   ban,
+	}
+}
+
+// report lowers the score of a peer according to the validation failure, and
+// disconnects or bans it if the score drops too low. Errors which aren't chain
+// validation failures are ignored.
+func (r *peerReputation) report(id string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	var penalty int64
+
+	for _, p := range peerPenalties {
+		if errors.Is(err, p.err) {
+			penalty = p.penalty
+			break
+		}
+	}
+
+	if penalty == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	r.lock.Lock()
+
+	s, ok := r.peers[id]
+	if !ok {
+		r.evict(now)
+
+		s = &peerScore{score: maxPeerScore, updated: now}
+		r.peers[id] = s
+	}
+
+	s.score = s.current(now) - penalty
+	s.updated = now
+	s.failures++
+	s.lastFailure = err.Error()
+
+	score := s.score
+	banned := score <= 0 && r.ban != nil
+
+	if banned {
+		s.bannedUntil = now.Add(peerBanDuration)
+	}
+
+	r.lock.Unlock()
+
+	peerPenaltyMeter.Mark(1)
+
+	switch {
+	case banned:
+		log.Warn("Banning peer serving conflicting chains", "peer", id, "score", score, "duration", peerBanDuration, "err", err)
+		peerBanMeter.Mark(1)
+		r.ban(id, peerBanDuration)
+	case score < droppedPeerScore:
+		log.Warn("Dropping peer serving conflicting chains", "peer", id, "score", score, "err", err)
+		peerDropMeter.Mark(1)
+		r.drop(id)
+	default:
+		log.Debug("Lowered peer score", "peer", id, "score", score, "err", err)
+	}
+}
+
+// evict forgets the fully recovered peers once too many are tracked, and if
+// none is, the one reported the longest ago. The lock must be held.
+func (r *peerReputation) evict(now time.Time) {
+	if len(r.peers) < maxPeerScores {
+		return
+	}
+
+	var (
+		oldest   string
+		oldestAt time.Time
+	)
+
+	for id, s := range r.peers {
+		if s.current(now) == maxPeerScore && now.After(s.bannedUntil) {
+			delete(r.peers, id)
+			continue
+		}
+
+		if oldest == "" || s.updated.Before(oldestAt) {
+			oldest, oldestAt = id, s.updated
+		}
+	}
+
+	if len(r.peers) >= maxPeerScores {
+		delete(r.peers, oldest)
+	}
+}
+
+// deprioritised reports whether a peer should only be synced from if no better
+// peer is available.
+func (r *peerReputation) deprioritised(id string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s, ok := r.peers[id]
+
+	return ok && s.current(time.Now()) < deprioritisedPeerScore
+}
+
+// score returns the reputation of a peer, the maximum one if it was never reported.
+func (r *peerReputation) score(id string) *PeerScore {
+	if r == nil {
+		return &PeerScore{Peer: id, Score: maxPeerScore}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.scoreLocked(id, time.Now())
+}
+
+// scores returns the reputation of the reported peers, the lowest scores first.
+func (r *peerReputation) scores() []*PeerScore {
+	if r == nil {
+		return []*PeerScore{}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+
+	scores := make([]*PeerScore, 0, len(r.peers))
+	for id := range r.peers {
+		scores = append(scores, r.scoreLocked(id, now))
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+
+		return scores[i].Peer < scores[j].Peer
+	})
+
+	return scores
+}
+
+// scoreLocked returns the reputation of a peer. The lock must be held.
+func (r *peerReputation) scoreLocked(id string, now time.Time) *PeerScore {
+	s, ok := r.peers[id]
+	if !ok {
+		return &PeerScore{Peer: id, Score: maxPeerScore}
+	}
+
+	score := s.current(now)
+
+	return &PeerScore{
+		Peer:          id,
+		Score:         score,
+		Deprioritised: score < deprioritisedPeerScore,
+		Failures:      s.failures,
+		LastFailure:   s.lastFailure,
+		LastReport:    s.updated,
+		BannedUntil:   s.bannedUntil,
+	}
+}
@@ -0,0 +1,105 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zenanetwork/go-zenanet/eth/downloader/whitelist"
+)
+
+func TestPeerReputation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dropped []string
+		banned  []string
+	)
+
+	reputation := newPeerReputation(
+		func(id string) { dropped = append(dropped, id) },
+		func(id string, duration time.Duration) {
+			require.Equal(t, peerBanDuration, duration)
+			banned = append(banned, id)
+		},
+	)
+
+	// Errors other than validation failures are ignored, as are the peers lagging
+	// behind the whitelisted blocks
+	reputation.report("peer", errors.New("timeout"))
+	reputation.report("peer", nil)
+	reputation.report("peer", whitelist.ErrNoRemote)
+	require.Empty(t, reputation.scores())
+	require.Equal(t, int64(maxPeerScore), reputation.score("peer").Score)
+
+	// The wrapped validation failures lower the score
+	reputation.report("peer", fmt.Errorf("invalid chain: %w", whitelist.ErrMismatch))
+	reputation.report("peer", whitelist.ErrLongFutureChain)
+
+	score := reputation.score("peer")
+	require.Equal(t, int64(maxPeerScore-30), score.Score)
+	require.Equal(t, uint64(2), score.Failures)
+	require.Equal(t, whitelist.ErrLongFutureChain.Error(), score.LastFailure)
+	require.False(t, score.Deprioritised)
+	require.False(t, reputation.deprioritised("peer"))
+
+	// Low scores are deprioritised, then dropped
+	reputation.report("peer", whitelist.ErrMismatch)
+	require.True(t, reputation.deprioritised("peer"))
+	require.Empty(t, dropped)
+
+	reputation.report("peer", whitelist.ErrMismatch)
+	reputation.report("peer", whitelist.ErrMismatch)
+	require.Equal(t, []string{"peer"}, dropped)
+	require.Empty(t, banned)
+
+	// Then banned once the score drops to zero
+	reputation.report("peer", whitelist.ErrMismatch)
+	require.Equal(t, []string{"peer"}, banned)
+	require.True(t, reputation.score("peer").BannedUntil.After(time.Now()))
+
+	// The lowest scores are listed first
+	reputation.report("other", whitelist.ErrLongFutureChain)
+
+	scores := reputation.scores()
+	require.Len(t, scores, 2)
+	require.Equal(t, "peer", scores[0].Peer)
+	require.Equal(t, "other", scores[1].Peer)
+	require.Equal(t, int64(maxPeerScore-10), scores[1].Score)
+}
+
+func TestPeerScoreRecovery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	s := &peerScore{score: 10, updated: now.Add(-30 * peerScoreRecovery)}
+	require.Equal(t, int64(40), s.current(now))
+
+	s.updated = now.Add(-time.Duration(maxPeerScore) * peerScoreRecovery)
+	require.Equal(t, int64(maxPeerScore), s.current(now))
+}
+
+func TestPeerReputationWithoutBan(t *testing.T) {
+	t.Parallel()
+
+	var dropped int
+
+	reputation := newPeerReputation(func(string) { dropped++ }, nil)
+	for i := 0; i < 6; i++ {
+		reputation.report("peer", whitelist.ErrMismatch)
+	}
+
+	// Without a ban method, the peer keeps being dropped
+	require.Equal(t, 3, dropped)
+
+	// A nil reputation never deprioritises anyone
+	var disabled *peerReputation
+
+	disabled.report("peer", whitelist.ErrMismatch)
+	require.False(t, disabled.deprioritised("peer"))
+	require.Empty(t, disabled.scores())
+}
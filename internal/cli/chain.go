@@ -16,6 +16,7 @@ func (c *ChainCommand) MarkDown() string {
 	items := []string{
 		"# Chain",
 		"The ```chain``` command groups actions to interact with the blockchain in the client:",
+		"- [```chain params```](./chain_params.md): Print the zena parameters of a chain.",
 		"- [```chain sethead```](./chain_sethead.md): Set the current chain to a certain block.",
 		"- [```chain watch```](./chain_watch.md): Watch the chainHead, reorg and fork events in real-time.",
 	}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/server/chains"
+	"github.com/zenanetwork/go-zenanet/params"
+
+	"github.com/mitchellh/cli"
+)
+
+// ChainParamsCommand is the command to print the zena parameters of a chain
+type ChainParamsCommand struct {
+	UI cli.Ui
+
+	chain    string
+	block    uint64
+	schedule bool
+	json     bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainParamsCommand) MarkDown() string {
+	items := []string{
+		"# Chain params",
+		"The ```chain params``` command validates the zena config of a chain and prints the zena parameters in effect at a given block, " +
+			"or the parameters in effect from every block at which a parameter changes or a zena fork activates. It doesn't require a running client.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainParamsCommand) Help() string {
+	return `Usage: zena chain params [--chain <name or genesis file>] [--block <number> | --schedule]
+
+  Print the zena parameters in effect at a block.
+
+  Print the parameter schedule of the amoy chain:
+
+    $ zena chain params --chain amoy --schedule` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainParamsCommand) Synopsis() string {
+	return "Print the zena parameters of a chain"
+}
+
+func (c *ChainParamsCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain params")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "chain",
+		Usage:   "Name of the chain or path of a genesis file",
+		Value:   &c.chain,
+		Default: "mainnet",
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "block",
+		Usage: "Block to print the parameters of",
+		Value: &c.block,
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "schedule",
+		Usage: "Print the parameters from every block at which they change",
+		Value: &c.schedule,
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "json",
+		Usage: "Print the output in JSON format",
+		Value: &c.json,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ChainParamsCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	chain, err := chains.GetChain(c.chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if chain.Genesis == nil || chain.Genesis.Config == nil || chain.Genesis.Config.Zena == nil {
+		c.UI.Error("the chain has no zena config")
+		return 1
+	}
+
+	config := chain.Genesis.Config.Zena
+	if err := config.Validate(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var (
+		out       interface{}
+		formatted string
+	)
+
+	if c.schedule {
+		schedule := config.ParamsSchedule()
+		out, formatted = schedule, formatParamsSchedule(schedule)
+	} else {
+		p := config.ParamsAt(c.block)
+		out, formatted = p, formatParams(p)
+	}
+
+	if c.json {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output(string(data))
+
+		return 0
+	}
+
+	c.UI.Output(formatted)
+
+	return 0
+}
+
+func formatParams(p *params.ZenaParams) string {
+	return formatKV([]string{
+		fmt.Sprintf("Block|%d", p.Number),
+		fmt.Sprintf("Period|%d", p.Period),
+		fmt.Sprintf("Producer delay|%d", p.ProducerDelay),
+		fmt.Sprintf("Sprint|%d", p.Sprint),
+		fmt.Sprintf("Sprint start|%d", p.SprintStart),
+		fmt.Sprintf("Backup multiplier|%d", p.BackupMultiplier),
+		fmt.Sprintf("State sync confirmation delay|%d", p.StateSyncConfirmationDelay),
+		fmt.Sprintf("Burnt contract|%s", p.BurntContract),
+		fmt.Sprintf("Forks|%s", formatForks(p)),
+	})
+}
+
+func formatParamsSchedule(schedule []*params.ZenaParams) string {
+	rows := make([]string, len(schedule)+1)
+	rows[0] = "Block|Period|Producer delay|Sprint|Backup multiplier|State sync delay|Burnt contract|Forks"
+
+	for i, p := range schedule {
+		rows[i+1] = fmt.Sprintf("%d|%d|%d|%d|%d|%d|%s|%s",
+			p.Number,
+			p.Period,
+			p.ProducerDelay,
+			p.Sprint,
+			p.BackupMultiplier,
+			p.StateSyncConfirmationDelay,
+			p.BurntContract,
+			formatForks(p))
+	}
+
+	return formatList(rows)
+}
+
+// formatForks lists the zena forks active in p.
+func formatForks(p *params.ZenaParams) string {
+	var forks []string
+
+	for _, fork := range []struct {
+		name   string
+		active bool
+	}{
+		{"jaipur", p.Jaipur},
+		{"delhi", p.Delhi},
+		{"indore", p.Indore},
+		{"ahmedabad", p.Ahmedabad},
+	} {
+		if fork.active {
+			forks = append(forks, fork.name)
+		}
+	}
+
+	if len(forks) == 0 {
+		return "-"
+	}
+
+	return strings.Join(forks, ",")
+}
@@ -103,6 +103,11 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"debug parallel-replay": func() (MarkDownCommand, error) {
+			return &DebugParallelReplayCommand{
+				UI: ui,
+			}, nil
+		},
 		"chain": func() (MarkDownCommand, error) {
 			return &ChainCommand{
 				UI: ui,
@@ -113,11 +118,41 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"chain params": func() (MarkDownCommand, error) {
+			return &ChainParamsCommand{
+				UI: ui,
+			}, nil
+		},
 		"chain sethead": func() (MarkDownCommand, error) {
 			return &ChainSetHeadCommand{
 				Meta2: meta2,
 			}, nil
 		},
+		"finality": func() (MarkDownCommand, error) {
+			return &FinalityCommand{
+				UI: ui,
+			}, nil
+		},
+		"finality status": func() (MarkDownCommand, error) {
+			return &FinalityStatusCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"finality unlock": func() (MarkDownCommand, error) {
+			return &FinalityUnlockCommand{
+				UI: ui,
+			}, nil
+		},
+		"finality purge": func() (MarkDownCommand, error) {
+			return &FinalityPurgeCommand{
+				UI: ui,
+			}, nil
+		},
+		"finality audit": func() (MarkDownCommand, error) {
+			return &FinalityAuditCommand{
+				UI: ui,
+			}, nil
+		},
 		"account": func() (MarkDownCommand, error) {
 			return &Account{
 				UI: ui,
@@ -168,6 +203,11 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"schedule": func() (MarkDownCommand, error) {
+			return &ScheduleCommand{
+				UI: ui,
+			}, nil
+		},
 		"fingerprint": func() (MarkDownCommand, error) {
 			return &FingerprintCommand{
 				UI: ui,
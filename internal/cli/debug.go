@@ -50,6 +50,7 @@ func (c *DebugCommand) MarkDown() string {
 		"The ```zena debug``` command takes a debug dump of the running client.",
 		"- [```zena debug pprof```](./debug_pprof.md): Dumps zena pprof traces.",
 		"- [```zena debug block <number>```](./debug_block.md): Dumps zena block traces.",
+		"- [```zena debug parallel-replay <block-range>```](./debug_parallel-replay.md): Compares the serial and the parallel execution of blocks.",
 	}
 	items = append(items, examples...)
 
@@ -68,7 +69,11 @@ func (c *DebugCommand) Help() string {
 
 	Get the block traces:
 
-		$ zena debug block <number>`
+		$ zena debug block <number>
+
+	Compare the serial and the parallel execution of blocks:
+
+		$ zena debug parallel-replay <block-range>`
 }
 
 // Synopsis implements the cli.Command interface
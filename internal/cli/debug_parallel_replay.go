@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/server"
+	"github.com/zenanetwork/go-zenanet/rpc"
+)
+
+// DebugParallelReplayCommand is the command to compare the serial and the
+// parallel execution of a range of blocks
+type DebugParallelReplayCommand struct {
+	UI cli.Ui
+
+	endpoint string
+	json     bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugParallelReplayCommand) MarkDown() string {
+	items := []string{
+		"# Debug parallel replay",
+		"The ```zena debug parallel-replay <block-range>``` command executes the blocks of the range again on the running client, " +
+			"with both the serial and the parallel state processor, and reports the transactions whose receipts, logs or written values differ, " +
+			"along with the MVHashMap versions they read. The range is either a single block number or ```<from>-<to>```, both inclusive. " +
+			"The client must run with ```parallelevm.enable``` and expose the ```debug``` JSON-RPC namespace on the endpoint.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugParallelReplayCommand) Help() string {
+	return `Usage: zena debug parallel-replay <block-range>
+
+  Compare the serial and the parallel execution of a range of blocks.
+
+  Replay the blocks 100 to 110:
+
+    $ zena debug parallel-replay 100-110` + c.Flags().Help()
+}
+
+func (c *DebugParallelReplayCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("parallel-replay")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "endpoint",
+		Usage:   "IPC path or URL of the JSON-RPC endpoint of the client",
+		Value:   &c.endpoint,
+		Default: filepath.Join(server.DefaultDataDir(), "zena.ipc"),
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "json",
+		Usage: "Print the output in JSON format",
+		Value: &c.json,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugParallelReplayCommand) Synopsis() string {
+	return "Compare the serial and the parallel execution of blocks"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugParallelReplayCommand) Run(args []string) int {
+	flags := c.Flags()
+
+	// the block range can be given before the flags
+	var blockRange string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		blockRange, args = args[0], args[1:]
+	}
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if blockRange == "" && len(flags.Args()) > 0 {
+		blockRange = flags.Args()[0]
+	}
+
+	if blockRange == "" {
+		c.UI.Error("No block range given")
+		return 1
+	}
+
+	from, to, err := parseBlockRange(blockRange)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := rpc.Dial(c.endpoint)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to connect to %s: %v", c.endpoint, err))
+		return 1
+	}
+	defer client.Close()
+
+	var replays []*core.ParallelReplay
+	if err := client.CallContext(context.Background(), &replays, "debug_replayParallel", rpc.BlockNumber(from), rpc.BlockNumber(to)); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.json {
+		out, err := json.MarshalIndent(replays, "", "  ")
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output(string(out))
+
+		return 0
+	}
+
+	c.UI.Output(formatParallelReplays(replays))
+
+	return 0
+}
+
+// parseBlockRange parses a block number or a range of block numbers, <from>-<to>.
+func parseBlockRange(s string) (uint64, uint64, error) {
+	first, last, isRange := strings.Cut(s, "-")
+
+	from, err := strconv.ParseUint(first, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q", s)
+	}
+
+	if !isRange {
+		return from, from, nil
+	}
+
+	to, err := strconv.ParseUint(last, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q", s)
+	}
+
+	if to < from {
+		return 0, 0, fmt.Errorf("invalid block range %q: %d is before %d", s, to, from)
+	}
+
+	return from, to, nil
+}
+
+func formatParallelReplays(replays []*core.ParallelReplay) string {
+	var (
+		out      []string
+		diverged int
+	)
+
+	for _, replay := range replays {
+		if !replay.Diverged() {
+			continue
+		}
+
+		diverged++
+
+		out = append(out, formatKV([]string{
+			fmt.Sprintf("Block|%d", replay.Number),
+			fmt.Sprintf("Hash|%s", replay.Hash.Hex()),
+			fmt.Sprintf("Transactions|%d", replay.Transactions),
+			fmt.Sprintf("Serial root|%s", replay.SerialRoot.Hex()),
+			fmt.Sprintf("Parallel root|%s", replay.ParallelRoot.Hex()),
+		}))
+
+		if replay.SerialError != "" {
+			out = append(out, "Serial error: "+replay.SerialError)
+		}
+
+		if replay.ParallelError != "" {
+			out = append(out, "Parallel error: "+replay.ParallelError)
+		}
+
+		for _, d := range replay.Divergences {
+			lines := []string{fmt.Sprintf("Tx %d (%s)", d.Index, d.Hash.Hex())}
+
+			if len(d.Receipt) > 0 {
+				lines = append(lines, "  Receipt fields: "+strings.Join(d.Receipt, ", "))
+			}
+
+			for _, diff := range d.State {
+				lines = append(lines, fmt.Sprintf("  %s: serial %s, parallel %s", diff.Key, diff.Serial, diff.Parallel))
+			}
+
+			lines = append(lines, "  Reads:")
+
+			for _, read := range d.Reads {
+				if read.Source == "mvhashmap" {
+					lines = append(lines, fmt.Sprintf("    %s from tx %d incarnation %d", read.Key, read.TxIndex, read.Incarnation))
+				} else {
+					lines = append(lines, fmt.Sprintf("    %s from storage", read.Key))
+				}
+			}
+
+			out = append(out, strings.Join(lines, "\n"))
+		}
+	}
+
+	out = append(out, fmt.Sprintf("Replayed %d blocks, %d diverged", len(replays), diverged))
+
+	return strings.Join(out, "\n\n")
+}
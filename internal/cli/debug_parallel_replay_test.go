@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockRange(t *testing.T) {
+	t.Parallel()
+
+	from, to, err := parseBlockRange("42")
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), from)
+	require.Equal(t, uint64(42), to)
+
+	from, to, err = parseBlockRange("100-110")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), from)
+	require.Equal(t, uint64(110), to)
+
+	for _, s := range []string{"", "latest", "100-", "-110", "110-100", "1-2-3"} {
+		_, _, err = parseBlockRange(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// FinalityCommand is the command to group the finality commands
+type FinalityCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *FinalityCommand) MarkDown() string {
+	items := []string{
+		"# Finality",
+		"The ```finality``` command groups actions to inspect and repair the checkpoints and milestones the chain is whitelisted against:",
+		"- [```finality audit```](./finality_audit.md): Display the changes made by the operators to the whitelisting state.",
+		"- [```finality purge```](./finality_purge.md): Remove future milestones.",
+		"- [```finality status```](./finality_status.md): Display the whitelisted checkpoint and milestone, the locked sprint and the rejected peers.",
+		"- [```finality unlock```](./finality_unlock.md): Remove milestone ids from the locked sprint.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *FinalityCommand) Help() string {
+	return `Usage: zena finality <subcommand>
+
+  This command groups actions to inspect and repair the finality of the chain.
+
+  Display the whitelisting state:
+
+    $ zena finality status
+
+  Unlock a sprint locked by a milestone:
+
+    $ zena finality unlock --reason <reason> <milestone id>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *FinalityCommand) Synopsis() string {
+	return "Inspect and repair the finality of the chain"
+}
+
+// Run implements the cli.Command interface
+func (c *FinalityCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/eth"
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/server"
+	"github.com/zenanetwork/go-zenanet/rpc"
+)
+
+// finalityAdmin holds the options of the commands changing the whitelisting
+// state through the admin JSON-RPC namespace of the client
+type finalityAdmin struct {
+	endpoint string
+	reason   string
+	all      bool
+}
+
+func (f *finalityAdmin) flags(name string, allUsage string) *flagset.Flagset {
+	flags := flagset.NewFlagSet(name)
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "endpoint",
+		Usage:   "IPC path or URL of the JSON-RPC endpoint of the client",
+		Value:   &f.endpoint,
+		Default: filepath.Join(server.DefaultDataDir(), "zena.ipc"),
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "reason",
+		Usage: "Reason of the change, recorded in the audit log",
+		Value: &f.reason,
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "all",
+		Usage: allUsage,
+		Value: &f.all,
+	})
+
+	return flags
+}
+
+// checkArgs makes sure either some entries or --all are given.
+func (f *finalityAdmin) checkArgs(args []string, what string) error {
+	if len(args) == 0 && !f.all {
+		return fmt.Errorf("no %s given, use --all to select them all", what)
+	}
+
+	if len(args) > 0 && f.all {
+		return fmt.Errorf("both %s and --all given", what)
+	}
+
+	if f.reason == "" {
+		return errors.New("no reason given")
+	}
+
+	return nil
+}
+
+// call invokes an admin method changing the whitelisting state and returns the
+// audit entry recording the change.
+func (f *finalityAdmin) call(method string, args ...interface{}) (*eth.FinalityAuditEntry, error) {
+	client, err := rpc.Dial(f.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %v", f.endpoint, err)
+	}
+	defer client.Close()
+
+	var entry *eth.FinalityAuditEntry
+	if err := client.CallContext(context.Background(), &entry, method, args...); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func formatFinalityAuditEntry(entry *eth.FinalityAuditEntry) string {
+	futureMilestones := make([]string, 0, len(entry.FutureMilestones))
+	for _, milestone := range entry.FutureMilestones {
+		futureMilestones = append(futureMilestones, fmt.Sprintf("%d", milestone.Number))
+	}
+
+	kv := []string{
+		fmt.Sprintf("ID|%d", entry.ID),
+		fmt.Sprintf("Time|%s", entry.Time.UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Action|%s", entry.Action),
+		fmt.Sprintf("Reason|%s", entry.Reason),
+		fmt.Sprintf("Milestone IDs|%s", strings.Join(entry.MilestoneIDs, ",")),
+		fmt.Sprintf("Future milestones|%s", strings.Join(futureMilestones, ",")),
+	}
+
+	if entry.Status != nil {
+		kv = append(kv,
+			fmt.Sprintf("Locked|%v", entry.Status.Locked),
+			fmt.Sprintf("Locked milestone IDs|%s", strings.Join(entry.Status.LockedMilestoneIDs, ",")),
+			fmt.Sprintf("Future milestones left|%d", len(entry.Status.FutureMilestones)),
+		)
+	}
+
+	return formatKV(kv)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/zenanetwork/go-zenanet/eth"
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/server"
+	"github.com/zenanetwork/go-zenanet/rpc"
+)
+
+// FinalityAuditCommand is the command to display the finality audit log
+type FinalityAuditCommand struct {
+	UI cli.Ui
+
+	endpoint string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *FinalityAuditCommand) MarkDown() string {
+	items := []string{
+		"# Finality audit",
+		"The ```zena finality audit``` command displays the changes made by the operators to the milestone locks and the future milestones, oldest first.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *FinalityAuditCommand) Help() string {
+	return `Usage: zena finality audit
+
+  Display the changes made by the operators to the whitelisting state.` + c.Flags().Help()
+}
+
+func (c *FinalityAuditCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("finality audit")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "endpoint",
+		Usage:   "IPC path or URL of the JSON-RPC endpoint of the client",
+		Value:   &c.endpoint,
+		Default: filepath.Join(server.DefaultDataDir(), "zena.ipc"),
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *FinalityAuditCommand) Synopsis() string {
+	return "Display the finality audit log"
+}
+
+// Run implements the cli.Command interface
+func (c *FinalityAuditCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := rpc.Dial(c.endpoint)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to connect to %s: %v", c.endpoint, err))
+		return 1
+	}
+	defer client.Close()
+
+	var entries []*eth.FinalityAuditEntry
+	if err := client.CallContext(context.Background(), &entries, "admin_finalityAuditLog"); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if len(entries) == 0 {
+		c.UI.Output("No change recorded")
+		return 0
+	}
+
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, formatFinalityAuditEntry(entry))
+	}
+
+	c.UI.Output(strings.Join(out, "\n\n"))
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+)
+
+// FinalityPurgeCommand is the command to remove future milestones
+type FinalityPurgeCommand struct {
+	UI cli.Ui
+
+	finalityAdmin
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *FinalityPurgeCommand) MarkDown() string {
+	items := []string{
+		"# Finality purge",
+		"The ```zena finality purge [end block numbers]``` command removes the future milestones ending at the given block numbers, " +
+			"or all of them with ```--all```. The change is persisted and recorded in the finality audit log. " +
+			"It goes through the ```admin``` JSON-RPC namespace, served on the IPC endpoint of the client.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *FinalityPurgeCommand) Help() string {
+	return `Usage: zena finality purge [options] [end block numbers]
+
+  Remove future milestones.
+
+  Remove the future milestone ending at block 1000:
+
+    $ zena finality purge --reason "reorged on iris" 1000` + c.Flags().Help()
+}
+
+func (c *FinalityPurgeCommand) Flags() *flagset.Flagset {
+	return c.flags("finality purge", "Remove all the future milestones")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *FinalityPurgeCommand) Synopsis() string {
+	return "Remove future milestones"
+}
+
+// Run implements the cli.Command interface
+func (c *FinalityPurgeCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if err := c.checkArgs(args, "end block number"); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	numbers := make([]uint64, 0, len(args))
+
+	for _, arg := range args {
+		number, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Invalid block number %q", arg))
+			return 1
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	entry, err := c.call("admin_purgeFutureMilestones", numbers, c.reason)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatFinalityAuditEntry(entry))
+
+	return 0
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/server/proto"
+)
+
+// FinalityStatusCommand is the command to display the whitelisting state
+type FinalityStatusCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *FinalityStatusCommand) MarkDown() string {
+	items := []string{
+		"# Finality status",
+		"The ```finality status``` command displays the whitelisted checkpoint and milestone, the locked sprint, the future milestones and the peers rejected for not matching them.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *FinalityStatusCommand) Help() string {
+	return `Usage: zena finality status
+
+  Display the whitelisted checkpoint and milestone, the locked sprint,
+  the future milestones and the rejected peers.
+
+  ` + c.Flags().Help()
+}
+
+func (c *FinalityStatusCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("finality status")
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *FinalityStatusCommand) Synopsis() string {
+	return "Display the whitelisting state"
+}
+
+// Run implements the cli.Command interface
+func (c *FinalityStatusCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	zenaClt, err := c.ZenaConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := zenaClt.Finality(context.Background(), &proto.FinalityRequest{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatFinality(resp))
+
+	return 0
+}
+
+func formatFinality(resp *proto.FinalityResponse) string {
+	printEntry := func(h *proto.Header) string {
+		if h == nil {
+			return "None"
+		}
+
+		return formatKV([]string{
+			fmt.Sprintf("Hash|%s", h.Hash),
+			fmt.Sprintf("Number|%d", h.Number),
+		})
+	}
+
+	lockedSprint := resp.LockedSprint
+	if lockedSprint == nil {
+		lockedSprint = &proto.FinalityResponse_LockedSprint{}
+	}
+
+	futureMilestones := make([]string, len(resp.FutureMilestones)+1)
+	futureMilestones[0] = "Number|Hash"
+
+	for i, h := range resp.FutureMilestones {
+		futureMilestones[i+1] = fmt.Sprintf("%d|%s", h.Number, h.Hash)
+	}
+
+	rejectedPeers := make([]string, len(resp.RejectedPeers)+1)
+	rejectedPeers[0] = "ID|Count|Last rejected|Reason"
+
+	for i, p := range resp.RejectedPeers {
+		rejectedPeers[i+1] = fmt.Sprintf("%s|%d|%s|%s", p.Id, p.Count, time.Unix(p.LastRejected, 0).UTC().Format(time.RFC3339), p.Reason)
+	}
+
+	full := []string{
+		"Checkpoint",
+		printEntry(resp.Checkpoint),
+		"\nMilestone",
+		printEntry(resp.Milestone),
+		"\nLocked Sprint",
+		formatKV([]string{
+			fmt.Sprintf("Locked|%v", lockedSprint.Locked),
+			fmt.Sprintf("Number|%d", lockedSprint.Number),
+			fmt.Sprintf("Hash|%s", lockedSprint.Hash),
+			fmt.Sprintf("Milestone IDs|%s", strings.Join(lockedSprint.MilestoneIDs, ",")),
+		}),
+		"\nFuture Milestones",
+		formatList(futureMilestones),
+		"\nRejected Peers",
+		formatList(rejectedPeers),
+	}
+
+	return strings.Join(full, "\n")
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+)
+
+// FinalityUnlockCommand is the command to remove milestone ids from the locked sprint
+type FinalityUnlockCommand struct {
+	UI cli.Ui
+
+	finalityAdmin
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *FinalityUnlockCommand) MarkDown() string {
+	items := []string{
+		"# Finality unlock",
+		"The ```zena finality unlock [milestone ids]``` command removes the given milestone ids from the locked sprint, " +
+			"or all of them with ```--all```, unlocking the sprint once no id is left. The change is persisted and recorded in the finality audit log. " +
+			"It goes through the ```admin``` JSON-RPC namespace, served on the IPC endpoint of the client.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *FinalityUnlockCommand) Help() string {
+	return `Usage: zena finality unlock [options] [milestone ids]
+
+  Remove milestone ids from the locked sprint.
+
+  Unlock the sprint whatever the milestones locking it:
+
+    $ zena finality unlock --reason "stale lock" --all` + c.Flags().Help()
+}
+
+func (c *FinalityUnlockCommand) Flags() *flagset.Flagset {
+	return c.flags("finality unlock", "Remove all the milestone ids and unlock the sprint")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *FinalityUnlockCommand) Synopsis() string {
+	return "Remove milestone ids from the locked sprint"
+}
+
+// Run implements the cli.Command interface
+func (c *FinalityUnlockCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	ids := flags.Args()
+	if err := c.checkArgs(ids, "milestone id"); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	entry, err := c.call("admin_unlockMilestones", ids, c.reason)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatFinalityAuditEntry(entry))
+
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
 	"github.com/zenanetwork/go-zenanet/internal/cli/server/proto"
@@ -29,7 +30,8 @@ func (p *PeersStatusCommand) MarkDown() string {
 func (p *PeersStatusCommand) Help() string {
 	return `Usage: zena peers status <peer id>
 
-  Display the status of a peer by its id.
+  Display the status of a peer by its id, along with its reputation. Peers
+  disconnected or banned for their score can still be looked up.
 
   ` + p.Flags().Help()
 }
@@ -81,7 +83,7 @@ func (p *PeersStatusCommand) Run(args []string) int {
 }
 
 func formatPeer(peer *proto.Peer) string {
-	base := formatKV([]string{
+	kv := []string{
 		fmt.Sprintf("Name|%s", peer.Name),
 		fmt.Sprintf("ID|%s", peer.Id),
 		fmt.Sprintf("ENR|%s", peer.Enr),
@@ -89,7 +91,18 @@ func formatPeer(peer *proto.Peer) string {
 		fmt.Sprintf("Enode|%s", peer.Enode),
 		fmt.Sprintf("Static|%v", peer.Static),
 		fmt.Sprintf("Trusted|%v", peer.Trusted),
-	})
+		fmt.Sprintf("Score|%d", peer.Score),
+		fmt.Sprintf("Deprioritised|%v", peer.Deprioritised),
+		fmt.Sprintf("Failures|%d", peer.Failures),
+	}
+
+	if peer.LastFailure != "" {
+		kv = append(kv, fmt.Sprintf("Last failure|%s", peer.LastFailure))
+	}
+
+	if peer.BannedUntil != 0 {
+		kv = append(kv, fmt.Sprintf("Banned until|%s", time.Unix(peer.BannedUntil, 0).UTC().Format(time.RFC3339)))
+	}
 
-	return base
+	return formatKV(kv)
 }
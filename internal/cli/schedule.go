@@ -0,0 +1,369 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/iris/span"
+	"github.com/zenanetwork/go-zenanet/consensus/zena/valset"
+	"github.com/zenanetwork/go-zenanet/internal/cli/flagset"
+	"github.com/zenanetwork/go-zenanet/rpc"
+
+	"github.com/mitchellh/cli"
+)
+
+// ScheduleCommand is the command to project the producer schedule of a validator set
+type ScheduleCommand struct {
+	UI cli.Ui
+
+	validators   string
+	irisURL      string
+	endpoint     string
+	spanID       uint64
+	sprints      uint64
+	sprintLength uint64
+	startBlock   uint64
+	backups      int
+	fairness     bool
+	json         bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ScheduleCommand) MarkDown() string {
+	items := []string{
+		"# Schedule",
+		"The ```schedule``` command projects the proposer and the backup producers of the upcoming sprints of a validator set, " +
+			"read from a file or from the producers of a span fetched from Iris. Given the endpoint of a client, the projection is compared with " +
+			"the signers of the sprints already produced. The fairness report compares the sprints proposed by every validator with its stake weight.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ScheduleCommand) Help() string {
+	return `Usage: zena schedule [--validators <file> | --iris <url> --span <id>]
+
+  Project the producer schedule of a validator set.
+
+  The validator file holds either a list of validators, a validator set or a span, in JSON.
+  The schedule of a span is the one of its selected producers.
+
+  Project the schedule of a span from Iris:
+
+    $ zena schedule --iris http://localhost:1317 --span 42 --fairness
+
+  Compare it with the sprints signed so far, and report the fairness of the realized schedule:
+
+    $ zena schedule --iris http://localhost:1317 --span 42 --endpoint ~/.zena/data/zena.ipc --fairness` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ScheduleCommand) Synopsis() string {
+	return "Project the producer schedule of a validator set"
+}
+
+func (c *ScheduleCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("schedule")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "validators",
+		Usage: "Path of a JSON file holding the validators, a validator set or a span",
+		Value: &c.validators,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "iris",
+		Usage: "URL of the Iris server to fetch the span from",
+		Value: &c.irisURL,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Usage: "IPC path or URL of the JSON-RPC endpoint of a client, to compare the schedule with the signers of the sprints already produced",
+		Value: &c.endpoint,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "span",
+		Usage: "ID of the span to fetch from Iris",
+		Value: &c.spanID,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "sprints",
+		Usage:   "Number of sprints to project",
+		Value:   &c.sprints,
+		Default: 16,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "sprint-length",
+		Usage:   "Number of blocks in a sprint",
+		Value:   &c.sprintLength,
+		Default: 16,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "start-block",
+		Usage: "First block of the first projected sprint, defaults to the start of the span",
+		Value: &c.startBlock,
+	})
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "backups",
+		Usage:   "Number of backup producers listed per sprint, 0 lists all of them",
+		Value:   &c.backups,
+		Default: 3,
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "fairness",
+		Usage: "Print the fairness report of the schedule, the realized one if an endpoint is set",
+		Value: &c.fairness,
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "json",
+		Usage: "Print the output in JSON format",
+		Value: &c.json,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ScheduleCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	vals, err := c.validatorSet()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	schedule, err := valset.ProjectSchedule(vals, valset.ScheduleConfig{
+		Sprints: c.sprints,
+		Backups: c.backups,
+	})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var realized []*valset.SprintSchedule
+
+	if c.endpoint != "" {
+		signers, err := c.signers(schedule)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		realized = valset.RealizedSchedule(schedule, signers)
+	}
+
+	var report *valset.FairnessReport
+
+	switch {
+	case c.fairness && realized != nil:
+		report = valset.Fairness(realized)
+	case c.fairness:
+		report = valset.Fairness(schedule)
+	}
+
+	if c.json {
+		out, err := json.MarshalIndent(struct {
+			Schedule []*valset.SprintSchedule `json:"schedule"`
+			Realized []*valset.SprintSchedule `json:"realized,omitempty"`
+			Fairness *valset.FairnessReport   `json:"fairness,omitempty"`
+		}{schedule, realized, report}, "", "  ")
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output(string(out))
+
+		return 0
+	}
+
+	c.UI.Output(formatSchedule(schedule, realized, c.endpoint != "", c.startBlock, c.sprintLength))
+
+	if report != nil {
+		c.UI.Output("")
+		c.UI.Output(formatFairness(report))
+	}
+
+	return 0
+}
+
+// validatorSet loads the validator set to project, either from a file or
+// from a span fetched from Iris.
+func (c *ScheduleCommand) validatorSet() (*valset.ValidatorSet, error) {
+	switch {
+	case c.validators != "" && c.irisURL != "":
+		return nil, errors.New("only one of validators and iris can be set")
+	case c.validators != "":
+		data, err := os.ReadFile(c.validators)
+		if err != nil {
+			return nil, err
+		}
+
+		return parseValidatorSet(data)
+	case c.irisURL != "":
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		client := iris.NewIrisClient(c.irisURL)
+		defer client.Close()
+
+		irisSpan, err := client.Span(ctx, c.spanID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch span %d: %w", c.spanID, err)
+		}
+
+		if c.startBlock == 0 {
+			c.startBlock = irisSpan.StartBlock
+		}
+
+		return spanProducers(irisSpan)
+	default:
+		return nil, errors.New("either validators or iris must be set")
+	}
+}
+
+// parseValidatorSet decodes a list of validators, a validator set or a span.
+// A list of validators starts from the first round of a new validator set.
+func parseValidatorSet(data []byte) (*valset.ValidatorSet, error) {
+	var validators []*valset.Validator
+	if err := json.Unmarshal(data, &validators); err == nil {
+		if len(validators) == 0 {
+			return nil, valset.ErrEmptySchedule
+		}
+
+		return valset.NewValidatorSet(validators), nil
+	}
+
+	var irisSpan span.IrisSpan
+	if err := json.Unmarshal(data, &irisSpan); err == nil && len(irisSpan.ValidatorSet.Validators) > 0 {
+		return spanProducers(&irisSpan)
+	}
+
+	vals := new(valset.ValidatorSet)
+	if err := json.Unmarshal(data, vals); err != nil {
+		return nil, fmt.Errorf("failed to decode validators: %w", err)
+	}
+
+	return vals, nil
+}
+
+// spanProducers returns the validator set of the selected producers of a span,
+// which the span commits as the producers of its sprints.
+func spanProducers(irisSpan *span.IrisSpan) (*valset.ValidatorSet, error) {
+	if len(irisSpan.SelectedProducers) == 0 {
+		return nil, fmt.Errorf("span %d has no selected producers: %w", irisSpan.ID, valset.ErrEmptySchedule)
+	}
+
+	producers := make([]*valset.Validator, len(irisSpan.SelectedProducers))
+	for i := range irisSpan.SelectedProducers {
+		producers[i] = irisSpan.SelectedProducers[i].Copy()
+	}
+
+	return valset.NewValidatorSet(producers), nil
+}
+
+// signers fetches the signer of the first block of every sprint of the
+// schedule, up to the last sprint already produced.
+func (c *ScheduleCommand) signers(schedule []*valset.SprintSchedule) ([]common.Address, error) {
+	client, err := rpc.Dial(c.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", c.endpoint, err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var head hexutil.Uint64
+	if err := client.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		return nil, fmt.Errorf("failed to fetch the head block: %w", err)
+	}
+
+	signers := make([]common.Address, 0, len(schedule))
+
+	for _, sprint := range schedule {
+		number := c.startBlock + sprint.Sprint*c.sprintLength
+		if number > uint64(head) {
+			break
+		}
+
+		var signer common.Address
+		if err := client.CallContext(ctx, &signer, "zena_getAuthor", hexutil.Uint64(number)); err != nil {
+			return nil, fmt.Errorf("failed to fetch the signer of block %d: %w", number, err)
+		}
+
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+func formatSchedule(schedule, realized []*valset.SprintSchedule, withSigners bool, startBlock, sprintLength uint64) string {
+	rows := make([]string, len(schedule)+1)
+	rows[0] = "Sprint|Blocks|Proposer|Backups"
+
+	if withSigners {
+		rows[0] += "|Signer"
+	}
+
+	for i, sprint := range schedule {
+		start := startBlock + sprint.Sprint*sprintLength
+
+		backups := make([]string, len(sprint.Backups))
+		for j, backup := range sprint.Backups {
+			backups[j] = backup.Hex()
+		}
+
+		rows[i+1] = fmt.Sprintf("%d|%d-%d|%s|%s",
+			sprint.Sprint,
+			start,
+			start+sprintLength-1,
+			sprint.Proposer.Hex(),
+			strings.Join(backups, ","))
+
+		if withSigners {
+			switch {
+			case i >= len(realized):
+				rows[i+1] += "|-"
+			case realized[i].Proposer == sprint.Proposer:
+				rows[i+1] += "|" + realized[i].Proposer.Hex()
+			default:
+				rows[i+1] += "|" + realized[i].Proposer.Hex() + " (out of turn)"
+			}
+		}
+	}
+
+	return formatList(rows)
+}
+
+func formatFairness(report *valset.FairnessReport) string {
+	rows := make([]string, len(report.Validators)+1)
+	rows[0] = "Validator|Expected share|Proposed share|Expected|Proposed|Deviation"
+
+	for i, v := range report.Validators {
+		rows[i+1] = fmt.Sprintf("%s|%.4f|%.4f|%.2f|%d|%+.2f",
+			v.Address.Hex(),
+			v.ExpectedShare,
+			v.ProposedShare,
+			v.Expected,
+			v.Proposed,
+			v.Deviation)
+	}
+
+	return formatList(rows) + fmt.Sprintf("\n\nSprints: %d, max deviation: %.2f sprints", report.Sprints, report.MaxDeviation)
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,12 +25,14 @@ import (
 	"github.com/zenanetwork/go-zenanet/cmd/utils"
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/common/fdlimit"
+	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/crypto"
 	"github.com/zenanetwork/go-zenanet/eth/downloader"
 	"github.com/zenanetwork/go-zenanet/eth/ethconfig"
 	"github.com/zenanetwork/go-zenanet/eth/gasprice"
 	"github.com/zenanetwork/go-zenanet/internal/cli/server/chains"
 	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/miner"
 	"github.com/zenanetwork/go-zenanet/node"
 	"github.com/zenanetwork/go-zenanet/p2p"
 	"github.com/zenanetwork/go-zenanet/p2p/enode"
@@ -268,6 +271,27 @@ type IrisConfig struct {
 
 	// UseIrisApp is used to fetch data from iris app when running iris as a child process
 	UseIrisApp bool `hcl:"zena.useirisapp,optional" toml:"zena.useirisapp,optional"`
+
+	// Endpoints are additional iris endpoints to fail over to
+	Endpoints []string `hcl:"endpoints,optional" toml:"endpoints,optional"`
+
+	// Quorum is the number of iris endpoints which have to agree on spans, checkpoints and milestones
+	Quorum int `hcl:"quorum,optional" toml:"quorum,optional"`
+
+	// Cache is used to keep finalized spans, checkpoints and state-sync events in the local database
+	Cache bool `hcl:"cache,optional" toml:"cache,optional"`
+
+	// RecordFile is the fixture file every iris response is recorded to
+	RecordFile string `hcl:"record-file,optional" toml:"record-file,optional"`
+
+	// ReplayFile is the fixture file iris responses are served from instead of a live iris
+	ReplayFile string `hcl:"replay-file,optional" toml:"replay-file,optional"`
+
+	// RewindPolicy is the policy applied when a checkpoint or a milestone doesn't match the local chain
+	RewindPolicy string `hcl:"rewind-policy,optional" toml:"rewind-policy,optional"`
+
+	// RewindThreshold is the number of consecutive mismatches rewinding the chain with the consecutive policy
+	RewindThreshold uint64 `hcl:"rewind-threshold,optional" toml:"rewind-threshold,optional"`
 }
 
 type TxPoolConfig struct {
@@ -329,6 +353,21 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// SpeculativeWindow is the number of transactions executed in parallel while building blocks
+	SpeculativeWindow int `hcl:"speculativewindow,optional" toml:"speculativewindow,optional"`
+
+	// Ordering is the policy picking the order of the transactions in the blocks
+	Ordering string `hcl:"ordering,optional" toml:"ordering,optional"`
+
+	// MaxTxsPerSender is the maximum number of transactions of a sender in a block with the fair ordering
+	MaxTxsPerSender int `hcl:"maxtxspersender,optional" toml:"maxtxspersender,optional"`
+
+	// PrioritySenders are the senders whose transactions are included first with the priority ordering
+	PrioritySenders []string `hcl:"prioritysenders,optional" toml:"prioritysenders,optional"`
+
+	// BuildTraces is the number of blocks built whose build traces are kept
+	BuildTraces int `hcl:"buildtraces,optional" toml:"buildtraces,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -599,6 +638,14 @@ type ParallelEVMConfig struct {
 	SpeculativeProcesses int `hcl:"procs,optional" toml:"procs,optional"`
 
 	Enforce bool `hcl:"enforce,optional" toml:"enforce,optional"`
+
+	Reports bool `hcl:"reports,optional" toml:"reports,optional"`
+
+	ReportsRetention uint64 `hcl:"reportsretention,optional" toml:"reportsretention,optional"`
+
+	TxDependencies string `hcl:"txdependencies,optional" toml:"txdependencies,optional"`
+
+	Adaptive bool `hcl:"adaptive,optional" toml:"adaptive,optional"`
 }
 
 func DefaultConfig() *Config {
@@ -643,9 +690,16 @@ func DefaultConfig() *Config {
 			},
 		},
 		Iris: &IrisConfig{
-			URL:         "http://localhost:1317",
-			Without:     false,
-			GRPCAddress: "",
+			URL:             "http://localhost:1317",
+			Without:         false,
+			GRPCAddress:     "",
+			Endpoints:       []string{},
+			Quorum:          0,
+			Cache:           false,
+			RecordFile:      "",
+			ReplayFile:      "",
+			RewindPolicy:    "auto",
+			RewindThreshold: 3,
 		},
 		SyncMode:    "full",
 		GcMode:      "full",
@@ -673,6 +727,11 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			SpeculativeWindow:   0,
+			Ordering:            miner.OrderingPriceTime,
+			MaxTxsPerSender:     0,
+			PrioritySenders:     []string{},
+			BuildTraces:         64,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -797,6 +856,10 @@ func DefaultConfig() *Config {
 			Enable:               true,
 			SpeculativeProcesses: 8,
 			Enforce:              false,
+			Reports:              false,
+			ReportsRetention:     10000,
+			TxDependencies:       string(core.TxDependencyVerify),
+			Adaptive:             false,
 		},
 	}
 }
@@ -935,6 +998,13 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.RunIris = c.Iris.RunIris
 	n.RunIrisArgs = c.Iris.RunIrisArgs
 	n.UseIrisApp = c.Iris.UseIrisApp
+	n.IrisEndpoints = c.Iris.Endpoints
+	n.IrisQuorum = c.Iris.Quorum
+	n.IrisCache = c.Iris.Cache
+	n.IrisRecordFile = c.Iris.RecordFile
+	n.IrisReplayFile = c.Iris.ReplayFile
+	n.FinalityRewindPolicy = c.Iris.RewindPolicy
+	n.FinalityRewindThreshold = c.Iris.RewindThreshold
 
 	// Developer Fake Author for producing blocks without authorisation on zena consensus
 	n.DevFakeAuthor = c.DevFakeAuthor
@@ -975,6 +1045,26 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.SpeculativeWindow = c.Sealer.SpeculativeWindow
+		n.Miner.Ordering = c.Sealer.Ordering
+		n.Miner.MaxTxsPerSender = c.Sealer.MaxTxsPerSender
+		n.Miner.BuildTraces = c.Sealer.BuildTraces
+
+		if !slices.Contains(miner.Orderings, c.Sealer.Ordering) {
+			return nil, fmt.Errorf("unknown transaction ordering %q, expected one of %v", c.Sealer.Ordering, miner.Orderings)
+		}
+
+		if c.Sealer.MaxTxsPerSender < 0 {
+			return nil, fmt.Errorf("negative transactions per sender limit: %d", c.Sealer.MaxTxsPerSender)
+		}
+
+		for _, sender := range c.Sealer.PrioritySenders {
+			if !common.IsHexAddress(sender) {
+				return nil, fmt.Errorf("priority sender is not an address: %s", sender)
+			}
+
+			n.Miner.PrioritySenders = append(n.Miner.PrioritySenders, common.HexToAddress(sender))
+		}
 
 		if zenbase := c.Sealer.Zenbase; zenbase != "" {
 			if !common.IsHexAddress(zenbase) {
@@ -1203,6 +1293,16 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
 	n.ParallelEVM.Enforce = c.ParallelEVM.Enforce
+	n.ParallelEVM.Reports = c.ParallelEVM.Reports
+	n.ParallelEVM.ReportsRetention = c.ParallelEVM.ReportsRetention
+
+	txDependencyMode, err := core.ParseTxDependencyMode(c.ParallelEVM.TxDependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	n.ParallelEVM.TxDependencyMode = txDependencyMode
+	n.ParallelEVM.Adaptive = c.ParallelEVM.Adaptive
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
 
 	if c.Ancient != "" {
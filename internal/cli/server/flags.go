@@ -203,6 +203,48 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Iris.UseIrisApp,
 		Default: c.cliConfig.Iris.UseIrisApp,
 	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "zena.irisendpoints",
+		Usage:   "Comma separated list of additional Iris endpoints to fail over to (http(s) URL, grpc://<address> or app)",
+		Value:   &c.cliConfig.Iris.Endpoints,
+		Default: c.cliConfig.Iris.Endpoints,
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "zena.irisquorum",
+		Usage:   "Number of Iris endpoints which have to agree on spans, checkpoints and milestones (0 disables the check)",
+		Value:   &c.cliConfig.Iris.Quorum,
+		Default: c.cliConfig.Iris.Quorum,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "zena.iriscache",
+		Usage:   "Keep finalized spans, checkpoints and state-sync events fetched from Iris in the local database",
+		Value:   &c.cliConfig.Iris.Cache,
+		Default: c.cliConfig.Iris.Cache,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "zena.irisrecord",
+		Usage:   "Record every Iris response to the given fixture file",
+		Value:   &c.cliConfig.Iris.RecordFile,
+		Default: c.cliConfig.Iris.RecordFile,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "zena.irisreplay",
+		Usage:   "Serve Iris responses from the given fixture file instead of a live Iris (offline replay)",
+		Value:   &c.cliConfig.Iris.ReplayFile,
+		Default: c.cliConfig.Iris.ReplayFile,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "zena.rewindpolicy",
+		Usage:   "Policy applied when a checkpoint or milestone doesn't match the local chain ('auto', 'halt' or 'consecutive')",
+		Value:   &c.cliConfig.Iris.RewindPolicy,
+		Default: c.cliConfig.Iris.RewindPolicy,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "zena.rewindthreshold",
+		Usage:   "Number of consecutive checkpoint or milestone mismatches rewinding the chain with the 'consecutive' rewind policy",
+		Value:   &c.cliConfig.Iris.RewindThreshold,
+		Default: c.cliConfig.Iris.RewindThreshold,
+	})
 
 	// txpool options
 	f.SliceStringFlag(&flagset.SliceStringFlag{
@@ -333,6 +375,41 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.speculativewindow",
+		Usage:   "Number of transactions executed speculatively in parallel while building blocks (0 = serial execution)",
+		Value:   &c.cliConfig.Sealer.SpeculativeWindow,
+		Default: c.cliConfig.Sealer.SpeculativeWindow,
+		Group:   "Sealer",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.ordering",
+		Usage:   "Policy ordering the transactions in the blocks (price-time, fifo, fair or priority)",
+		Value:   &c.cliConfig.Sealer.Ordering,
+		Default: c.cliConfig.Sealer.Ordering,
+		Group:   "Sealer",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.maxtxspersender",
+		Usage:   "Maximum number of transactions of a sender in a block with the fair ordering (0 = no limit)",
+		Value:   &c.cliConfig.Sealer.MaxTxsPerSender,
+		Default: c.cliConfig.Sealer.MaxTxsPerSender,
+		Group:   "Sealer",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "miner.prioritysenders",
+		Usage:   "Comma separated senders whose transactions are included first with the priority ordering",
+		Value:   &c.cliConfig.Sealer.PrioritySenders,
+		Default: c.cliConfig.Sealer.PrioritySenders,
+		Group:   "Sealer",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.buildtraces",
+		Usage:   "Number of blocks built whose build traces are kept for miner_getBuildTrace (0 = disabled)",
+		Value:   &c.cliConfig.Sealer.BuildTraces,
+		Default: c.cliConfig.Sealer.BuildTraces,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -992,6 +1069,30 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.ParallelEVM.Enforce,
 		Default: c.cliConfig.ParallelEVM.Enforce,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.reports",
+		Usage:   "Persist a report of the Block STM execution of every block, served by debug_getParallelExecutionReport",
+		Value:   &c.cliConfig.ParallelEVM.Reports,
+		Default: c.cliConfig.ParallelEVM.Reports,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "parallelevm.reportsretention",
+		Usage:   "Number of recent blocks whose Block STM execution reports are kept (0 keeps them all)",
+		Value:   &c.cliConfig.ParallelEVM.ReportsRetention,
+		Default: c.cliConfig.ParallelEVM.ReportsRetention,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "parallelevm.txdependencies",
+		Usage:   "Use of the transaction dependencies declared in block headers: 'trust' schedules by them and falls back to full Block STM if they are incomplete, 'verify' uses them as hints, 'ignore' discards them",
+		Value:   &c.cliConfig.ParallelEVM.TxDependencies,
+		Default: c.cliConfig.ParallelEVM.TxDependencies,
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.adaptive",
+		Usage:   "Size the Block STM workers block by block from the declared transaction dependencies and the recent abort rate, up to parallelevm.procs (0 for GOMAXPROCS)",
+		Value:   &c.cliConfig.ParallelEVM.Adaptive,
+		Default: c.cliConfig.ParallelEVM.Adaptive,
+	})
 
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "dev.gaslimit",
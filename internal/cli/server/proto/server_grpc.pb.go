@@ -31,6 +31,7 @@ type ZenaClient interface {
 	ChainWatch(ctx context.Context, in *ChainWatchRequest, opts ...grpc.CallOption) (Zena_ChainWatchClient, error)
 	DebugPprof(ctx context.Context, in *DebugPprofRequest, opts ...grpc.CallOption) (Zena_DebugPprofClient, error)
 	DebugBlock(ctx context.Context, in *DebugBlockRequest, opts ...grpc.CallOption) (Zena_DebugBlockClient, error)
+	Finality(ctx context.Context, in *FinalityRequest, opts ...grpc.CallOption) (*FinalityResponse, error)
 }
 
 type zenaClient struct {
@@ -215,6 +216,17 @@ func (x *zenaDebugBlockClient) Recv() (*DebugFileResponse, error) {
 	return m, nil
 }
 
+func (c *zenaClient) Finality(ctx context.Context, in *FinalityRequest, opts ...grpc.CallOption) (*FinalityResponse, error) {
+	out := new(FinalityResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Zena/Finality", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 // ZenaServer is the server API for Zena service.
 // All implementations must embed UnimplementedZenaServer
 // for forward compatibility
@@ -228,6 +240,7 @@ type ZenaServer interface {
 	ChainWatch(*ChainWatchRequest, Zena_ChainWatchServer) error
 	DebugPprof(*DebugPprofRequest, Zena_DebugPprofServer) error
 	DebugBlock(*DebugBlockRequest, Zena_DebugBlockServer) error
+	Finality(context.Context, *FinalityRequest) (*FinalityResponse, error)
 	mustEmbedUnimplementedZenaServer()
 }
 
@@ -262,6 +275,9 @@ func (UnimplementedZenaServer) DebugPprof(*DebugPprofRequest, Zena_DebugPprofSer
 func (UnimplementedZenaServer) DebugBlock(*DebugBlockRequest, Zena_DebugBlockServer) error {
 	return status.Errorf(codes.Unimplemented, "method DebugBlock not implemented")
 }
+func (UnimplementedZenaServer) Finality(context.Context, *FinalityRequest) (*FinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Finality not implemented")
+}
 func (UnimplementedZenaServer) mustEmbedUnimplementedZenaServer() {}
 
 // UnsafeZenaServer may be embedded to opt out of forward compatibility for this service.
@@ -467,6 +483,27 @@ func (x *zenaDebugBlockServer) Send(m *DebugFileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Zena_Finality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(ZenaServer).Finality(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Zena/Finality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZenaServer).Finality(ctx, req.(*FinalityRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
 // Zena_ServiceDesc is the grpc.ServiceDesc for Zena service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -498,6 +535,10 @@ var Zena_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Zena_Status_Handler,
 		},
+		{
+			MethodName: "Finality",
+			Handler:    _Zena_Finality_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -14,6 +14,7 @@ import (
 
 	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/eth/downloader/whitelist"
 	"github.com/zenanetwork/go-zenanet/eth/tracers"
 	"github.com/zenanetwork/go-zenanet/eth/tracers/logger"
 	"github.com/zenanetwork/go-zenanet/internal/cli/server/pprof"
@@ -133,7 +134,7 @@ func (s *Server) PeersList(ctx context.Context, req *proto.PeersListRequest) (*p
 
 	peers := s.node.Server().PeersInfo()
 	for _, p := range peers {
-		resp.Peers = append(resp.Peers, peerInfoToPeer(p))
+		resp.Peers = append(resp.Peers, s.withPeerScore(peerInfoToPeer(p)))
 	}
 
 	return resp, nil
@@ -154,12 +155,47 @@ func (s *Server) PeersStatus(ctx context.Context, req *proto.PeersStatusRequest)
 
 	resp := &proto.PeersStatusResponse{}
 	if peerInfo != nil {
-		resp.Peer = peerInfoToPeer(peerInfo)
+		resp.Peer = s.withPeerScore(peerInfoToPeer(peerInfo))
+		return resp, nil
+	}
+
+	// The peer may be disconnected or banned for its score, look it up in the
+	// reputation of the peers which served invalid chains
+	if s.backend != nil {
+		for _, score := range s.backend.PeerScores() {
+			if strings.HasPrefix(score.Peer, req.Enode) {
+				if resp.Peer != nil {
+					return nil, fmt.Errorf("more than one peer with the same prefix")
+				}
+
+				resp.Peer = s.withPeerScore(&proto.Peer{Id: score.Peer})
+			}
+		}
 	}
 
 	return resp, nil
 }
 
+// withPeerScore fills in the reputation of the peer.
+func (s *Server) withPeerScore(peer *proto.Peer) *proto.Peer {
+	if s.backend == nil {
+		return peer
+	}
+
+	score := s.backend.PeerScore(peer.Id)
+
+	peer.Score = score.Score
+	peer.Deprioritised = score.Deprioritised
+	peer.Failures = score.Failures
+	peer.LastFailure = score.LastFailure
+
+	if !score.BannedUntil.IsZero() {
+		peer.BannedUntil = score.BannedUntil.Unix()
+	}
+
+	return peer
+}
+
 func peerInfoToPeer(info *p2p.PeerInfo) *proto.Peer {
 	return &proto.Peer{
 		Id:      info.ID,
@@ -221,6 +257,41 @@ func (s *Server) Status(ctx context.Context, in *proto.StatusRequest) (*proto.St
 	return resp, nil
 }
 
+func (s *Server) Finality(ctx context.Context, req *proto.FinalityRequest) (*proto.FinalityResponse, error) {
+	if s.backend == nil {
+		return nil, ErrUnavailable
+	}
+
+	status := s.backend.Whitelist().Status()
+
+	resp := &proto.FinalityResponse{
+		Checkpoint: entryToProtoHeader(status.Checkpoint),
+		Milestone:  entryToProtoHeader(status.Milestone),
+		LockedSprint: &proto.FinalityResponse_LockedSprint{
+			Locked:       status.Locked,
+			Number:       status.LockedMilestoneNumber,
+			Hash:         status.LockedMilestoneHash.String(),
+			MilestoneIDs: status.LockedMilestoneIDs,
+		},
+		FutureMilestones: make([]*proto.Header, 0, len(status.FutureMilestones)),
+	}
+
+	for i := range status.FutureMilestones {
+		resp.FutureMilestones = append(resp.FutureMilestones, entryToProtoHeader(&status.FutureMilestones[i]))
+	}
+
+	for _, rejection := range s.backend.Downloader().PeerRejections() {
+		resp.RejectedPeers = append(resp.RejectedPeers, &proto.FinalityResponse_RejectedPeer{
+			Id:           rejection.Peer,
+			Reason:       rejection.Reason,
+			Count:        rejection.Count,
+			LastRejected: rejection.Time.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
 func headerToProtoHeader(h *types.Header) *proto.Header {
 	return &proto.Header{
 		Hash:   h.Hash().String(),
@@ -228,6 +299,17 @@ func headerToProtoHeader(h *types.Header) *proto.Header {
 	}
 }
 
+func entryToProtoHeader(e *whitelist.Entry) *proto.Header {
+	if e == nil {
+		return nil
+	}
+
+	return &proto.Header{
+		Hash:   e.Hash.String(),
+		Number: e.Number,
+	}
+}
+
 func (s *Server) DebugBlock(req *proto.DebugBlockRequest, stream proto.Zena_DebugBlockServer) error {
 	traceReq := &tracers.TraceBlockRequest{
 		Number: req.Number,
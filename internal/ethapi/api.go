@@ -42,6 +42,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state"
 	"github.com/zenanetwork/go-zenanet/core/tracing"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/crypto"
@@ -297,6 +298,18 @@ func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// ConditionalEvictions returns the conditional transactions recently evicted
+// from the pool as their options could no longer hold, the most recent first.
+func (api *TxPoolAPI) ConditionalEvictions() []*txpool.ConditionalEviction {
+	return api.b.TxPoolConditionalEvictions()
+}
+
+// ConditionalEviction returns why a conditional transaction was evicted from
+// the pool, nil if it wasn't recently evicted.
+func (api *TxPoolAPI) ConditionalEviction(hash common.Hash) *txpool.ConditionalEviction {
+	return api.b.TxPoolConditionalEviction(hash)
+}
+
 // ZenanetAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type ZenanetAccountAPI struct {
@@ -46,6 +46,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/bloombits"
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/crypto"
@@ -607,6 +608,24 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) TxPoolConditionalEvictions() []*txpool.ConditionalEviction {
+	panic("implement me")
+}
+func (b testBackend) TxPoolConditionalEviction(hash common.Hash) *txpool.ConditionalEviction {
+	panic("implement me")
+}
+func (b testBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, maxBlock uint64) (*txpool.PrivateTxStatus, error) {
+	panic("implement me")
+}
+func (b testBackend) PrivateTxStatus(hash common.Hash) *txpool.PrivateTxStatus {
+	panic("implement me")
+}
+func (b testBackend) SendBundle(ctx context.Context, bundle *txpool.Bundle) (*txpool.BundleStatus, error) {
+	panic("implement me")
+}
+func (b testBackend) BundleStatus(hash common.Hash) *txpool.BundleStatus {
+	panic("implement me")
+}
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
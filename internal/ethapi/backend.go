@@ -29,6 +29,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/core/bloombits"
 	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/ethdb"
@@ -84,6 +85,12 @@ type Backend interface {
 	Stats() (pending int, queued int)
 	TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction)
 	TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction)
+	TxPoolConditionalEvictions() []*txpool.ConditionalEviction
+	TxPoolConditionalEviction(hash common.Hash) *txpool.ConditionalEviction
+	SendPrivateTx(ctx context.Context, signedTx *types.Transaction, maxBlock uint64) (*txpool.PrivateTxStatus, error)
+	PrivateTxStatus(hash common.Hash) *txpool.PrivateTxStatus
+	SendBundle(ctx context.Context, bundle *txpool.Bundle) (*txpool.BundleStatus, error)
+	BundleStatus(hash common.Hash) *txpool.BundleStatus
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 
 	ChainConfig() *params.ChainConfig
@@ -2,10 +2,14 @@ package ethapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/zenanetwork/go-zenanet/common"
 	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/log"
 	"github.com/zenanetwork/go-zenanet/rpc"
 )
 
@@ -106,6 +110,105 @@ func (api *ZenaAPI) SendRawTransactionConditional(ctx context.Context, input hex
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// SendPrivateRawTransaction adds the signed transaction to the private pool, to
+// be included by this node only, up to the block maxBlock. It is never broadcast
+// to the network, and is dropped if it isn't included by the deadline, or the
+// default lifetime of the private pool if none is given.
+func (api *ZenaAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, maxBlock *hexutil.Uint64) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := checkTxFee(tx.GasPrice(), tx.Gas(), api.b.RPCTxFeeCap()); err != nil {
+		return common.Hash{}, err
+	}
+
+	if !tx.Protected() {
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+
+	var deadline uint64
+	if maxBlock != nil {
+		deadline = uint64(*maxBlock)
+	}
+
+	status, err := api.b.SendPrivateTx(ctx, tx, deadline)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	log.Info("Submitted private transaction", "hash", status.Hash, "from", status.From, "nonce", status.Nonce, "maxBlock", status.MaxBlock)
+
+	return tx.Hash(), nil
+}
+
+// GetPrivateTransactionStatus returns the status of a private transaction
+// submitted to this node, nil if it's unknown.
+func (api *ZenaAPI) GetPrivateTransactionStatus(hash common.Hash) *txpool.PrivateTxStatus {
+	return api.b.PrivateTxStatus(hash)
+}
+
+// SendBundleArgs represents the arguments to submit a bundle.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlock          *hexutil.Uint64 `json:"minBlock"`
+	MaxBlock          *hexutil.Uint64 `json:"maxBlock"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+// SendBundle adds a bundle of signed transactions to the bundle pool, to be
+// included atomically and in order by this node in one of the blocks from
+// minBlock to maxBlock, or not at all. The range defaults to the next block if
+// minBlock isn't given, and to minBlock if maxBlock isn't. The transactions of
+// revertingTxHashes may revert without failing the bundle.
+func (api *ZenaAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	bundle := &txpool.Bundle{
+		Txs:               make([]*types.Transaction, len(args.Txs)),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if err := checkTxFee(tx.GasPrice(), tx.Gas(), api.b.RPCTxFeeCap()); err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if !tx.Protected() {
+			return common.Hash{}, fmt.Errorf("transaction %d: only replay-protected (EIP-155) transactions allowed over RPC", i)
+		}
+
+		bundle.Txs[i] = tx
+	}
+
+	if args.MinBlock != nil {
+		bundle.MinBlock = uint64(*args.MinBlock)
+	}
+
+	if args.MaxBlock != nil {
+		bundle.MaxBlock = uint64(*args.MaxBlock)
+	}
+
+	status, err := api.b.SendBundle(ctx, bundle)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	log.Info("Submitted bundle", "hash", status.Hash, "txs", len(status.Txs), "minBlock", status.MinBlock, "maxBlock", status.MaxBlock)
+
+	return status.Hash, nil
+}
+
+// GetBundleStatus returns the status of a bundle submitted to this node, along
+// with its last simulation by the miner, nil if it's unknown.
+func (api *ZenaAPI) GetBundleStatus(hash common.Hash) *txpool.BundleStatus {
+	return api.b.BundleStatus(hash)
+}
+
 func (api *ZenaAPI) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	return api.b.GetVoteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core"
 	"github.com/zenanetwork/go-zenanet/core/bloombits"
 	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/ethdb"
@@ -408,6 +409,18 @@ func (b *backendMock) TxPoolContent() (map[common.Address][]*types.Transaction,
 func (b *backendMock) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	return nil, nil
 }
+func (b *backendMock) TxPoolConditionalEvictions() []*txpool.ConditionalEviction { return nil }
+func (b *backendMock) TxPoolConditionalEviction(hash common.Hash) *txpool.ConditionalEviction {
+	return nil
+}
+func (b *backendMock) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, maxBlock uint64) (*txpool.PrivateTxStatus, error) {
+	return nil, nil
+}
+func (b *backendMock) PrivateTxStatus(hash common.Hash) *txpool.PrivateTxStatus { return nil }
+func (b *backendMock) SendBundle(ctx context.Context, bundle *txpool.Bundle) (*txpool.BundleStatus, error) {
+	return nil, nil
+}
+func (b *backendMock) BundleStatus(hash common.Hash) *txpool.BundleStatus { return nil }
 func (b *backendMock) SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription      { return nil }
 func (b *backendMock) BloomStatus() (uint64, uint64)                                        { return 0, 0 }
 func (b *backendMock) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {}
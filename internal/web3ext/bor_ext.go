@@ -55,11 +55,66 @@ web3._extend({
 			call: 'zena_getCurrentValidators',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'getValidatorSetDiff',
+			call: 'zena_getValidatorSetDiff',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getSpanHistory',
+			call: 'zena_getSpanHistory',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getProducerStats',
+			call: 'zena_getProducerStats',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getProducerStatsBySpan',
+			call: 'zena_getProducerStatsBySpan',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'simulateStateSync',
+			call: 'zena_simulateStateSync',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getParams',
+			call: 'zena_getParams',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getParamsSchedule',
+			call: 'zena_getParamsSchedule',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'getRootHash',
 			call: 'zena_getRootHash',
 			params: 2,
 		}),
+		new web3._extend.Method({
+			name: 'getBlockInclusionProof',
+			call: 'zena_getBlockInclusionProof',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getReceiptInclusionProof',
+			call: 'zena_getReceiptInclusionProof',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getFinalityIncidents',
+			call: 'zena_getFinalityIncidents',
+			params: 0,
+		}),
 		new web3._extend.Method({
 			name: 'getVoteOnHash',
 			call: 'zena_getVoteOnHash',
@@ -71,6 +126,27 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null]
 		}),
+		new web3._extend.Method({
+			name: 'sendPrivateRawTransaction',
+			call: 'zena_sendPrivateRawTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getPrivateTransactionStatus',
+			call: 'zena_getPrivateTransactionStatus',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'sendBundle',
+			call: 'zena_sendBundle',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getBundleStatus',
+			call: 'zena_getBundleStatus',
+			params: 1
+		}),
 	]
 });
 `
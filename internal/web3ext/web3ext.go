@@ -202,6 +202,24 @@ web3._extend({
 			call: 'admin_setMaxPeers',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'finalityStatus',
+			call: 'admin_finalityStatus'
+		}),
+		new web3._extend.Method({
+			name: 'unlockMilestones',
+			call: 'admin_unlockMilestones',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'purgeFutureMilestones',
+			call: 'admin_purgeFutureMilestones',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'finalityAuditLog',
+			call: 'admin_finalityAuditLog'
+		}),
 		new web3._extend.Method({
 			name: 'getExecutionPoolSize',
 			call: 'admin_getExecutionPoolSize'
@@ -278,6 +296,16 @@ web3._extend({
 			call: 'debug_getRawReceipts',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getParallelExecutionReport',
+			call: 'debug_getParallelExecutionReport',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'replayParallel',
+			call: 'debug_replayParallel',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransaction',
 			call: 'debug_getRawTransaction',
@@ -739,6 +767,12 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'getBuildTrace',
+			call: 'miner_getBuildTrace',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
 	],
 	properties: []
 });
@@ -854,6 +888,15 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Property({
+			name: 'conditionalEvictions',
+			getter: 'txpool_conditionalEvictions'
+		}),
+		new web3._extend.Method({
+			name: 'conditionalEviction',
+			call: 'txpool_conditionalEviction',
+			params: 1,
+		}),
 	]
 });
 `
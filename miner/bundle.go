@@ -0,0 +1,153 @@
+package miner
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+var (
+	bundleCommittedMeter = metrics.NewRegisteredMeter("worker/bundle/committed", nil) // Bundles included in the block being built
+	bundleFailedMeter    = metrics.NewRegisteredMeter("worker/bundle/failed", nil)    // Bundles discarded as one of their transactions failed
+)
+
+// commitBundles simulates the bundles which may be included in the block being
+// built, in their order of submission, includes those whose transactions all
+// succeed and reports the outcome of every simulation to the pool.
+func (w *worker) commitBundles(env *environment, interrupt *atomic.Int32) error {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+
+	for _, bundle := range w.eth.TxPool().PendingBundles(env.header.Number.Uint64()) {
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return signalToErr(signal)
+			}
+		}
+
+		w.eth.TxPool().ReportBundle(bundle.Hash(), w.simulateBundle(env, bundle))
+	}
+
+	return nil
+}
+
+// simulateBundle executes a bundle on a copy of the environment, and keeps the
+// result only if every transaction succeeds or is allowed to revert. The state
+// is copied as the journal doesn't survive the end of a transaction.
+func (w *worker) simulateBundle(env *environment, bundle *txpool.Bundle) *txpool.BundleSimulation {
+	var (
+		sim        = env.copy()
+		simulation = &txpool.BundleSimulation{BlockNumber: env.header.Number.Uint64()}
+	)
+
+	durations := make([]time.Duration, 0, len(bundle.Txs))
+
+	// Record the reads and writes of the transactions, like commitTransactions,
+	// so their dependencies are declared in the header
+	recordDeps := w.chainConfig.IsCancun(env.header.Number) && w.IsRunning()
+	if recordDeps && sim.deps == nil {
+		sim.deps = map[int]map[int]bool{}
+	}
+
+	for _, tx := range bundle.Txs {
+		hash := tx.Hash()
+
+		sim.state.SetTxContext(hash, sim.tcount)
+
+		// Applying a transaction stops the recording, so it is resumed for each
+		if recordDeps {
+			sim.state.AddEmptyMVHashMap()
+			sim.state.ClearReadMap()
+			sim.state.ClearWriteMap()
+		}
+
+		start := time.Now()
+		_, err := w.commitTransaction(sim, tx)
+		durations = append(durations, time.Since(start))
+
+		if err != nil {
+			simulation.FailedTx, simulation.Error = &hash, err.Error()
+			break
+		}
+
+		receipt := sim.receipts[len(sim.receipts)-1]
+		simulation.GasUsed += receipt.GasUsed
+
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.CanRevert(hash) {
+			simulation.FailedTx, simulation.Error = &hash, "execution reverted"
+			break
+		}
+
+		sim.tcount++
+
+		if recordDeps {
+			sim.deps = blockstm.UpdateDeps(sim.deps, sim.txDep(sim.state.MVReadMap(), sim.state.MVFullWriteList()))
+		}
+	}
+
+	if recordDeps {
+		sim.state.ClearReadMap()
+		sim.state.ClearWriteMap()
+	}
+
+	if simulation.FailedTx != nil {
+		log.Debug("Bundle failed", "hash", bundle.Hash(), "tx", simulation.FailedTx, "err", simulation.Error)
+		bundleFailedMeter.Mark(1)
+
+		traceBundle(env.trace, env.signer, bundle, durations, simulation)
+
+		return simulation
+	}
+
+	// Keep the outcome of the simulation
+	env.state.StopPrefetcher()
+
+	env.state, env.gasPool = sim.state, sim.gasPool
+	env.txs, env.receipts, env.tcount = sim.txs, sim.receipts, sim.tcount
+	env.depsMVFullWriteList, env.mvReadMapList, env.deps = sim.depsMVFullWriteList, sim.mvReadMapList, sim.deps
+	env.header.GasUsed = sim.header.GasUsed
+	env.bundles++
+
+	simulation.Success = true
+
+	traceBundle(env.trace, env.signer, bundle, durations, simulation)
+
+	log.Debug("Bundle committed", "hash", bundle.Hash(), "txs", len(bundle.Txs), "gas", simulation.GasUsed)
+	bundleCommittedMeter.Mark(1)
+
+	return simulation
+}
+
+// traceBundle records the outcomes of the transactions of a simulated bundle,
+// all of them failing along with the one which failed the bundle.
+func traceBundle(trace *BuildTrace, signer types.Signer, bundle *txpool.Bundle, durations []time.Duration, simulation *txpool.BundleSimulation) {
+	if trace == nil {
+		return
+	}
+
+	for i, tx := range bundle.Txs {
+		from, _ := types.Sender(signer, tx)
+
+		var duration time.Duration
+		if i < len(durations) {
+			duration = durations[i]
+		}
+
+		switch {
+		case simulation.Success:
+			trace.record(tx.Hash(), from, TxIncluded, nil, duration)
+		case tx.Hash() == *simulation.FailedTx:
+			trace.record(tx.Hash(), from, TxFailed, errors.New(simulation.Error), duration)
+		default:
+			trace.record(tx.Hash(), from, TxBundleFailed, nil, duration)
+		}
+	}
+}
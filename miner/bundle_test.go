@@ -0,0 +1,106 @@
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/ethash"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/bundlepool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/params"
+)
+
+func TestBundleTxDependencies(t *testing.T) {
+	t.Parallel()
+
+	// Blocks are built with the MV hashmap from Cancun on
+	config := *params.TestChainConfig
+	config.ShanghaiBlock = common.Big0
+	config.CancunBlock = common.Big0
+
+	var (
+		keys    = make([]*ecdsa.PrivateKey, 3)
+		counter = common.HexToAddress("0x1000") // Increments slot 0 and emits a log
+		alloc   = types.GenesisAlloc{
+			counter: {Code: common.FromHex("0x60005460010160005560006000a000")},
+		}
+	)
+
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.Account{Balance: testBankFunds}
+	}
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		engine = ethash.NewFaker()
+		gspec  = &core.Genesis{Config: &config, Alloc: alloc}
+	)
+
+	chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	assert.NilError(t, err)
+
+	defer chain.Stop()
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{
+		legacypool.New(testTxPoolConfig, chain),
+		bundlepool.New(bundlepool.DefaultConfig, chain),
+	})
+	assert.NilError(t, err)
+
+	defer pool.Close()
+
+	var (
+		signer   = types.LatestSigner(&config)
+		gasPrice = big.NewInt(10 * params.InitialBaseFee)
+		tx       = func(sender int, nonce uint64) *types.Transaction {
+			return types.MustSignNewTx(keys[sender], signer, &types.LegacyTx{Nonce: nonce, To: &counter, Gas: 100000, GasPrice: gasPrice})
+		}
+	)
+
+	// Both transactions of the bundle call the counter, as does the pending
+	// transaction of another sender
+	_, err = pool.AddBundle(&txpool.Bundle{Txs: []*types.Transaction{tx(0, 0), tx(1, 0)}})
+	assert.NilError(t, err)
+
+	for _, err := range pool.Add([]*types.Transaction{tx(2, 0)}, true, true) {
+		assert.NilError(t, err)
+	}
+
+	workerConfig := *testConfig
+	workerConfig.ExtraData = blockExtra(t)
+
+	backend := &testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}
+
+	w := newWorker(&workerConfig, &config, engine, backend, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	w.running.Store(true)
+
+	env, err := w.prepareWork(&generateParams{timestamp: uint64(time.Now().Unix()), coinbase: testBankAddress})
+	assert.NilError(t, err)
+	assert.NilError(t, w.fillTransactions(nil, env))
+	assert.Equal(t, len(env.txs), 3)
+	assert.Equal(t, env.bundles, 1)
+
+	// The dependencies of the bundle transactions are declared along with the others
+	deps := types.NewBlockWithHeader(env.header).GetTxDependency()
+	assert.Equal(t, len(deps), 3)
+	assert.Equal(t, len(deps[0]), 0)
+	assert.DeepEqual(t, deps[1], []uint64{0})
+
+	// The pending transaction reads the counter last written by the bundle
+	assert.DeepEqual(t, deps[2], []uint64{1})
+}
@@ -46,12 +46,17 @@ type Backend interface {
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Zenbase           common.Address `toml:",omitempty"` // Public address for block mining rewards
-	ExtraData           hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
-	GasCeil             uint64         // Target gas ceiling for mined blocks.
-	GasPrice            *big.Int       // Minimum gas price for mining a transaction
-	Recommit            time.Duration  // The time interval for miner to re-create mining work.
-	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
+	Zenbase             common.Address   `toml:",omitempty"` // Public address for block mining rewards
+	ExtraData           hexutil.Bytes    `toml:",omitempty"` // Block extra data set by the miner
+	GasCeil             uint64           // Target gas ceiling for mined blocks.
+	GasPrice            *big.Int         // Minimum gas price for mining a transaction
+	Recommit            time.Duration    // The time interval for miner to re-create mining work.
+	CommitInterruptFlag bool             // Interrupt commit when time is up ( default = true)
+	SpeculativeWindow   int              // Number of transactions executed in parallel while building blocks (0 = serial execution)
+	Ordering            string           // Transaction ordering policy (price-time, fifo, fair or priority)
+	MaxTxsPerSender     int              // Maximum number of transactions of a sender in a block with the fair ordering (0 = no limit)
+	PrioritySenders     []common.Address `toml:",omitempty"` // Senders included first with the priority ordering
+	BuildTraces         int              // Number of blocks built whose build traces are kept (0 = disabled)
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
 }
@@ -67,6 +72,8 @@ var DefaultConfig = Config{
 	// run 3 rounds.
 	Recommit:          2 * time.Second,
 	NewPayloadTimeout: 2 * time.Second,
+	Ordering:          OrderingPriceTime,
+	BuildTraces:       64,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -104,6 +111,12 @@ func (miner *Miner) GetWorker() *worker {
 	return miner.worker
 }
 
+// BuildTrace returns the trace of the last build of a block built locally, nil
+// if it isn't kept.
+func (miner *Miner) BuildTrace(number uint64) *BuildTrace {
+	return miner.worker.buildTraces.get(number)
+}
+
 // update keeps track of the downloader events. Please be aware that this is a one shot type of update loop.
 // It's entered once and as soon as `Done` or `Failed` has been broadcasted the events are unregistered and
 // the loop is exited. This to prevent a major security vuln where external parties can DOS you with blocks
@@ -28,9 +28,10 @@ import (
 
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 type txWithMinerFee struct {
-	tx   *txpool.LazyTransaction
-	from common.Address
-	fees *uint256.Int
+	tx     *txpool.LazyTransaction
+	from   common.Address
+	fees   *uint256.Int
+	picked int // Number of transactions of the sender picked before this one
 }
 
 // newTxWithMinerFee creates a wrapped transaction, calculating the effective
@@ -54,32 +55,27 @@ func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee
 	}, nil
 }
 
-// txByPriceAndTime implements both the sort and the heap interface, making it useful
+// txByPolicy implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-type txByPriceAndTime []*txWithMinerFee
-
-func (s txByPriceAndTime) Len() int { return len(s) }
-func (s txByPriceAndTime) Less(i, j int) bool {
-	// If the prices are equal, use the time the transaction was first seen for
-	// deterministic sorting
-	cmp := s[i].fees.Cmp(s[j].fees)
-	if cmp == 0 {
-		return s[i].tx.Time.Before(s[j].tx.Time)
-	}
-	return cmp > 0
+type txByPolicy struct {
+	txs    []*txWithMinerFee
+	policy orderingPolicy
 }
-func (s txByPriceAndTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
-func (s *txByPriceAndTime) Push(x interface{}) {
-	*s = append(*s, x.(*txWithMinerFee))
+func (s txByPolicy) Len() int           { return len(s.txs) }
+func (s txByPolicy) Less(i, j int) bool { return s.policy.Less(s.txs[i], s.txs[j]) }
+func (s txByPolicy) Swap(i, j int)      { s.txs[i], s.txs[j] = s.txs[j], s.txs[i] }
+
+func (s *txByPolicy) Push(x interface{}) {
+	s.txs = append(s.txs, x.(*txWithMinerFee))
 }
 
-func (s *txByPriceAndTime) Pop() interface{} {
-	old := *s
+func (s *txByPolicy) Pop() interface{} {
+	old := s.txs
 	n := len(old)
 	x := old[n-1]
 	old[n-1] = nil
-	*s = old[0 : n-1]
+	s.txs = old[0 : n-1]
 	return x
 }
 
@@ -88,31 +84,31 @@ func (s *txByPriceAndTime) Pop() interface{} {
 // entire batches of transactions for non-executable accounts.
 type transactionsByPriceAndNonce struct {
 	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	heads   txByPolicy                                   // Next transaction for each unique account (policy heap)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *uint256.Int                                 // Current base fee
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// transactions sorted by the ordering policy in a nonce-honouring way.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, policy orderingPolicy) *transactionsByPriceAndNonce {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
 		baseFeeUint = uint256.MustFromBig(baseFee)
 	}
-	// Initialize a price and received time based heap with the head transactions
-	heads := make(txByPriceAndTime, 0, len(txs))
+	// Initialize a policy based heap with the head transactions
+	heads := txByPolicy{txs: make([]*txWithMinerFee, 0, len(txs)), policy: policy}
 	for from, accTxs := range txs {
 		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
 		if err != nil {
 			delete(txs, from)
 			continue
 		}
-		heads = append(heads, wrapped)
+		heads.txs = append(heads.txs, wrapped)
 		txs[from] = accTxs[1:]
 	}
 	heap.Init(&heads)
@@ -126,22 +122,26 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 	}
 }
 
-// Peek returns the next transaction by price.
+// Peek returns the next transaction by the ordering policy.
 func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *uint256.Int) {
-	if len(t.heads) == 0 {
+	if len(t.heads.txs) == 0 {
 		return nil, nil
 	}
-	return t.heads[0].tx, t.heads[0].fees
+	return t.heads.txs[0].tx, t.heads.txs[0].fees
 }
 
-// Shift replaces the current best head with the next one from the same account.
+// Shift replaces the current best head with the next one from the same account,
+// unless the ordering policy picked enough transactions from it.
 func (t *transactionsByPriceAndNonce) Shift() {
-	acc := t.heads[0].from
-	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
-			t.heads[0], t.txs[acc] = wrapped, txs[1:]
-			heap.Fix(&t.heads, 0)
-			return
+	acc, picked := t.heads.txs[0].from, t.heads.txs[0].picked+1
+	if limit := t.heads.policy.SenderLimit(); limit == 0 || picked < limit {
+		if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+			if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+				wrapped.picked = picked
+				t.heads.txs[0], t.txs[acc] = wrapped, txs[1:]
+				heap.Fix(&t.heads, 0)
+				return
+			}
 		}
 	}
 	heap.Pop(&t.heads)
@@ -158,13 +158,13 @@ func (t *transactionsByPriceAndNonce) Pop() {
 	heap.Pop(&t.heads)
 }
 
-// Empty returns if the price heap is empty. It can be used to check it simpler
+// Empty returns if the policy heap is empty. It can be used to check it simpler
 // than calling peek and checking for nil return.
 func (t *transactionsByPriceAndNonce) Empty() bool {
-	return len(t.heads) == 0
+	return len(t.heads.txs) == 0
 }
 
 // Clear removes the entire content of the heap.
 func (t *transactionsByPriceAndNonce) Clear() {
-	t.heads, t.txs = nil, nil
+	t.heads.txs, t.txs = nil, nil
 }
@@ -0,0 +1,123 @@
+package miner
+
+import (
+	"fmt"
+
+	"github.com/zenanetwork/go-zenanet/common"
+)
+
+// Transaction ordering policies selectable through the miner config.
+const (
+	OrderingPriceTime = "price-time" // Highest tip first, earliest arrival on equal tips
+	OrderingFIFO      = "fifo"       // Earliest arrival first, whatever the tip
+	OrderingFair      = "fair"       // Senders take turns, each capped per block
+	OrderingPriority  = "priority"   // Whitelisted senders first, then by price and time
+)
+
+// Orderings are the names of the transaction ordering policies.
+var Orderings = []string{OrderingPriceTime, OrderingFIFO, OrderingFair, OrderingPriority}
+
+// orderingPolicy decides the order in which the block builder picks the
+// transactions, among the next executable transaction of every sender. The
+// transactions of a sender are always picked in nonce order.
+type orderingPolicy interface {
+	// Less reports whether the transaction a must be picked before b.
+	Less(a, b *txWithMinerFee) bool
+
+	// SenderLimit returns the maximum number of transactions picked from a
+	// sender, 0 for no limit.
+	SenderLimit() int
+}
+
+// newOrderingPolicy returns the transaction ordering policy selected by the
+// config, price-time if none is.
+func newOrderingPolicy(config *Config) (orderingPolicy, error) {
+	switch config.Ordering {
+	case "", OrderingPriceTime:
+		return priceTimeOrdering{}, nil
+	case OrderingFIFO:
+		return fifoOrdering{}, nil
+	case OrderingFair:
+		if config.MaxTxsPerSender < 0 {
+			return nil, fmt.Errorf("negative transactions per sender limit: %d", config.MaxTxsPerSender)
+		}
+
+		return fairOrdering{limit: config.MaxTxsPerSender}, nil
+	case OrderingPriority:
+		senders := make(map[common.Address]struct{}, len(config.PrioritySenders))
+		for _, sender := range config.PrioritySenders {
+			senders[sender] = struct{}{}
+		}
+
+		return priorityOrdering{senders: senders}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering %q", config.Ordering)
+	}
+}
+
+// priceTimeOrdering picks the transactions paying the highest tip first, and
+// the ones seen earlier on equal tips to avoid network spam attacks aiming for
+// a specific ordering.
+type priceTimeOrdering struct{}
+
+func (priceTimeOrdering) Less(a, b *txWithMinerFee) bool {
+	cmp := a.fees.Cmp(b.fees)
+	if cmp == 0 {
+		return a.tx.Time.Before(b.tx.Time)
+	}
+
+	return cmp > 0
+}
+
+func (priceTimeOrdering) SenderLimit() int { return 0 }
+
+// fifoOrdering picks the transactions in their order of arrival, falling back
+// to the tip for the ones seen at the same time.
+type fifoOrdering struct{}
+
+func (fifoOrdering) Less(a, b *txWithMinerFee) bool {
+	if !a.tx.Time.Equal(b.tx.Time) {
+		return a.tx.Time.Before(b.tx.Time)
+	}
+
+	return a.fees.Cmp(b.fees) > 0
+}
+
+func (fifoOrdering) SenderLimit() int { return 0 }
+
+// fairOrdering picks the transactions of the senders in turns, by price and
+// time among the ones with as many transactions already picked, and stops
+// picking from a sender once it reached the limit.
+type fairOrdering struct {
+	limit int
+}
+
+func (fairOrdering) Less(a, b *txWithMinerFee) bool {
+	if a.picked != b.picked {
+		return a.picked < b.picked
+	}
+
+	return priceTimeOrdering{}.Less(a, b)
+}
+
+func (o fairOrdering) SenderLimit() int { return o.limit }
+
+// priorityOrdering picks the transactions of the whitelisted senders first,
+// and orders both groups by price and time. The local transactions are always
+// picked before the remote ones, whatever the policy.
+type priorityOrdering struct {
+	senders map[common.Address]struct{}
+}
+
+func (o priorityOrdering) Less(a, b *txWithMinerFee) bool {
+	_, priorityA := o.senders[a.from]
+	_, priorityB := o.senders[b.from]
+
+	if priorityA != priorityB {
+		return priorityA
+	}
+
+	return priceTimeOrdering{}.Less(a, b)
+}
+
+func (priorityOrdering) SenderLimit() int { return 0 }
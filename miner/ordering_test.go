@@ -20,6 +20,7 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -102,7 +103,7 @@ func testTransactionPriceNonceSort(t *testing.T, baseFee *big.Int) {
 		expectedCount += count
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee)
+	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee, priceTimeOrdering{})
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -168,7 +169,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		})
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, nil)
+	txset := newTransactionsByPriceAndNonce(signer, groups, nil, priceTimeOrdering{})
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -194,3 +195,94 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// orderingTestGroups signs a transaction per price of each account, the account
+// i sending its transactions at time i.
+func orderingTestGroups(t *testing.T, signer types.Signer, keys []*ecdsa.PrivateKey, prices ...[]int64) map[common.Address][]*txpool.LazyTransaction {
+	t.Helper()
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for i, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+
+		for nonce, price := range prices[i] {
+			tx, err := types.SignTx(types.NewTransaction(uint64(nonce), common.Address{}, big.NewInt(100), 100, big.NewInt(price), nil), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign tx: %s", err)
+			}
+			tx.SetTime(time.Unix(0, int64(i)))
+
+			groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+				Gas:       tx.Gas(),
+				BlobGas:   tx.BlobGas(),
+			})
+		}
+	}
+
+	return groups
+}
+
+// Tests that the ordering policies pick the transactions in their order, and
+// stop picking from a sender past its limit.
+func TestTransactionOrderingPolicies(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	senders := make([]common.Address, len(keys))
+	for i, key := range keys {
+		senders[i] = crypto.PubkeyToAddress(key.PublicKey)
+	}
+
+	prices := [][]int64{{1, 1, 1}, {3, 3, 3}, {2}}
+
+	tests := []struct {
+		config Config
+		want   []int // Senders of the transactions in their expected order
+	}{
+		{Config{Ordering: OrderingPriceTime}, []int{1, 1, 1, 2, 0, 0, 0}},
+		{Config{Ordering: OrderingFIFO}, []int{0, 0, 0, 1, 1, 1, 2}},
+		{Config{Ordering: OrderingFair}, []int{1, 2, 0, 1, 0, 1, 0}},
+		{Config{Ordering: OrderingFair, MaxTxsPerSender: 2}, []int{1, 2, 0, 1, 0}},
+		{Config{Ordering: OrderingPriority, PrioritySenders: []common.Address{senders[0]}}, []int{0, 0, 0, 1, 1, 1, 2}},
+		{Config{Ordering: OrderingPriority, PrioritySenders: []common.Address{senders[2]}}, []int{2, 1, 1, 1, 0, 0, 0}},
+	}
+	for i, test := range tests {
+		policy, err := newOrderingPolicy(&test.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to create ordering policy: %v", i, err)
+		}
+
+		txset := newTransactionsByPriceAndNonce(signer, orderingTestGroups(t, signer, keys, prices...), nil, policy)
+
+		var got []int
+		for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+			from, _ := types.Sender(signer, tx.Tx)
+			got = append(got, slices.Index(senders, from))
+			txset.Shift()
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("test %d (%s): invalid ordering: have senders %v, want %v", i, test.config.Ordering, got, test.want)
+		}
+	}
+}
+
+func TestUnknownTransactionOrdering(t *testing.T) {
+	t.Parallel()
+
+	if _, err := newOrderingPolicy(&Config{Ordering: "random"}); err == nil {
+		t.Error("expected an error for an unknown ordering policy")
+	}
+	if _, err := newOrderingPolicy(&Config{Ordering: OrderingFair, MaxTxsPerSender: -1}); err == nil {
+		t.Error("expected an error for a negative transactions per sender limit")
+	}
+}
@@ -0,0 +1,226 @@
+package miner
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/log"
+	"github.com/zenanetwork/go-zenanet/metrics"
+)
+
+var (
+	speculativeCommittedMeter  = metrics.NewRegisteredMeter("worker/speculative/committed", nil)  // Transactions committed from their speculative execution
+	speculativeReexecutedMeter = metrics.NewRegisteredMeter("worker/speculative/reexecuted", nil) // Transactions executed again after a conflict or failure
+	speculativeWindowTimer     = metrics.NewRegisteredTimer("worker/speculative/window", nil)
+)
+
+// speculativeCandidate is a transaction of a speculative window.
+type speculativeCandidate struct {
+	tx   *types.Transaction
+	from common.Address
+	spec *core.SpeculativeTx
+	err  error
+}
+
+// speculativeWindow collects the next best transactions, at most one per
+// sender, to execute them in parallel before committing them in order.
+type speculativeWindow struct {
+	size       int
+	candidates []*speculativeCandidate
+	senders    map[common.Address]struct{}
+	gas        uint64                      // Sum of the gas limits of the candidates
+	dropped    map[common.Address]struct{} // Senders of failed transactions, whose remaining transactions are skipped
+}
+
+func newSpeculativeWindow(size int) *speculativeWindow {
+	return &speculativeWindow{
+		size:    size,
+		senders: make(map[common.Address]struct{}),
+		dropped: make(map[common.Address]struct{}),
+	}
+}
+
+func (sw *speculativeWindow) add(tx *types.Transaction, from common.Address) {
+	sw.candidates = append(sw.candidates, &speculativeCandidate{tx: tx, from: from})
+	sw.senders[from] = struct{}{}
+	sw.gas += tx.Gas()
+}
+
+func (sw *speculativeWindow) reset() {
+	sw.candidates = sw.candidates[:0]
+	sw.senders = make(map[common.Address]struct{})
+	sw.gas = 0
+}
+
+func (sw *speculativeWindow) empty() bool {
+	return len(sw.candidates) == 0
+}
+
+func (sw *speculativeWindow) full() bool {
+	return len(sw.candidates) >= sw.size
+}
+
+// accepts reports whether tx from the given sender can join the window
+// without exceeding the gas left in the block.
+func (sw *speculativeWindow) accepts(tx *types.Transaction, from common.Address, gasLeft uint64) bool {
+	if _, ok := sw.senders[from]; ok {
+		return false
+	}
+
+	return sw.gas+tx.Gas() <= gasLeft
+}
+
+func (sw *speculativeWindow) isDropped(from common.Address) bool {
+	_, ok := sw.dropped[from]
+	return ok
+}
+
+// conflicts reports whether any of the values read were written.
+func conflicts(reads map[blockstm.Key]blockstm.ReadDescriptor, written map[blockstm.Key]struct{}) bool {
+	for k := range reads {
+		if _, ok := written[k]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commitSpeculativeWindow executes the transactions of the window in parallel,
+// each on its own overlay of the current state, then commits them in order.
+// Transactions which read a value written by an earlier transaction of the
+// window, or which failed, are executed again on the current state. It returns
+// the logs of the committed transactions.
+func (w *worker) commitSpeculativeWindow(env *environment, window *speculativeWindow, chDeps chan blockstm.TxDep) []*types.Log {
+	defer window.reset()
+
+	var (
+		start    = time.Now()
+		vmConfig = *w.chain.GetVMConfig()
+		gasLimit = env.gasPool.Gas()
+		wg       sync.WaitGroup
+	)
+
+	// The executions share the current state, which is left untouched until they
+	// are all done, and only copy the accounts they access
+	for i, c := range window.candidates {
+		statedb := env.state.Overlay()
+		statedb.SetTxContext(c.tx.Hash(), env.tcount+i)
+
+		wg.Add(1)
+
+		go func(c *speculativeCandidate, statedb *state.StateDB) {
+			defer wg.Done()
+
+			// The block context caches block hashes, so it isn't shared between executions
+			blockContext := core.NewEVMBlockContext(env.header, w.chain, &env.coinbase)
+			c.spec, c.err = core.ExecuteSpeculativeTx(w.chainConfig, blockContext, statedb, env.header, c.tx, gasLimit, vmConfig, w.interruptCtx)
+		}(c, statedb)
+	}
+
+	wg.Wait()
+	speculativeWindowTimer.UpdateSince(start)
+
+	// Every committed transaction pays fees to the coinbase and the burnt contract
+	feeKeys := []blockstm.Key{blockstm.NewSubpathKey(env.coinbase, state.BalancePath)}
+	if w.chainConfig.Zena != nil && w.chainConfig.IsLondon(env.header.Number) {
+		burntContract := common.HexToAddress(w.chainConfig.Zena.CalculateBurntContract(env.header.Number.Uint64()))
+		feeKeys = append(feeKeys, blockstm.NewSubpathKey(burntContract, state.BalancePath))
+	}
+
+	var (
+		logs    []*types.Log
+		written = make(map[blockstm.Key]struct{})
+	)
+
+	for i, c := range window.candidates {
+		if w.interruptCtx != nil && w.interruptCtx.Err() != nil {
+			for _, c := range window.candidates[i:] {
+				env.trace.record(c.tx.Hash(), c.from, TxInterrupted, nil, 0)
+			}
+
+			break
+		}
+
+		env.state.SetTxContext(c.tx.Hash(), env.tcount)
+
+		var (
+			txLogs []*types.Log
+			err    error
+			reads  map[blockstm.Key]blockstm.ReadDescriptor
+			writes []blockstm.WriteDescriptor
+			start  = time.Now()
+		)
+
+		if c.err == nil && !conflicts(c.spec.MVReadMap(), written) {
+			txLogs, err = w.applySpeculativeTransaction(env, c.tx, c.spec)
+			reads, writes = c.spec.MVReadMap(), c.spec.MVFullWriteList()
+
+			if err == nil {
+				speculativeCommittedMeter.Mark(1)
+			}
+		} else {
+			env.state.AddEmptyMVHashMap()
+			env.state.ClearReadMap()
+			env.state.ClearWriteMap()
+
+			txLogs, err = w.commitTransaction(env, c.tx)
+			reads, writes = env.state.MVReadMap(), env.state.MVFullWriteList()
+
+			speculativeReexecutedMeter.Mark(1)
+		}
+
+		duration := time.Since(start)
+
+		switch {
+		case errors.Is(err, core.ErrNonceTooLow):
+			log.Trace("Skipping transaction with low nonce", "hash", c.tx.Hash(), "sender", c.from, "nonce", c.tx.Nonce())
+			env.trace.record(c.tx.Hash(), c.from, TxNonceTooLow, err, duration)
+
+		case errors.Is(err, nil):
+			logs = append(logs, txLogs...)
+			env.tcount++
+
+			env.trace.record(c.tx.Hash(), c.from, TxIncluded, nil, duration)
+
+			env.recordTxDep(chDeps, reads, writes)
+
+			for _, write := range writes {
+				written[write.Path] = struct{}{}
+			}
+
+			for _, k := range feeKeys {
+				written[k] = struct{}{}
+			}
+
+		default:
+			log.Debug("Transaction failed, account skipped", "hash", c.tx.Hash(), "err", err)
+			env.trace.record(c.tx.Hash(), c.from, failureOutcome(err), err, duration)
+			window.dropped[c.from] = struct{}{}
+		}
+	}
+
+	env.state.ClearReadMap()
+	env.state.ClearWriteMap()
+
+	return logs
+}
+
+// applySpeculativeTransaction commits a transaction from its speculative execution.
+func (w *worker) applySpeculativeTransaction(env *environment, tx *types.Transaction, spec *core.SpeculativeTx) ([]*types.Log, error) {
+	receipt, err := spec.Apply(w.chainConfig, env.gasPool, env.state, env.header, &env.header.GasUsed)
+	if err != nil {
+		return nil, err
+	}
+
+	env.txs = append(env.txs, tx)
+	env.receipts = append(env.receipts, receipt)
+
+	return receipt.Logs, nil
+}
@@ -0,0 +1,229 @@
+package miner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/consensus/ethash"
+	"github.com/zenanetwork/go-zenanet/core"
+	"github.com/zenanetwork/go-zenanet/core/blockstm"
+	"github.com/zenanetwork/go-zenanet/core/rawdb"
+	"github.com/zenanetwork/go-zenanet/core/state"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+	"github.com/zenanetwork/go-zenanet/crypto"
+	"github.com/zenanetwork/go-zenanet/event"
+	"github.com/zenanetwork/go-zenanet/params"
+	"github.com/zenanetwork/go-zenanet/rlp"
+)
+
+func TestSpeculativeWindow(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender1 = common.HexToAddress("0x01")
+		sender2 = common.HexToAddress("0x02")
+		tx      = func(gas uint64) *types.Transaction {
+			return types.NewTx(&types.LegacyTx{Gas: gas, GasPrice: big.NewInt(1)})
+		}
+	)
+
+	window := newSpeculativeWindow(2)
+	assert.Assert(t, window.empty())
+
+	window.add(tx(50_000), sender1)
+
+	// A sender has at most one transaction in the window, and the window fits the block
+	assert.Assert(t, !window.accepts(tx(21_000), sender1, 1_000_000))
+	assert.Assert(t, !window.accepts(tx(21_000), sender2, 70_000))
+	assert.Assert(t, window.accepts(tx(21_000), sender2, 71_000))
+
+	window.add(tx(21_000), sender2)
+	assert.Assert(t, window.full())
+
+	window.dropped[sender1] = struct{}{}
+	window.reset()
+
+	assert.Assert(t, window.empty())
+	assert.Assert(t, window.accepts(tx(21_000), sender1, 21_000))
+	assert.Assert(t, window.isDropped(sender1))
+}
+
+func TestSpeculativeConflicts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		balance = blockstm.NewSubpathKey(common.HexToAddress("0x01"), state.BalancePath)
+		slot    = blockstm.NewStateKey(common.HexToAddress("0x02"), common.HexToHash("0x03"))
+		reads   = map[blockstm.Key]blockstm.ReadDescriptor{balance: {Path: balance}}
+	)
+
+	assert.Assert(t, !conflicts(reads, map[blockstm.Key]struct{}{slot: {}}))
+	assert.Assert(t, conflicts(reads, map[blockstm.Key]struct{}{slot: {}, balance: {}}))
+}
+
+func TestSpeculativeBuildMatchesSerial(t *testing.T) {
+	t.Parallel()
+
+	// Blocks are built with the MV hashmap from Cancun on
+	config := *params.TestChainConfig
+	config.ShanghaiBlock = common.Big0
+	config.CancunBlock = common.Big0
+
+	var (
+		keys    = make([]*ecdsa.PrivateKey, 4)
+		senders = make([]common.Address, 4)
+
+		coinbase = common.HexToAddress("0xc0ffee")
+		counter  = common.HexToAddress("0x1000") // Increments slot 0 and emits a log
+		reader   = common.HexToAddress("0x2000") // Stores the balance of the coinbase
+		empty    = common.HexToAddress("0x3000") // Existing empty account, deleted once touched
+
+		alloc = types.GenesisAlloc{
+			counter: {Code: common.FromHex("0x60005460010160005560006000a000")},
+			reader:  {Code: common.FromHex("0x413160005500")},
+			empty:   {Balance: common.Big0},
+		}
+	)
+
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		senders[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		alloc[senders[i]] = types.Account{Balance: testBankFunds}
+	}
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		engine = ethash.NewFaker()
+		gspec  = &core.Genesis{Config: &config, Alloc: alloc}
+	)
+
+	chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	assert.NilError(t, err)
+
+	defer chain.Stop()
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	assert.NilError(t, err)
+
+	defer pool.Close()
+
+	var (
+		signer   = types.LatestSigner(&config)
+		gasPrice = big.NewInt(10 * params.InitialBaseFee)
+		tx       = func(sender int, nonce uint64, to *common.Address, value int64, data string) *types.Transaction {
+			return types.MustSignNewTx(keys[sender], signer, &types.LegacyTx{Nonce: nonce, To: to, Value: big.NewInt(value), Gas: 100000, GasPrice: gasPrice, Data: common.FromHex(data)})
+		}
+		txs = []*types.Transaction{
+			// Conflicting senders, calling the same counter
+			tx(0, 0, &counter, 0, ""),
+			tx(1, 0, &counter, 0, ""),
+			// A transfer to the coinbase
+			tx(2, 0, &coinbase, 1000, ""),
+			// A contract creation, storing 1 in slot 0
+			tx(3, 0, nil, 0, "0x600160005560016000f3"),
+			// A contract created and self-destructed in the same transaction
+			tx(0, 1, nil, 1000, "0x600160005533ff"),
+			// A touch of an empty account
+			tx(1, 1, &empty, 0, ""),
+			tx(2, 1, &counter, 0, ""),
+			// A read of the fees paid so far
+			tx(3, 1, &reader, 0, ""),
+		}
+		created   = crypto.CreateAddress(senders[3], 0)
+		destroyed = crypto.CreateAddress(senders[0], 1)
+	)
+
+	for _, err := range pool.Add(txs, true, true) {
+		assert.NilError(t, err)
+	}
+
+	backend := &testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}
+	timestamp := uint64(time.Now().Unix())
+
+	build := func(window int) (*environment, *types.Block) {
+		workerConfig := *testConfig
+		workerConfig.SpeculativeWindow = window
+		workerConfig.ExtraData = blockExtra(t)
+
+		w := newWorker(&workerConfig, &config, engine, backend, new(event.TypeMux), nil, false)
+		defer w.close()
+
+		w.running.Store(true)
+
+		env, err := w.prepareWork(&generateParams{timestamp: timestamp, coinbase: coinbase})
+		assert.NilError(t, err)
+		assert.NilError(t, w.fillTransactions(nil, env))
+		assert.Equal(t, len(env.txs), len(txs))
+
+		block, err := engine.FinalizeAndAssemble(chain, types.CopyHeader(env.header), env.state.Copy(), &types.Body{Transactions: env.txs}, env.receipts)
+		assert.NilError(t, err)
+
+		return env, block
+	}
+
+	serialEnv, serial := build(0)
+	speculativeEnv, speculative := build(4)
+
+	// The transactions had the expected effects
+	for _, env := range []*environment{serialEnv, speculativeEnv} {
+		assert.Equal(t, env.state.GetState(counter, common.Hash{}), common.BigToHash(big.NewInt(3)))
+		assert.Equal(t, env.state.GetState(created, common.Hash{}), common.BigToHash(common.Big1))
+		assert.Assert(t, !env.state.Exist(destroyed))
+		assert.Assert(t, !env.state.Exist(empty))
+		assert.Assert(t, env.state.GetState(reader, common.Hash{}) != common.Hash{})
+	}
+
+	// Both blocks have the same transactions, receipts, logs and state
+	assert.Equal(t, speculative.TxHash(), serial.TxHash())
+	assert.Equal(t, speculative.ReceiptHash(), serial.ReceiptHash())
+	assert.Equal(t, speculative.Bloom(), serial.Bloom())
+	assert.Equal(t, speculative.GasUsed(), serial.GasUsed())
+	assert.Equal(t, speculative.Root(), serial.Root())
+
+	for i, receipt := range speculativeEnv.receipts {
+		expected := serialEnv.receipts[i]
+
+		assert.Equal(t, receipt.TxHash, expected.TxHash)
+		assert.Equal(t, receipt.Status, expected.Status)
+		assert.Equal(t, receipt.GasUsed, expected.GasUsed)
+		assert.Equal(t, receipt.ContractAddress, expected.ContractAddress)
+		assert.Equal(t, len(receipt.Logs), len(expected.Logs))
+
+		for j, l := range receipt.Logs {
+			assert.Equal(t, l.Address, expected.Logs[j].Address)
+			assert.DeepEqual(t, l.Topics, expected.Logs[j].Topics)
+			assert.DeepEqual(t, l.Data, expected.Logs[j].Data)
+			assert.Equal(t, l.Index, expected.Logs[j].Index)
+		}
+	}
+
+	// And a validator processing the speculative block agrees with it
+	statedb, err := chain.StateAt(chain.Genesis().Root())
+	assert.NilError(t, err)
+
+	receipts, _, usedGas, err := core.NewStateProcessor(&config, chain, chain.HeaderChain()).Process(speculative, statedb, vm.Config{}, context.Background())
+	assert.NilError(t, err)
+	assert.NilError(t, chain.Validator().ValidateState(speculative, statedb, receipts, usedGas, false))
+}
+
+// blockExtra returns a block extra field holding empty zena extra data, in which
+// the worker declares the dependencies of the transactions of the block.
+func blockExtra(t *testing.T) []byte {
+	t.Helper()
+
+	data, err := rlp.EncodeToBytes(types.BlockExtraData{})
+	assert.NilError(t, err)
+
+	extra := append(make([]byte, types.ExtraVanityLength), data...)
+
+	return append(extra, make([]byte, types.ExtraSealLength)...)
+}
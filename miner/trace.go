@@ -0,0 +1,208 @@
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/common/hexutil"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/core/vm"
+)
+
+// Outcomes of the transactions considered while building a block
+const (
+	TxIncluded        = "included"         // Included in the block
+	TxNonceTooLow     = "nonce too low"    // Its nonce was used by a transaction included meanwhile
+	TxNonceGap        = "nonce gap"        // A previous transaction of its sender was skipped
+	TxUnderpriced     = "underpriced"      // Its tip is lower than the minimum tip of the miner
+	TxOutOfGas        = "out of gas"       // Not enough gas, or blob gas, left in the block
+	TxInterrupted     = "interrupted"      // The building of the block was interrupted before or while executing it
+	TxEvicted         = "evicted"          // Evicted from the pool before being executed
+	TxConditionsUnmet = "conditions unmet" // The options of the conditional transaction don't hold
+	TxUnprotected     = "unprotected"      // Replay protected before EIP-155 is enabled
+	TxBundleFailed    = "bundle failed"    // Another transaction of its bundle failed
+	TxFailed          = "error"            // Failed to execute
+)
+
+// TxTrace is the outcome of a transaction considered while building a block.
+type TxTrace struct {
+	Hash     common.Hash    `json:"hash"`
+	From     common.Address `json:"from"`
+	Outcome  string         `json:"outcome"`
+	Error    string         `json:"error,omitempty"`
+	Offset   time.Duration  `json:"offset"`             // Time elapsed since the start of the build when it was considered
+	Duration time.Duration  `json:"duration,omitempty"` // Time spent executing it
+}
+
+// BuildTrace records why each transaction considered while building a block
+// was, or wasn't, included. The transactions which weren't considered at all
+// aren't recorded: the building ended before their turn came.
+type BuildTrace struct {
+	Number     uint64        `json:"number"`
+	ParentHash common.Hash   `json:"parentHash"`
+	Start      time.Time     `json:"start"`
+	Duration   time.Duration `json:"duration"`
+	MinTip     *hexutil.Big  `json:"minTip"` // Transactions paying a lower tip are recorded as underpriced
+	Txs        []*TxTrace    `json:"txs"`
+}
+
+func newBuildTrace(header *types.Header, minTip *big.Int) *BuildTrace {
+	return &BuildTrace{
+		Number:     header.Number.Uint64(),
+		ParentHash: header.ParentHash,
+		Start:      time.Now(),
+		MinTip:     (*hexutil.Big)(new(big.Int).Set(minTip)),
+	}
+}
+
+// record adds the outcome of a transaction. It's a noop on a nil trace, the
+// traces being only recorded for the blocks built to be sealed.
+func (bt *BuildTrace) record(hash common.Hash, from common.Address, outcome string, err error, duration time.Duration) {
+	if bt == nil {
+		return
+	}
+
+	trace := &TxTrace{
+		Hash:     hash,
+		From:     from,
+		Outcome:  outcome,
+		Offset:   time.Since(bt.Start),
+		Duration: duration,
+	}
+	if err != nil {
+		trace.Error = err.Error()
+	}
+
+	bt.Txs = append(bt.Txs, trace)
+}
+
+// recordGaps records the transactions discarded along with the transaction of
+// their sender which was skipped.
+func (bt *BuildTrace) recordGaps(from common.Address, txs []*txpool.LazyTransaction) {
+	for _, tx := range txs {
+		bt.record(tx.Hash, from, TxNonceGap, nil, 0)
+	}
+}
+
+// filterUnderpriced removes from txs the first transaction of every sender paying
+// a lower tip than minTip, and the following ones, as the pool does when given a
+// minimum tip, recording them as underpriced. The senders in exempt are kept.
+func (bt *BuildTrace) filterUnderpriced(txs map[common.Address][]*txpool.LazyTransaction, minTip, baseFee *uint256.Int, exempt map[common.Address]struct{}) {
+	for from, list := range txs {
+		if _, ok := exempt[from]; ok {
+			continue
+		}
+
+		for i, tx := range list {
+			underpriced := tx.GasTipCap.Lt(minTip)
+			if baseFee != nil {
+				// The fee cap must cover the base fee, then the remaining fee the minimum tip
+				underpriced = underpriced || tx.GasFeeCap.Lt(baseFee) || new(uint256.Int).Sub(tx.GasFeeCap, baseFee).Lt(minTip)
+			}
+
+			if underpriced {
+				bt.record(tx.Hash, from, TxUnderpriced, nil, 0)
+				bt.recordGaps(from, list[i+1:])
+
+				if i == 0 {
+					delete(txs, from)
+				} else {
+					txs[from] = list[:i]
+				}
+
+				break
+			}
+		}
+	}
+}
+
+// recordNext records the outcome of the next transaction of txs, if any.
+func (bt *BuildTrace) recordNext(txs *transactionsByPriceAndNonce, outcome string) {
+	if bt == nil || txs.Empty() {
+		return
+	}
+
+	head := txs.heads.txs[0]
+	bt.record(head.tx.Hash, head.from, outcome, nil, 0)
+}
+
+// popTraced pops the next transaction of txs, recording its outcome and the nonce
+// gap left for the following transactions of its sender.
+func popTraced(trace *BuildTrace, txs *transactionsByPriceAndNonce, outcome string, err error, duration time.Duration) {
+	if trace != nil {
+		head := txs.heads.txs[0]
+
+		trace.record(head.tx.Hash, head.from, outcome, err, duration)
+		trace.recordGaps(head.from, txs.txs[head.from])
+	}
+
+	txs.Pop()
+}
+
+// failureOutcome returns the outcome of a transaction which failed to execute.
+func failureOutcome(err error) string {
+	if errors.Is(err, vm.ErrInterrupt) {
+		return TxInterrupted
+	}
+
+	return TxFailed
+}
+
+// buildTraces is a ring buffer of the traces of the last blocks built, keeping
+// the last trace of each block number.
+type buildTraces struct {
+	mu     sync.RWMutex
+	traces []*BuildTrace
+	next   int
+}
+
+func newBuildTraces(size int) *buildTraces {
+	return &buildTraces{traces: make([]*BuildTrace, size)}
+}
+
+// add ends a trace and adds it, replacing the previous trace of the same block
+// or the oldest one.
+func (bt *buildTraces) add(trace *BuildTrace) {
+	if bt == nil || trace == nil || len(bt.traces) == 0 {
+		return
+	}
+
+	trace.Duration = time.Since(trace.Start)
+
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	for i, t := range bt.traces {
+		if t != nil && t.Number == trace.Number {
+			bt.traces[i] = trace
+			return
+		}
+	}
+
+	bt.traces[bt.next] = trace
+	bt.next = (bt.next + 1) % len(bt.traces)
+}
+
+// get returns the last trace of a block, nil if there is none.
+func (bt *buildTraces) get(number uint64) *BuildTrace {
+	if bt == nil {
+		return nil
+	}
+
+	bt.mu.RLock()
+	defer bt.mu.RUnlock()
+
+	for _, trace := range bt.traces {
+		if trace != nil && trace.Number == number {
+			return trace
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"gotest.tools/assert"
+
+	"github.com/zenanetwork/go-zenanet/common"
+	"github.com/zenanetwork/go-zenanet/core/txpool"
+	"github.com/zenanetwork/go-zenanet/core/types"
+	"github.com/zenanetwork/go-zenanet/crypto"
+)
+
+func TestBuildTraces(t *testing.T) {
+	t.Parallel()
+
+	trace := func(number int64) *BuildTrace {
+		return newBuildTrace(&types.Header{Number: big.NewInt(number)}, common.Big1)
+	}
+
+	traces := newBuildTraces(2)
+
+	first, second := trace(1), trace(2)
+	traces.add(first)
+	traces.add(second)
+
+	assert.Equal(t, traces.get(1), first)
+	assert.Equal(t, traces.get(2), second)
+	assert.Assert(t, traces.get(3) == nil)
+
+	// A new build of a block replaces its trace
+	rebuilt := trace(2)
+	traces.add(rebuilt)
+
+	assert.Equal(t, traces.get(1), first)
+	assert.Equal(t, traces.get(2), rebuilt)
+
+	// The oldest trace is dropped once the buffer is full
+	third := trace(3)
+	traces.add(third)
+
+	assert.Assert(t, traces.get(1) == nil)
+	assert.Equal(t, traces.get(2), rebuilt)
+	assert.Equal(t, traces.get(3), third)
+
+	// Nothing is kept when disabled
+	var disabled *buildTraces
+
+	disabled.add(trace(4))
+	assert.Assert(t, disabled.get(4) == nil)
+}
+
+func TestBuildTraceNonceGaps(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+
+	var (
+		signer    = types.HomesteadSigner{}
+		groups    = orderingTestGroups(t, signer, keys, []int64{2, 2, 2}, []int64{1})
+		sender    = crypto.PubkeyToAddress(keys[0].PublicKey)
+		senderTxs = groups[sender] // The set takes over the groups
+		txs       = newTransactionsByPriceAndNonce(signer, groups, nil, priceTimeOrdering{})
+		trace     = newBuildTrace(&types.Header{Number: common.Big1}, common.Big0)
+	)
+
+	// Skipping the first transaction of a sender leaves a gap for the others
+	popTraced(trace, txs, TxOutOfGas, nil, 0)
+	trace.recordNext(txs, TxUnderpriced)
+
+	assert.Equal(t, len(trace.Txs), 4)
+
+	for i, outcome := range []string{TxOutOfGas, TxNonceGap, TxNonceGap} {
+		assert.Equal(t, trace.Txs[i].From, sender)
+		assert.Equal(t, trace.Txs[i].Hash, senderTxs[i].Hash)
+		assert.Equal(t, trace.Txs[i].Outcome, outcome)
+	}
+
+	assert.Equal(t, trace.Txs[3].From, crypto.PubkeyToAddress(keys[1].PublicKey))
+	assert.Equal(t, trace.Txs[3].Outcome, TxUnderpriced)
+}
+
+func TestBuildTraceUnderpriced(t *testing.T) {
+	t.Parallel()
+
+	lazy := func(hash byte, feeCap, tipCap uint64) *txpool.LazyTransaction {
+		return &txpool.LazyTransaction{
+			Hash:      common.Hash{hash},
+			GasFeeCap: uint256.NewInt(feeCap),
+			GasTipCap: uint256.NewInt(tipCap),
+		}
+	}
+
+	var (
+		priced  = common.Address{1}
+		capped  = common.Address{2}
+		cheap   = common.Address{3}
+		exempt  = common.Address{4}
+		baseFee = uint256.NewInt(10)
+		trace   = newBuildTrace(&types.Header{Number: common.Big1}, common.Big2)
+		txs     = map[common.Address][]*txpool.LazyTransaction{
+			priced: {lazy(1, 20, 5)},
+			capped: {lazy(2, 20, 5), lazy(3, 11, 5), lazy(4, 20, 5)}, // The fee cap leaves a tip of 1
+			cheap:  {lazy(5, 5, 5)},                                  // The fee cap doesn't cover the base fee
+			exempt: {lazy(6, 20, 1)},
+		}
+	)
+
+	trace.filterUnderpriced(txs, uint256.NewInt(2), baseFee, map[common.Address]struct{}{exempt: {}})
+
+	assert.Equal(t, len(txs), 3)
+	assert.Equal(t, len(txs[priced]), 1)
+	assert.Equal(t, len(txs[capped]), 1)
+	assert.Equal(t, len(txs[exempt]), 1)
+
+	outcomes := make(map[common.Hash]string)
+	for _, tx := range trace.Txs {
+		outcomes[tx.Hash] = tx.Outcome
+	}
+
+	assert.DeepEqual(t, outcomes, map[common.Hash]string{
+		{3}: TxUnderpriced,
+		{4}: TxNonceGap,
+		{5}: TxUnderpriced,
+	})
+}
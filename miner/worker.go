@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -104,9 +105,15 @@ type environment struct {
 	receipts []*types.Receipt
 	sidecars []*types.BlobTxSidecar
 	blobs    int
+	hidden   int  // Leading private and bundle transactions, which must not leak into the pending block
+	private  bool // Whether private transactions are being committed, whose logs must not leak either
+	bundles  int  // Bundles included
+
+	trace *BuildTrace // Outcomes of the transactions considered, nil if not recorded
 
 	depsMVFullWriteList [][]blockstm.WriteDescriptor
 	mvReadMapList       []map[blockstm.Key]blockstm.ReadDescriptor
+	deps                map[int]map[int]bool // Dependencies of the committed transactions, derived from their reads and writes
 }
 
 // copy creates a deep copy of environment.
@@ -115,11 +122,14 @@ func (env *environment) copy() *environment {
 		signer:              env.signer,
 		state:               env.state.Copy(),
 		tcount:              env.tcount,
+		hidden:              env.hidden,
+		bundles:             env.bundles,
 		coinbase:            env.coinbase,
 		header:              types.CopyHeader(env.header),
 		receipts:            copyReceipts(env.receipts),
 		depsMVFullWriteList: env.depsMVFullWriteList,
 		mvReadMapList:       env.mvReadMapList,
+		deps:                maps.Clone(env.deps),
 	}
 
 	if env.gasPool != nil {
@@ -135,6 +145,34 @@ func (env *environment) copy() *environment {
 	return cpy
 }
 
+// recordTxDep records the reads and writes of the last committed transaction,
+// from which the dependencies between the transactions of the block are derived.
+func (env *environment) recordTxDep(chDeps chan blockstm.TxDep, reads map[blockstm.Key]blockstm.ReadDescriptor, writes []blockstm.WriteDescriptor) {
+	chDeps <- env.txDep(reads, writes)
+}
+
+// txDep records the reads and writes of the last committed transaction and
+// returns them, along with the writes of the previous ones.
+func (env *environment) txDep(reads map[blockstm.Key]blockstm.ReadDescriptor, writes []blockstm.WriteDescriptor) blockstm.TxDep {
+	env.depsMVFullWriteList = append(env.depsMVFullWriteList, writes)
+	env.mvReadMapList = append(env.mvReadMapList, reads)
+
+	if env.tcount > len(env.depsMVFullWriteList) {
+		log.Warn("blockstm - env.tcount > len(env.depsMVFullWriteList)", "env.tcount", env.tcount, "len(depsMVFullWriteList)", len(env.depsMVFullWriteList))
+	}
+
+	readList := make([]blockstm.ReadDescriptor, 0, len(reads))
+	for _, v := range reads {
+		readList = append(readList, v)
+	}
+
+	return blockstm.TxDep{
+		Index:         env.tcount - 1,
+		ReadList:      readList,
+		FullWriteList: env.depsMVFullWriteList,
+	}
+}
+
 // discard terminates the background prefetcher go-routine. It should
 // always be called for all created environment instances otherwise
 // the go-routine leak can happen.
@@ -252,6 +290,12 @@ type worker struct {
 	// payload in proof-of-stake stage.
 	recommit time.Duration
 
+	// ordering is the policy picking the order of the transactions of the blocks.
+	ordering orderingPolicy
+
+	// buildTraces keeps the traces of the last blocks built, nil if disabled.
+	buildTraces *buildTraces
+
 	// External functions
 	isLocalBlock func(header *types.Header) bool // Function used to determine whether the specified block is mined by local miner.
 
@@ -342,6 +386,19 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 
 	worker.newpayloadTimeout = newpayloadTimeout
 
+	// Sanitize the transaction ordering policy.
+	ordering, err := newOrderingPolicy(worker.config)
+	if err != nil {
+		log.Warn("Sanitizing transaction ordering to default", "err", err, "updated", OrderingPriceTime)
+		ordering = priceTimeOrdering{}
+	}
+
+	worker.ordering = ordering
+
+	if config.BuildTraces > 0 {
+		worker.buildTraces = newBuildTraces(config.BuildTraces)
+	}
+
 	worker.wg.Add(4)
 
 	go worker.mainLoop()
@@ -634,8 +691,8 @@ func (w *worker) mainLoop() {
 						BlobGas:   tx.BlobGas(),
 					})
 				}
-				plainTxs := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee) // Mixed bag of everrything, yolo
-				blobTxs := newTransactionsByPriceAndNonce(w.current.signer, nil, w.current.header.BaseFee)  // Empty bag, don't bother optimising
+				plainTxs := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee, w.ordering) // Mixed bag of everrything, yolo
+				blobTxs := newTransactionsByPriceAndNonce(w.current.signer, nil, w.current.header.BaseFee, w.ordering)  // Empty bag, don't bother optimising
 
 				tcount := w.current.tcount
 
@@ -651,7 +708,7 @@ func (w *worker) mainLoop() {
 				// Only update the snapshot if any new transactons were added
 				// to the pending block
 				if tcount != w.current.tcount {
-					w.updateSnapshot(w.current)
+					w.updatePendingSnapshot(w.current)
 				}
 			} else {
 				// Special case, if the consensus engine is 0 period clique(dev mode),
@@ -851,6 +908,7 @@ func (w *worker) makeEnv(parent *types.Header, header *types.Header, coinbase co
 
 	env.depsMVFullWriteList = [][]blockstm.WriteDescriptor{}
 	env.mvReadMapList = []map[blockstm.Key]blockstm.ReadDescriptor{}
+	env.deps = map[int]map[int]bool{}
 
 	return env, nil
 }
@@ -872,6 +930,55 @@ func (w *worker) updateSnapshot(env *environment) {
 	w.snapshotState = env.state.Copy()
 }
 
+// updatePendingSnapshot updates the pending snapshot with the transactions of the
+// environment which may be revealed. If it includes private or bundle ones, the
+// others are executed again on top of the parent state without them.
+func (w *worker) updatePendingSnapshot(env *environment) {
+	if env.hidden == 0 {
+		w.updateSnapshot(env)
+		return
+	}
+
+	parent := w.chain.GetHeader(env.header.ParentHash, env.header.Number.Uint64()-1)
+	if parent == nil {
+		log.Error("Failed to find the parent of the pending block", "number", env.header.Number, "parent", env.header.ParentHash)
+		return
+	}
+
+	header := types.CopyHeader(env.header)
+	header.GasUsed = 0
+
+	public, err := w.makeEnv(parent, header, env.coinbase)
+	if err != nil {
+		log.Error("Failed to create the pending block context", "err", err)
+		return
+	}
+	defer public.discard()
+
+	public.gasPool = new(core.GasPool).AddGas(header.GasLimit)
+
+	for _, tx := range env.txs[env.hidden:] {
+		snap, gp := public.state.Snapshot(), public.gasPool.Gas()
+
+		public.state.SetTxContext(tx.Hash(), public.tcount)
+
+		// The transactions depending on the hidden ones may fail, they are left out
+		receipt, err := core.ApplyTransaction(w.chainConfig, w.chain, &public.coinbase, public.gasPool, public.state, public.header, tx, &public.header.GasUsed, *w.chain.GetVMConfig(), context.Background())
+		if err != nil {
+			public.state.RevertToSnapshot(snap)
+			public.gasPool.SetGas(gp)
+
+			continue
+		}
+
+		public.txs = append(public.txs, tx)
+		public.receipts = append(public.receipts, receipt)
+		public.tcount++
+	}
+
+	w.updateSnapshot(public)
+}
+
 func (w *worker) commitTransaction(env *environment, tx *types.Transaction) ([]*types.Log, error) {
 	var (
 		snap = env.state.Snapshot()
@@ -911,7 +1018,12 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 
 	// create and add empty mvHashMap in statedb
 	if EnableMVHashMap && w.IsRunning() {
-		deps = map[int]map[int]bool{}
+		// The dependencies of the transactions committed before, e.g. those of
+		// the bundles, are kept
+		deps = env.deps
+		if deps == nil {
+			deps = map[int]map[int]bool{}
+		}
 
 		chDeps = make(chan blockstm.TxDep)
 
@@ -931,6 +1043,25 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		}(chDeps)
 	}
 
+	// Execute the transactions speculatively in parallel windows, the recorded
+	// reads and writes telling which ones conflict
+	var window *speculativeWindow
+
+	if w.config.SpeculativeWindow > 1 && EnableMVHashMap && w.IsRunning() && w.chain.GetVMConfig().Tracer == nil {
+		window = newSpeculativeWindow(w.config.SpeculativeWindow)
+	}
+
+	// flush commits the pending speculative window, if any
+	flush := func() bool {
+		if window == nil || window.empty() {
+			return false
+		}
+
+		coalescedLogs = append(coalescedLogs, w.commitSpeculativeWindow(env, window, chDeps)...)
+
+		return true
+	}
+
 	var lastTxHash common.Hash
 
 mainloop:
@@ -938,6 +1069,9 @@ mainloop:
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
 			if signal := interrupt.Load(); signal != commitInterruptNone {
+				env.trace.recordNext(plainTxs, TxInterrupted)
+				env.trace.recordNext(blobTxs, TxInterrupted)
+
 				return signalToErr(signal)
 			}
 		}
@@ -952,6 +1086,16 @@ mainloop:
 			case <-w.interruptCtx.Done():
 				txCommitInterruptCounter.Inc(1)
 				log.Warn("Tx Level Interrupt", "hash", lastTxHash, "err", w.interruptCtx.Err())
+
+				if window != nil {
+					for _, c := range window.candidates {
+						env.trace.record(c.tx.Hash(), c.from, TxInterrupted, nil, 0)
+					}
+				}
+
+				env.trace.recordNext(plainTxs, TxInterrupted)
+				env.trace.recordNext(blobTxs, TxInterrupted)
+
 				break mainloop
 			default:
 			}
@@ -959,13 +1103,21 @@ mainloop:
 
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
+			if flush() {
+				continue
+			}
+
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
+			env.trace.recordNext(plainTxs, TxOutOfGas)
+			env.trace.recordNext(blobTxs, TxOutOfGas)
+
 			break
 		}
 		// If we don't have enough blob space for any further blob transactions,
 		// skip that list altogether
 		if !blobTxs.Empty() && env.blobs*params.BlobTxBlobGasPerBlob >= params.MaxBlobGasPerBlock {
 			log.Trace("Not enough blob space for further blob transactions")
+			env.trace.recordNext(blobTxs, TxOutOfGas)
 			blobTxs.Clear()
 			// Fall though to pick up any plain txs
 		}
@@ -991,56 +1143,83 @@ mainloop:
 			}
 		}
 		if ltx == nil {
+			if flush() {
+				continue
+			}
+
 			break
 		}
 		lastTxHash = ltx.Hash
 		// If we don't have enough space for the next transaction, skip the account.
 		if env.gasPool.Gas() < ltx.Gas {
 			log.Trace("Not enough gas left for transaction", "hash", ltx.Hash, "left", env.gasPool.Gas(), "needed", ltx.Gas)
-			txs.Pop()
+			popTraced(env.trace, txs, TxOutOfGas, nil, 0)
 			continue
 		}
 		if left := uint64(params.MaxBlobGasPerBlock - env.blobs*params.BlobTxBlobGasPerBlob); left < ltx.BlobGas {
 			log.Trace("Not enough blob gas left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas)
-			txs.Pop()
+			popTraced(env.trace, txs, TxOutOfGas, nil, 0)
 			continue
 		}
 		// If we don't receive enough tip for the next transaction, skip the account
 		if ptip.Cmp(minTip) < 0 {
+			if flush() {
+				continue
+			}
+
 			log.Trace("Not enough tip for transaction", "hash", ltx.Hash, "tip", ptip, "needed", minTip)
+			env.trace.recordNext(txs, TxUnderpriced)
+
 			break // If the next-best is too low, surely no better will be available
 		}
 		// Transaction seems to fit, pull it up from the pool
 		tx := ltx.Resolve()
 		if tx == nil {
 			log.Trace("Ignoring evicted transaction", "hash", ltx.Hash)
-			txs.Pop()
+			popTraced(env.trace, txs, TxEvicted, nil, 0)
 			continue
 		}
 		// Error may be ignored here. The error has already been checked
 		// during transaction acceptance in the transaction pool.
 		from, _ := types.Sender(env.signer, tx)
 
+		// Blob and conditional transactions are committed one by one, on the state
+		// left by the pending speculative window
+		speculative := window != nil && tx.Type() != types.BlobTxType && tx.GetOptions() == nil
+
+		if window != nil {
+			if window.isDropped(from) {
+				log.Trace("Skipping transaction of failed account", "hash", ltx.Hash, "sender", from)
+				popTraced(env.trace, txs, TxNonceGap, nil, 0)
+
+				continue
+			}
+
+			if !speculative && flush() {
+				continue
+			}
+		}
+
 		// not prioritising conditional transaction, yet.
 		//nolint:nestif
 		if options := tx.GetOptions(); options != nil {
 			if err := env.header.ValidateBlockNumberOptionsPIP15(options.BlockNumberMin, options.BlockNumberMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
-				txs.Pop()
+				popTraced(env.trace, txs, TxConditionsUnmet, err, 0)
 
 				continue
 			}
 
 			if err := env.header.ValidateTimestampOptionsPIP15(options.TimestampMin, options.TimestampMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
-				txs.Pop()
+				popTraced(env.trace, txs, TxConditionsUnmet, err, 0)
 
 				continue
 			}
 
 			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
-				txs.Pop()
+				popTraced(env.trace, txs, TxConditionsUnmet, err, 0)
 
 				continue
 			}
@@ -1050,13 +1229,31 @@ mainloop:
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", w.chainConfig.EIP155Block)
-			txs.Pop()
+			popTraced(env.trace, txs, TxUnprotected, nil, 0)
 			continue
 		}
+		if speculative {
+			if !window.empty() && !window.accepts(tx, from, env.gasPool.Gas()) {
+				flush()
+				continue
+			}
+
+			window.add(tx, from)
+			txs.Shift()
+
+			if window.full() {
+				flush()
+			}
+
+			continue
+		}
+
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
+		start := time.Now()
 		logs, err := w.commitTransaction(env, tx)
+		duration := time.Since(start)
 
 		// Check if we have a `delay` set in interrupt context. It's only set during tests.
 		if w.interruptCtx != nil {
@@ -1070,6 +1267,7 @@ mainloop:
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
+			env.trace.record(ltx.Hash, from, TxNonceTooLow, err, duration)
 			txs.Shift()
 
 		case errors.Is(err, nil):
@@ -1078,29 +1276,17 @@ mainloop:
 			env.tcount++
 
 			if EnableMVHashMap && w.IsRunning() {
-				env.depsMVFullWriteList = append(env.depsMVFullWriteList, env.state.MVFullWriteList())
-				env.mvReadMapList = append(env.mvReadMapList, env.state.MVReadMap())
-
-				if env.tcount > len(env.depsMVFullWriteList) {
-					log.Warn("blockstm - env.tcount > len(env.depsMVFullWriteList)", "env.tcount", env.tcount, "len(depsMVFullWriteList)", len(env.depsMVFullWriteList))
-				}
-
-				temp := blockstm.TxDep{
-					Index:         env.tcount - 1,
-					ReadList:      env.state.MVReadList(),
-					FullWriteList: env.depsMVFullWriteList,
-				}
-
-				chDeps <- temp
+				env.recordTxDep(chDeps, env.state.MVReadMap(), env.state.MVFullWriteList())
 			}
 
+			env.trace.record(ltx.Hash, from, TxIncluded, nil, duration)
 			txs.Shift()
 
 		default:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
-			txs.Pop()
+			popTraced(env.trace, txs, failureOutcome(err), err, duration)
 		}
 
 		if EnableMVHashMap && w.IsRunning() {
@@ -1116,6 +1302,8 @@ mainloop:
 		})
 		depsWg.Wait()
 
+		env.deps = deps
+
 		var blockExtraData types.BlockExtraData
 
 		tempVanity := env.header.Extra[:types.ExtraVanityLength]
@@ -1173,7 +1361,7 @@ mainloop:
 		env.header.Extra = append(env.header.Extra, tempSeal...)
 	}
 
-	if !w.IsRunning() && len(coalescedLogs) > 0 {
+	if !w.IsRunning() && !env.private && len(coalescedLogs) > 0 {
 		// We don't push the pendingLogsEvent while we are sealing. The reason is that
 		// when we are sealing, the worker will regenerate a sealing block every 3 seconds.
 		// In order to avoid pushing the repeated pendingLog, we disable the pending log pushing.
@@ -1299,6 +1487,16 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	tip := w.tip
 	w.mu.RUnlock()
 
+	// Record the outcomes of the transactions considered for the blocks to seal
+	if w.buildTraces != nil && w.IsRunning() {
+		env.trace = newBuildTrace(env.header, tip.ToBig())
+
+		defer func() {
+			w.buildTraces.add(env.trace)
+			env.trace = nil
+		}()
+	}
+
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(tip.ToBig()),
@@ -1317,11 +1515,58 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	)
 
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
+
+	// Include the private transactions first, they are submitted to this node
+	// to be included with priority in the blocks it seals, and the bundles next,
+	// each one atomically. Neither is included in the pending block of a node
+	// which isn't mining.
+	if w.IsRunning() {
+		if privateTxs := w.eth.TxPool().PendingPrivate(filter); len(privateTxs) > 0 {
+			plainTxs := newTransactionsByPriceAndNonce(env.signer, privateTxs, env.header.BaseFee, w.ordering)
+			blobTxs := newTransactionsByPriceAndNonce(env.signer, nil, env.header.BaseFee, w.ordering)
+
+			env.private = true
+			err := w.commitTransactions(env, plainTxs, blobTxs, interrupt, new(uint256.Int))
+			env.private = false
+
+			env.hidden = len(env.txs)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		err := w.commitBundles(env, interrupt)
+		env.hidden = len(env.txs)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// When tracing, the minimum tip is enforced here rather than by the pool, so
+	// the transactions it excludes are recorded as underpriced
+	minTip := filter.MinTip
+	if env.trace != nil {
+		filter.MinTip = nil
+	}
+
 	pendingPlainTxs := w.eth.TxPool().Pending(filter)
 
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
 	pendingBlobTxs := w.eth.TxPool().Pending(filter)
 
+	if env.trace != nil {
+		// The legacy pool exempts the local accounts from the minimum tip
+		locals := make(map[common.Address]struct{})
+		for _, account := range w.eth.TxPool().Locals() {
+			locals[account] = struct{}{}
+		}
+
+		env.trace.filterUnderpriced(pendingPlainTxs, minTip, filter.BaseFee, locals)
+		env.trace.filterUnderpriced(pendingBlobTxs, minTip, filter.BaseFee, nil)
+	}
+
 	// Split the pending transactions into locals and remotes.
 	localPlainTxs, remotePlainTxs = make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
 	localBlobTxs, remoteBlobTxs = make(map[common.Address][]*txpool.LazyTransaction), pendingBlobTxs
@@ -1341,8 +1586,8 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
 		var plainTxs, blobTxs *transactionsByPriceAndNonce
 
-		plainTxs = newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
-		blobTxs = newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
+		plainTxs = newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee, w.ordering)
+		blobTxs = newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee, w.ordering)
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interrupt, new(uint256.Int)); err != nil {
 			return err
@@ -1352,8 +1597,8 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
 		var plainTxs, blobTxs *transactionsByPriceAndNonce
 
-		plainTxs = newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
-		blobTxs = newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+		plainTxs = newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee, w.ordering)
+		blobTxs = newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee, w.ordering)
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interrupt, new(uint256.Int)); err != nil {
 			return err
@@ -1558,7 +1803,7 @@ func (w *worker) commit(env *environment, interval func(), update bool, start ti
 	}
 
 	if update {
-		w.updateSnapshot(env)
+		w.updatePendingSnapshot(env)
 	}
 
 	return nil
@@ -37,6 +37,7 @@ import (
 	"github.com/zenanetwork/go-zenanet/core/rawdb"
 	"github.com/zenanetwork/go-zenanet/core/txpool"
 	"github.com/zenanetwork/go-zenanet/core/txpool/legacypool"
+	"github.com/zenanetwork/go-zenanet/core/txpool/privatepool"
 	"github.com/zenanetwork/go-zenanet/core/types"
 	"github.com/zenanetwork/go-zenanet/core/vm"
 	"github.com/zenanetwork/go-zenanet/crypto"
@@ -1142,3 +1143,113 @@ func BenchmarkZenaMiningBlockSTMMetadata(b *testing.B) {
 		}
 	}
 }
+
+func TestPrivateTxsNotPending(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		engine = ethash.NewFaker()
+		gspec  = &core.Genesis{
+			Config: ethashChainConfig,
+			Alloc: types.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				testUserAddress: {Balance: testBankFunds},
+			},
+		}
+	)
+
+	chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	assert.NilError(t, err)
+
+	defer chain.Stop()
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{
+		legacypool.New(testTxPoolConfig, chain),
+		privatepool.New(privatepool.DefaultConfig, chain),
+	})
+	assert.NilError(t, err)
+
+	backend := &testWorkerBackend{db: db, chain: chain, txPool: pool, genesis: gspec}
+
+	w := newWorker(testConfig, ethashChainConfig, engine, backend, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	w.setZenbase(testBankAddress)
+
+	logsCh := make(chan []*types.Log, 16)
+	sub := w.pendingLogsFeed.Subscribe(logsCh)
+
+	defer sub.Unsubscribe()
+
+	// Both transactions create a contract emitting a log
+	var (
+		signer  = types.LatestSigner(ethashChainConfig)
+		code    = common.FromHex("0x60006000a000") // LOG0(0, 0)
+		private = types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{Nonce: 0, Gas: 100000, GasPrice: big.NewInt(10 * params.InitialBaseFee), Data: code})
+		public  = types.MustSignNewTx(testUserKey, signer, &types.LegacyTx{Nonce: 0, Gas: 100000, GasPrice: big.NewInt(10 * params.InitialBaseFee), Data: code})
+	)
+
+	_, err = pool.AddPrivate(private, 0)
+	assert.NilError(t, err)
+
+	for _, err := range pool.Add([]*types.Transaction{public}, true, true) {
+		assert.NilError(t, err)
+	}
+
+	build := func() *environment {
+		env, err := w.prepareWork(&generateParams{timestamp: uint64(time.Now().Unix()), coinbase: testBankAddress})
+		assert.NilError(t, err)
+		assert.NilError(t, w.fillTransactions(nil, env))
+
+		return env
+	}
+
+	// A node which isn't mining leaves the private transactions out
+	env := build()
+	assert.Equal(t, len(env.txs), 1)
+	assert.Equal(t, env.txs[0].Hash(), public.Hash())
+
+	// And never delivers their logs as pending
+	var publicLogs int
+
+	for done := false; !done; {
+		select {
+		case logs := <-logsCh:
+			for _, l := range logs {
+				assert.Assert(t, l.TxHash != private.Hash())
+
+				if l.TxHash == public.Hash() {
+					publicLogs++
+				}
+			}
+		case <-time.After(100 * time.Millisecond):
+			done = true
+		}
+	}
+
+	assert.Assert(t, publicLogs > 0)
+
+	// A mining node includes them first, but keeps them out of the pending block
+	w.running.Store(true)
+
+	env = build()
+	assert.Equal(t, len(env.txs), 2)
+	assert.Equal(t, env.txs[0].Hash(), private.Hash())
+	assert.Equal(t, env.hidden, 1)
+
+	w.updatePendingSnapshot(env)
+
+	block, receipts, state := w.pending()
+	assert.Equal(t, len(block.Transactions()), 1)
+	assert.Equal(t, block.Transactions()[0].Hash(), public.Hash())
+	assert.Equal(t, len(receipts), 1)
+	assert.Equal(t, state.GetNonce(testBankAddress), uint64(0))
+	assert.Equal(t, state.GetNonce(testUserAddress), uint64(1))
+
+	select {
+	case logs := <-logsCh:
+		t.Fatalf("pending logs delivered while mining: %v", logs)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
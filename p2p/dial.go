@@ -76,6 +76,7 @@ var (
 	errAlreadyConnected = errors.New("already connected")
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNetRestrict      = errors.New("not contained in netrestrict list")
+	errBannedNode       = errors.New("banned")
 	errNoPort           = errors.New("node does not provide TCP port")
 )
 
@@ -135,6 +136,7 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	bannedUntil    func(enode.ID) (time.Time, bool) // ban expiry of a node, may be nil
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -409,6 +411,15 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 		return errRecentlyDialed
 	}
 
+	if d.bannedUntil != nil {
+		if until, banned := d.bannedUntil(n.ID()); banned {
+			// Keep the node in the history until the ban expires, so that static
+			// nodes are added back to the pool once it's lifted
+			d.history.add(string(n.ID().Bytes()), d.clock.Now().Add(time.Until(until)))
+			return errBannedNode
+		}
+	}
+
 	return nil
 }
 
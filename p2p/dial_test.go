@@ -152,6 +152,36 @@ func TestDialSchedNetRestrict(t *testing.T) {
 	})
 }
 
+// This test checks that banned candidates are not dialed.
+func TestDialSchedBanned(t *testing.T) {
+	t.Parallel()
+
+	nodes := []*enode.Node{
+		newNode(uintID(0x01), "127.0.0.1:30303"),
+		newNode(uintID(0x02), "127.0.0.2:30303"),
+		newNode(uintID(0x03), "127.0.0.3:30303"),
+	}
+	config := dialConfig{
+		maxActiveDials: 10,
+		maxDialPeers:   10,
+		bannedUntil: func(id enode.ID) (time.Time, bool) {
+			return time.Now().Add(time.Hour), id == uintID(0x02)
+		},
+	}
+	runDialTest(t, config, []dialTestRound{
+		{
+			discovered:   nodes,
+			wantNewDials: []*enode.Node{nodes[0], nodes[2]},
+		},
+		{
+			succeeded: []enode.ID{
+				nodes[0].ID(),
+				nodes[2].ID(),
+			},
+		},
+	})
+}
+
 // This test checks that static dials work and obey the limits.
 func TestDialSchedStaticDial(t *testing.T) {
 	t.Parallel()
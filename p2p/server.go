@@ -70,6 +70,7 @@ var (
 	errServerStopped       = errors.New("server stopped")
 	errEncHandshakeError   = errors.New("rlpx enc error")
 	errProtoHandshakeError = errors.New("rlpx proto error")
+	errBannedPeer          = errors.New("peer is banned")
 )
 
 // Config holds Server options.
@@ -216,6 +217,10 @@ type Server struct {
 
 	// State of run loop and listenLoop.
 	inboundHistory expHeap
+
+	// Peers refused until their ban expires.
+	banLock sync.Mutex
+	banned  map[enode.ID]time.Time
 }
 
 type peerOpFunc func(map[enode.ID]*Peer)
@@ -440,6 +445,48 @@ func (srv *Server) RemoveTrustedPeer(node *enode.Node) {
 	srv.SetMaxPeers(srv.MaxPeers)
 }
 
+// BanPeer disconnects the peer with the given id, if connected, and refuses any
+// connection to or from it until the ban expires. Trusted peers aren't refused.
+func (srv *Server) BanPeer(id enode.ID, duration time.Duration) {
+	now := time.Now()
+
+	srv.banLock.Lock()
+	if srv.banned == nil {
+		srv.banned = make(map[enode.ID]time.Time)
+	}
+
+	// Forget the expired bans, so the map only holds the active ones
+	for banned, until := range srv.banned {
+		if now.After(until) {
+			delete(srv.banned, banned)
+		}
+	}
+
+	srv.banned[id] = now.Add(duration)
+	srv.banLock.Unlock()
+
+	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
+		if peer := peers[id]; peer != nil && !peer.rw.is(trustedConn) {
+			peer.Disconnect(DiscUselessPeer)
+		}
+	})
+}
+
+// BannedUntil returns the time the ban of the peer with the given id expires,
+// if it's banned.
+func (srv *Server) BannedUntil(id enode.ID) (time.Time, bool) {
+	srv.banLock.Lock()
+	defer srv.banLock.Unlock()
+
+	until, ok := srv.banned[id]
+	if ok && time.Now().After(until) {
+		delete(srv.banned, id)
+		return time.Time{}, false
+	}
+
+	return until, ok
+}
+
 // SubscribeEvents subscribes the given channel to peer events
 func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
@@ -688,6 +735,7 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		bannedUntil:    srv.BannedUntil,
 	}
 	if srv.discv4 != nil {
 		config.resolver = srv.discv4
@@ -923,11 +971,19 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscAlreadyConnected
 	case c.node.ID() == srv.localnode.ID():
 		return DiscSelf
+	case !c.is(trustedConn) && srv.isBanned(c.node.ID()):
+		return errBannedPeer
 	default:
 		return nil
 	}
 }
 
+// isBanned reports whether the peer with the given id is banned.
+func (srv *Server) isBanned(id enode.ID) bool {
+	_, banned := srv.BannedUntil(id)
+	return banned
+}
+
 func (srv *Server) addPeerChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
 	// Drop connections with no matching protocols.
 	if len(srv.Protocols) > 0 && countMatchingProtocols(srv.Protocols, c.caps) == 0 {
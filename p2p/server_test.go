@@ -341,6 +341,77 @@ func TestServerAtCap(t *testing.T) {
 	}
 }
 
+func TestServerBanPeer(t *testing.T) {
+	trustedNode := newkey()
+	trustedID := enode.PubkeyToIDV4(&trustedNode.PublicKey)
+
+	srv := &Server{
+		Config: Config{
+			PrivateKey:   newkey(),
+			MaxPeers:     10,
+			NoDial:       true,
+			NoDiscovery:  true,
+			TrustedNodes: []*enode.Node{newNode(trustedID, "")},
+			Logger:       testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+
+	defer srv.Stop()
+
+	newconn := func(id enode.ID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&trustedNode.PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), id)
+
+		return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+	}
+
+	bannedID := randomID()
+	srv.BanPeer(bannedID, time.Hour)
+
+	if _, banned := srv.BannedUntil(bannedID); !banned {
+		t.Fatal("peer not banned")
+	}
+
+	if err := srv.checkpoint(newconn(bannedID), srv.checkpointPostHandshake); err != errBannedPeer {
+		t.Error("wrong error for banned conn:", err)
+	}
+
+	// Trusted peers are never refused
+	srv.BanPeer(trustedID, time.Hour)
+
+	if err := srv.checkpoint(newconn(trustedID), srv.checkpointPostHandshake); err != nil {
+		t.Error("unexpected error for trusted conn:", err)
+	}
+
+	// Expired bans are lifted
+	expiredID := randomID()
+	srv.BanPeer(expiredID, -time.Second)
+
+	if _, banned := srv.BannedUntil(expiredID); banned {
+		t.Error("expired ban still active")
+	}
+
+	if err := srv.checkpoint(newconn(expiredID), srv.checkpointPostHandshake); err != nil {
+		t.Error("unexpected error for conn after the ban:", err)
+	}
+
+	// Expired bans are forgotten when another peer is banned
+	srv.BanPeer(expiredID, -time.Second)
+	srv.BanPeer(randomID(), time.Hour)
+
+	srv.banLock.Lock()
+	_, kept := srv.banned[expiredID]
+	srv.banLock.Unlock()
+
+	if kept {
+		t.Error("expired ban not pruned")
+	}
+}
+
 func TestServerPeerLimits(t *testing.T) {
 	srvkey := newkey()
 	clientkey := newkey()
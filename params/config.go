@@ -1113,6 +1113,12 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		}
 	}
 
+	if c.Zena != nil {
+		if err := c.Zena.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@
 package params
 
 import (
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -194,3 +195,91 @@ func TestZenaKeyValueConfigHelper(t *testing.T) {
 	assert.Equal(t, zenaKeyValueConfigHelper(burntContract, 41824608), "0x617b94CCCC2511808A3C9478ebb96f455CF167aA")
 	assert.Equal(t, zenaKeyValueConfigHelper(burntContract, 41824608+1), "0x617b94CCCC2511808A3C9478ebb96f455CF167aA")
 }
+
+func TestZenaConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *ZenaConfig {
+		return &ZenaConfig{
+			Period:                     map[string]uint64{"0": 2},
+			ProducerDelay:              map[string]uint64{"0": 6, "38189056": 4},
+			Sprint:                     map[string]uint64{"0": 64, "38189056": 16},
+			BackupMultiplier:           map[string]uint64{"0": 2},
+			StateSyncConfirmationDelay: map[string]uint64{"44934656": 128},
+			JaipurBlock:                big.NewInt(23850000),
+			DelhiBlock:                 big.NewInt(38189056),
+			IndoreBlock:                big.NewInt(44934656),
+		}
+	}
+
+	assert.NilError(t, valid().Validate())
+	assert.NilError(t, ZenaTestChainConfig.Zena.Validate())
+
+	// sprint length change off a sprint boundary
+	config := valid()
+	config.Sprint = map[string]uint64{"0": 64, "100": 16}
+	assert.ErrorContains(t, config.Validate(), "not a sprint boundary")
+
+	// sprint length change off a boundary of the new length
+	config = valid()
+	config.Sprint = map[string]uint64{"0": 16, "64": 48}
+	assert.ErrorContains(t, config.Validate(), "not a multiple of the new length")
+
+	config = valid()
+	config.Period = map[string]uint64{"16": 2}
+	assert.ErrorContains(t, config.Validate(), "period is not defined at block 0")
+
+	config = valid()
+	config.BurntContract = map[string]string{"london": "0x000000000000000000000000000000000000dead"}
+	assert.ErrorContains(t, config.Validate(), `burntContract has an invalid block number "london"`)
+
+	config = valid()
+	config.DelhiBlock = big.NewInt(100)
+	assert.ErrorContains(t, config.Validate(), "jaipurBlock enabled at block 23850000, but delhiBlock enabled at block 100")
+
+	config = valid()
+	config.StateSyncConfirmationDelay = nil
+	assert.ErrorContains(t, config.Validate(), "stateSyncConfirmationDelay must be defined")
+
+	// the zena config is validated along with the fork order
+	chainConfig := *ZenaTestChainConfig
+	chainConfig.Zena = valid()
+	chainConfig.Zena.Sprint = map[string]uint64{"0": 64, "100": 16}
+	assert.ErrorContains(t, chainConfig.CheckConfigForkOrder(), "invalid zena config")
+	assert.Assert(t, errors.Is(chainConfig.CheckConfigForkOrder(), ErrInvalidZenaConfig))
+}
+
+func TestZenaParamsAt(t *testing.T) {
+	t.Parallel()
+
+	config := &ZenaConfig{
+		Period:                     map[string]uint64{"0": 2},
+		Sprint:                     map[string]uint64{"0": 64, "128": 16},
+		StateSyncConfirmationDelay: map[string]uint64{"200": 128},
+		BurntContract:              map[string]string{"100": "0x000000000000000000000000000000000000dead"},
+		IndoreBlock:                big.NewInt(200),
+	}
+
+	params := config.ParamsAt(130)
+	assert.Equal(t, params.Sprint, uint64(16))
+	assert.Equal(t, params.SprintStart, uint64(128))
+	assert.Equal(t, params.Period, uint64(2))
+	assert.Equal(t, params.BurntContract, "0x000000000000000000000000000000000000dead")
+	assert.Equal(t, params.StateSyncConfirmationDelay, uint64(0))
+	assert.Equal(t, params.Indore, false)
+
+	params = config.ParamsAt(99)
+	assert.Equal(t, params.Sprint, uint64(64))
+	assert.Equal(t, params.SprintStart, uint64(64))
+	assert.Equal(t, params.BurntContract, "")
+
+	schedule := config.ParamsSchedule()
+	assert.Equal(t, len(schedule), 4)
+
+	for i, number := range []uint64{0, 100, 128, 200} {
+		assert.Equal(t, schedule[i].Number, number)
+	}
+
+	assert.Equal(t, schedule[3].Indore, true)
+	assert.Equal(t, schedule[3].StateSyncConfirmationDelay, uint64(128))
+}
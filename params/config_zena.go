@@ -0,0 +1,249 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// ErrInvalidZenaConfig is returned for zena configs whose parameters or fork
+// blocks are inconsistent.
+var ErrInvalidZenaConfig = errors.New("invalid zena config")
+
+// zenaBlockKeys parses and sorts the block numbers keying a zena config map.
+func zenaBlockKeys[T any](name string, field map[string]T) ([]uint64, error) {
+	keys := make([]uint64, 0, len(field))
+
+	for k := range field {
+		number, err := strconv.ParseUint(k, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s has an invalid block number %q", ErrInvalidZenaConfig, name, k)
+		}
+
+		keys = append(keys, number)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys, nil
+}
+
+// Validate checks that the block-keyed parameters and the fork blocks of the
+// zena config are well-formed and consistent with each other.
+func (c *ZenaConfig) Validate() error {
+	// Every block-keyed map must be keyed by block numbers
+	for _, field := range []struct {
+		name    string
+		values  map[string]uint64
+		genesis bool // whether the parameter must apply from genesis
+	}{
+		{"period", c.Period, true},
+		{"producerDelay", c.ProducerDelay, true},
+		{"sprint", c.Sprint, true},
+		{"backupMultiplier", c.BackupMultiplier, true},
+		{"stateSyncConfirmationDelay", c.StateSyncConfirmationDelay, false},
+	} {
+		keys, err := zenaBlockKeys(field.name, field.values)
+		if err != nil {
+			return err
+		}
+
+		if field.genesis && len(keys) > 0 && keys[0] != 0 {
+			return fmt.Errorf("%w: %s is not defined at block 0, first defined at block %d", ErrInvalidZenaConfig, field.name, keys[0])
+		}
+	}
+
+	if _, err := zenaBlockKeys("burntContract", c.BurntContract); err != nil {
+		return err
+	}
+
+	if _, err := zenaBlockKeys("overrideStateSyncRecords", c.OverrideStateSyncRecords); err != nil {
+		return err
+	}
+
+	if _, err := zenaBlockKeys("blockAlloc", c.BlockAlloc); err != nil {
+		return err
+	}
+
+	if err := c.validateSprints(); err != nil {
+		return err
+	}
+
+	if err := c.validateForkOrder(); err != nil {
+		return err
+	}
+
+	// From Indore the state sync window is computed from the confirmation delay
+	if c.IndoreBlock != nil {
+		keys, _ := zenaBlockKeys("stateSyncConfirmationDelay", c.StateSyncConfirmationDelay)
+		if len(keys) == 0 || keys[0] > c.IndoreBlock.Uint64() {
+			return fmt.Errorf("%w: stateSyncConfirmationDelay must be defined at or before indoreBlock %v", ErrInvalidZenaConfig, c.IndoreBlock)
+		}
+	}
+
+	return nil
+}
+
+// validateSprints checks that every sprint length change happens on a block
+// which both ends a sprint of the previous length and starts a sprint of the
+// new one, otherwise sprint boundaries would be skipped.
+func (c *ZenaConfig) validateSprints() error {
+	keys, _ := zenaBlockKeys("sprint", c.Sprint)
+
+	var prev uint64
+
+	for i, number := range keys {
+		length := c.Sprint[strconv.FormatUint(number, 10)]
+
+		// A zero sprint at genesis is replaced by the default one by the engine
+		if length == 0 {
+			if i > 0 {
+				return fmt.Errorf("%w: sprint length is 0 at block %d", ErrInvalidZenaConfig, number)
+			}
+
+			continue
+		}
+
+		if prev != 0 && number%prev != 0 {
+			return fmt.Errorf("%w: sprint length changes from %d to %d at block %d, which is not a sprint boundary", ErrInvalidZenaConfig, prev, length, number)
+		}
+
+		if number%length != 0 {
+			return fmt.Errorf("%w: sprint length changes to %d at block %d, which is not a multiple of the new length", ErrInvalidZenaConfig, length, number)
+		}
+
+		prev = length
+	}
+
+	return nil
+}
+
+// validateForkOrder checks that the zena forks, when scheduled, are
+// scheduled in order.
+func (c *ZenaConfig) validateForkOrder() error {
+	type fork struct {
+		name  string
+		block *big.Int
+	}
+
+	var last fork
+
+	for _, cur := range []fork{
+		{name: "jaipurBlock", block: c.JaipurBlock},
+		{name: "delhiBlock", block: c.DelhiBlock},
+		{name: "indoreBlock", block: c.IndoreBlock},
+		{name: "ahmedabadBlock", block: c.AhmedabadBlock},
+	} {
+		if cur.block == nil {
+			continue
+		}
+
+		if last.block != nil && last.block.Cmp(cur.block) > 0 {
+			return fmt.Errorf("%w: %v enabled at block %v, but %v enabled at block %v", ErrInvalidZenaConfig,
+				last.name, last.block, cur.name, cur.block)
+		}
+
+		last = cur
+	}
+
+	return nil
+}
+
+// ZenaParams are the zena parameters in effect at a given block.
+type ZenaParams struct {
+	Number                     uint64 `json:"number"`
+	Period                     uint64 `json:"period"`
+	ProducerDelay              uint64 `json:"producerDelay"`
+	Sprint                     uint64 `json:"sprint"`
+	SprintStart                uint64 `json:"sprintStart"` // First block of the sprint containing the block
+	BackupMultiplier           uint64 `json:"backupMultiplier"`
+	StateSyncConfirmationDelay uint64 `json:"stateSyncConfirmationDelay"`
+	BurntContract              string `json:"burntContract,omitempty"`
+	Jaipur                     bool   `json:"jaipur"`
+	Delhi                      bool   `json:"delhi"`
+	Indore                     bool   `json:"indore"`
+	Ahmedabad                  bool   `json:"ahmedabad"`
+}
+
+// ParamsAt returns the zena parameters in effect at the given block.
+// Parameters missing from the config, or not in effect yet, are left empty.
+func (c *ZenaConfig) ParamsAt(number uint64) *ZenaParams {
+	p := &ZenaParams{
+		Number:    number,
+		Jaipur:    c.IsJaipur(new(big.Int).SetUint64(number)),
+		Delhi:     c.IsDelhi(new(big.Int).SetUint64(number)),
+		Indore:    c.IsIndore(new(big.Int).SetUint64(number)),
+		Ahmedabad: c.IsAhmedabad(new(big.Int).SetUint64(number)),
+	}
+
+	if len(c.Period) > 0 {
+		p.Period = c.CalculatePeriod(number)
+	}
+
+	if len(c.ProducerDelay) > 0 {
+		p.ProducerDelay = c.CalculateProducerDelay(number)
+	}
+
+	if len(c.Sprint) > 0 {
+		p.Sprint = c.CalculateSprint(number)
+	}
+
+	if len(c.BackupMultiplier) > 0 {
+		p.BackupMultiplier = c.CalculateBackupMultiplier(number)
+	}
+
+	// The state sync delay and the burnt contract only apply from their first block
+	if keys, _ := zenaBlockKeys("", c.StateSyncConfirmationDelay); len(keys) > 0 && number >= keys[0] {
+		p.StateSyncConfirmationDelay = c.CalculateStateSyncDelay(number)
+	}
+
+	if keys, _ := zenaBlockKeys("", c.BurntContract); len(keys) > 0 && number >= keys[0] {
+		p.BurntContract = c.CalculateBurntContract(number)
+	}
+
+	if p.Sprint > 0 {
+		p.SprintStart = number - number%p.Sprint
+	}
+
+	return p
+}
+
+// ParamsSchedule returns the zena parameters in effect from every block at
+// which a parameter changes or a fork activates, in ascending order.
+func (c *ZenaConfig) ParamsSchedule() []*ZenaParams {
+	blocks := map[uint64]struct{}{0: {}}
+
+	for _, field := range []map[string]uint64{c.Period, c.ProducerDelay, c.Sprint, c.BackupMultiplier, c.StateSyncConfirmationDelay} {
+		keys, _ := zenaBlockKeys("", field)
+		for _, number := range keys {
+			blocks[number] = struct{}{}
+		}
+	}
+
+	keys, _ := zenaBlockKeys("", c.BurntContract)
+	for _, number := range keys {
+		blocks[number] = struct{}{}
+	}
+
+	for _, fork := range []*big.Int{c.JaipurBlock, c.DelhiBlock, c.IndoreBlock, c.AhmedabadBlock} {
+		if fork != nil {
+			blocks[fork.Uint64()] = struct{}{}
+		}
+	}
+
+	numbers := make([]uint64, 0, len(blocks))
+	for number := range blocks {
+		numbers = append(numbers, number)
+	}
+
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	schedule := make([]*ZenaParams, 0, len(numbers))
+	for _, number := range numbers {
+		schedule = append(schedule, c.ParamsAt(number))
+	}
+
+	return schedule
+}